@@ -0,0 +1,374 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*   I m p l e m e n t a t i o n   */
+
+// Count returns the number of rows where field name is set, i.e. not a nil
+// pointer, like COUNT(column) does in SQL.
+// Count does not move the current row of the iteration.
+// Count returns an error if the rows are closed or the field does not exist.
+func (r *StructRows) Count(name string) (int, error) {
+	f, err := r.column(name, newColumnOptions(nil))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not count column")
+	}
+	n := 0
+	for i := 0; i < r.Len(); i++ {
+		if r.cell(f, i).IsValid() {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Sum returns the sum of the values of field name across all rows, typed
+// after the field, e.g. an int32 for an int32 field or a time.Duration for a
+// time.Duration field. Nil pointers are skipped. Sum returns the zero-value of
+// the field type if there are no values.
+// Sum does not move the current row of the iteration.
+// Sum returns an error if the rows are closed, the field does not exist, is
+// unexported, is not numeric, or if the sum overflows the field type.
+func (r *StructRows) Sum(name string) (interface{}, error) {
+	f, values, err := r.aggregated(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sum column")
+	}
+	sum := reflect.New(f).Elem()
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var acc int64
+		for _, v := range values {
+			x := v.Int()
+			if (x > 0 && acc > math.MaxInt64-x) || (x < 0 && acc < math.MinInt64-x) || sum.OverflowInt(acc+x) {
+				return nil, errors.Errorf("could not sum column %s: %s overflow", name, f)
+			}
+			acc += x
+		}
+		sum.SetInt(acc)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var acc uint64
+		for _, v := range values {
+			x := v.Uint()
+			if acc > math.MaxUint64-x || sum.OverflowUint(acc+x) {
+				return nil, errors.Errorf("could not sum column %s: %s overflow", name, f)
+			}
+			acc += x
+		}
+		sum.SetUint(acc)
+	case reflect.Float32, reflect.Float64:
+		var acc float64
+		for _, v := range values {
+			acc += v.Float()
+		}
+		sum.SetFloat(acc)
+	default:
+		return nil, errors.Errorf("could not sum column %s of type %s", name, f)
+	}
+	return sum.Interface(), nil
+}
+
+// Avg returns the average of the values of field name across all rows. It is
+// a float64 for numeric fields, a time.Duration for time.Duration fields and a
+// time.Time for time.Time fields. Nil pointers are skipped. Avg returns nil if
+// there are no values.
+// Avg does not move the current row of the iteration.
+// Avg returns an error if the rows are closed, the field does not exist, is
+// unexported or if its type cannot be averaged.
+func (r *StructRows) Avg(name string) (interface{}, error) {
+	f, values, err := r.aggregated(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not average column")
+	}
+	if !isAveraged(f) {
+		return nil, errors.Errorf("could not average column %s of type %s", name, f)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	n := float64(len(values))
+	switch {
+	case f == reflect.TypeOf(time.Time{}):
+		t0 := values[0].Interface().(time.Time)
+		var acc float64
+		for _, v := range values {
+			acc += float64(v.Interface().(time.Time).Sub(t0))
+		}
+		return t0.Add(time.Duration(acc / n)), nil
+	case f == reflect.TypeOf(time.Duration(0)):
+		var acc float64
+		for _, v := range values {
+			acc += float64(v.Int())
+		}
+		return time.Duration(acc / n), nil
+	}
+	var acc float64
+	for _, v := range values {
+		switch {
+		case v.CanInt():
+			acc += float64(v.Int())
+		case v.CanUint():
+			acc += float64(v.Uint())
+		default:
+			acc += v.Float()
+		}
+	}
+	return acc / n, nil
+}
+
+// Min returns the smallest value of field name across all rows, typed after
+// the field. Numbers, strings, durations and times can be compared. Nil
+// pointers are skipped. Min returns nil if there are no values.
+// Min does not move the current row of the iteration.
+// Min returns an error if the rows are closed, the field does not exist, is
+// unexported or if its type cannot be ordered.
+func (r *StructRows) Min(name string) (interface{}, error) {
+	return r.extremum(name, false)
+}
+
+// Max returns the largest value of field name across all rows, typed after
+// the field. Numbers, strings, durations and times can be compared. Nil
+// pointers are skipped. Max returns nil if there are no values.
+// Max does not move the current row of the iteration.
+// Max returns an error if the rows are closed, the field does not exist, is
+// unexported or if its type cannot be ordered.
+func (r *StructRows) Max(name string) (interface{}, error) {
+	return r.extremum(name, true)
+}
+
+// Pluck fills the slice pointed to by dest with the values of field name
+// across all rows, e.g. Pluck("ID", &ids) to build an IN() query. Values are
+// converted to the element type of dest when they differ, e.g. from int to uint
+// or to string. Nil pointers become zero-values, or nil if dest holds pointers.
+// Option Tagged identifies the column by its struct tag name instead.
+// Pluck does not move the current row of the iteration.
+// Pluck returns an error if the rows are closed, the field does not exist or is
+// unexported, dest is not a pointer to a slice or if a value cannot be converted.
+func (r *StructRows) Pluck(name string, dest interface{}, opts ...ColumnOption) error {
+	d := reflect.ValueOf(dest)
+	if d.Kind() != reflect.Ptr || d.IsNil() || d.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("could not pluck column %s into %T: want a pointer to a slice", name, dest)
+	}
+	f, err := r.column(name, newColumnOptions(opts))
+	if err != nil {
+		return errors.Wrap(err, "could not pluck column")
+	}
+	values := reflect.MakeSlice(d.Elem().Type(), r.Len(), r.Len())
+	for i := 0; i < r.Len(); i++ {
+		if err := coerce(values.Index(i), r.cell(f, i)); err != nil {
+			return errors.Wrapf(err, "could not pluck column %s of row %d", name, i)
+		}
+	}
+	d.Elem().Set(values)
+	return nil
+}
+
+/*   U n e x p o r t e d   */
+
+// aggregated returns the values of field name across all rows, skipping nil
+// pointers, along with their type. It returns ErrNotExported if the values of
+// field name cannot be read, see AllowUnexported.
+func (r *StructRows) aggregated(name string) (reflect.Type, []reflect.Value, error) {
+	f, err := r.column(name, newColumnOptions(nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	t := f.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	values := make([]reflect.Value, 0, r.Len())
+	for i := 0; i < r.Len(); i++ {
+		if v := r.cell(f, i); v.IsValid() {
+			if !v.CanInterface() {
+				return nil, nil, errors.Wrapf(ErrNotExported, "column %s", name)
+			}
+			values = append(values, v)
+		}
+	}
+	return t, values, nil
+}
+
+// extremum returns the largest value of field name if largest is true, or the
+// smallest one otherwise.
+func (r *StructRows) extremum(name string, largest bool) (interface{}, error) {
+	f, values, err := r.aggregated(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get extremum of column")
+	}
+	if !isOrdered(f) {
+		return nil, errors.Errorf("could not compare values of column %s of type %s", name, f)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if lessValue(m, v) == largest {
+			m = v
+		}
+	}
+	return m.Interface(), nil
+}
+
+// isAveraged returns true if values of type t can be averaged.
+func isAveraged(t reflect.Type) bool {
+	if t == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isOrdered returns true if values of type t can be compared by lessValue.
+func isOrdered(t reflect.Type) bool {
+	return isAveraged(t) || t.Kind() == reflect.String
+}
+
+// lessValue returns true if v is strictly smaller than x, both being of the same
+// ordered type.
+func lessValue(v, x reflect.Value) bool {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		return v.Interface().(time.Time).Before(x.Interface().(time.Time))
+	}
+	switch {
+	case v.CanInt():
+		return v.Int() < x.Int()
+	case v.CanUint():
+		return v.Uint() < x.Uint()
+	case v.CanFloat():
+		return v.Float() < x.Float()
+	}
+	return v.String() < x.String()
+}
+
+// coerce sets the settable value v to x, converting it between numeric kinds,
+// strings and numbers when their types differ. v is left to its zero-value if
+// x is the zero Value, e.g. a nil pointer. coerce returns ErrNotExported if x
+// was obtained from an unexported field.
+func coerce(v, x reflect.Value) error {
+	if !x.IsValid() {
+		return nil
+	}
+	if !x.CanInterface() {
+		return ErrNotExported
+	}
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if x.Type().AssignableTo(v.Type()) {
+		v.Set(x)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch {
+		case x.CanInt():
+			n = x.Int()
+		case x.CanUint():
+			if x.Uint() > math.MaxInt64 {
+				return errors.Errorf("value %d overflows %s", x.Uint(), v.Type())
+			}
+			n = int64(x.Uint())
+		case x.Kind() == reflect.String:
+			i, err := strconv.ParseInt(x.String(), 10, 64)
+			if err != nil {
+				return err
+			}
+			n = i
+		default:
+			return errors.Errorf("cannot convert %s to %s", x.Type(), v.Type())
+		}
+		if v.OverflowInt(n) {
+			return errors.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		switch {
+		case x.CanUint():
+			n = x.Uint()
+		case x.CanInt():
+			if x.Int() < 0 {
+				return errors.Errorf("value %d overflows %s", x.Int(), v.Type())
+			}
+			n = uint64(x.Int())
+		case x.Kind() == reflect.String:
+			u, err := strconv.ParseUint(x.String(), 10, 64)
+			if err != nil {
+				return err
+			}
+			n = u
+		default:
+			return errors.Errorf("cannot convert %s to %s", x.Type(), v.Type())
+		}
+		if v.OverflowUint(n) {
+			return errors.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case x.CanFloat():
+			v.SetFloat(x.Float())
+		case x.CanInt():
+			v.SetFloat(float64(x.Int()))
+		case x.CanUint():
+			v.SetFloat(float64(x.Uint()))
+		case x.Kind() == reflect.String:
+			f, err := strconv.ParseFloat(x.String(), 64)
+			if err != nil {
+				return err
+			}
+			v.SetFloat(f)
+		default:
+			return errors.Errorf("cannot convert %s to %s", x.Type(), v.Type())
+		}
+		return nil
+	case reflect.String:
+		if s, ok := x.Interface().(fmt.Stringer); ok {
+			v.SetString(s.String())
+			return nil
+		}
+		switch {
+		case x.Kind() == reflect.String:
+			v.SetString(x.String())
+		case x.CanInt():
+			v.SetString(strconv.FormatInt(x.Int(), 10))
+		case x.CanUint():
+			v.SetString(strconv.FormatUint(x.Uint(), 10))
+		case x.CanFloat():
+			v.SetString(strconv.FormatFloat(x.Float(), 'g', -1, 64))
+		case x.Kind() == reflect.Bool:
+			v.SetString(strconv.FormatBool(x.Bool()))
+		default:
+			return errors.Errorf("cannot convert %s to %s", x.Type(), v.Type())
+		}
+		return nil
+	}
+	if x.Type().ConvertibleTo(v.Type()) {
+		v.Set(x.Convert(v.Type()))
+		return nil
+	}
+	return errors.Errorf("cannot convert %s to %s", x.Type(), v.Type())
+}
@@ -0,0 +1,243 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAggregated struct {
+	Name    string
+	Count   int32
+	Size    uint8
+	Ratio   float64
+	Uptime  time.Duration
+	Created time.Time
+	Score   *int
+	Enabled bool
+}
+
+func testAggregatedRows(t *testing.T, ts []testAggregated) *StructRows {
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	return rows
+}
+
+func TestAggregate(t *testing.T) {
+	score := 7
+	created := time.Date(2021, 6, 25, 0, 0, 0, 0, time.UTC)
+	rows := testAggregatedRows(t, []testAggregated{
+		{Name: "Nginx", Count: 5, Size: 100, Ratio: 0.5, Uptime: time.Hour, Created: created, Score: &score},
+		{Name: "Apache", Count: -1, Size: 50, Ratio: 1.5, Uptime: 3 * time.Hour, Created: created.Add(48 * time.Hour)},
+		{Name: "Caddy", Count: 2, Size: 0, Ratio: 1, Uptime: 2 * time.Hour, Created: created.Add(24 * time.Hour)},
+	})
+	defer rows.Close()
+
+	n, err := rows.Count("Name")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, n)
+	n, err = rows.Count("Score")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, n)
+
+	sum, err := rows.Sum("Count")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(6), sum)
+	sum, err = rows.Sum("Ratio")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3.0, sum)
+	sum, err = rows.Sum("Uptime")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 6*time.Hour, sum)
+	sum, err = rows.Sum("Score")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 7, sum)
+	sum, err = rows.Sum("Size")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint8(150), sum)
+	_, err = rows.Sum("Name")
+	assert.NotEqual(t, nil, err)
+	_, err = rows.Sum("Created")
+	assert.NotEqual(t, nil, err)
+
+	avg, err := rows.Avg("Count")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2.0, avg)
+	avg, err = rows.Avg("Size")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 50.0, avg)
+	avg, err = rows.Avg("Uptime")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2*time.Hour, avg)
+	avg, err = rows.Avg("Created")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, created.Add(24*time.Hour), avg)
+	_, err = rows.Avg("Enabled")
+	assert.NotEqual(t, nil, err)
+
+	min, err := rows.Min("Count")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(-1), min)
+	min, err = rows.Min("Name")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Apache", min)
+	min, err = rows.Min("Created")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, created, min)
+	max, err := rows.Max("Uptime")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3*time.Hour, max)
+	max, err = rows.Max("Size")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint8(100), max)
+	_, err = rows.Max("Enabled")
+	assert.NotEqual(t, nil, err)
+
+	_, err = rows.Max("Missing")
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+	assert.Equal(t, OutOfRange, rows.Index())
+}
+
+func TestAggregateEmpty(t *testing.T) {
+	rows := testAggregatedRows(t, []testAggregated{{Count: math.MaxInt32}, {Count: 1}})
+
+	_, err := rows.Sum("Count")
+	assert.NotEqual(t, nil, err)
+	sum, err := rows.Sum("Score")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, sum)
+	avg, err := rows.Avg("Score")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, avg)
+	max, err := rows.Max("Score")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, max)
+
+	rows.Close()
+	_, err = rows.Count("Name")
+	assert.Equal(t, ErrRowsClosed, errors.Cause(err))
+}
+
+func TestPluck(t *testing.T) {
+	type testStruct struct {
+		ID     int    `json:"id"`
+		Name   string `json:"name"`
+		Uptime time.Duration
+		Score  *int
+	}
+	score := 7
+	ts := []testStruct{
+		{ID: 1, Name: "Apache", Uptime: time.Hour, Score: &score},
+		{ID: 22, Name: "Nginx"},
+	}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	defer rows.Close()
+
+	var ids []uint
+	err = rows.Pluck("ID", &ids)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint{1, 22}, ids)
+
+	var keys []string
+	err = rows.Pluck("id", &keys, Tagged("json"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"1", "22"}, keys)
+	err = rows.Pluck("Uptime", &keys)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"1h0m0s", "0s"}, keys)
+
+	var scores []int64
+	err = rows.Pluck("Score", &scores)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int64{7, 0}, scores)
+	var ptrs []*int
+	err = rows.Pluck("Score", &ptrs)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []*int{&score, nil}, ptrs)
+
+	var small []int8
+	err = rows.Pluck("Uptime", &small)
+	assert.NotEqual(t, nil, err)
+	var names []int
+	err = rows.Pluck("Name", &names)
+	assert.NotEqual(t, nil, err)
+	err = rows.Pluck("ID", ids)
+	assert.NotEqual(t, nil, err)
+	err = rows.Pluck("Missing", &ids)
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+}
+
+func TestPluckRecursive(t *testing.T) {
+	type testProgram struct {
+		Name string `json:"name"`
+	}
+	type testStruct struct {
+		Name    string       `json:"name"`
+		Program *testProgram `json:"program"`
+	}
+	ts := []testStruct{
+		{Name: "Apache", Program: &testProgram{Name: "httpd"}},
+		{Name: "Nginx"},
+		{Name: "Caddy", Program: &testProgram{Name: "caddy"}},
+	}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	defer rows.Close()
+
+	cols, err := rows.Columns(Tagged("json"), Recursive())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"name", "program.name"}, cols)
+
+	var names []string
+	err = rows.Pluck("program.name", &names, Tagged("json"), Recursive())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"httpd", "", "caddy"}, names)
+
+	_, err = rows.Distinct("Program.Name")
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+}
+
+func TestUnexportedColumn(t *testing.T) {
+	type Server struct {
+		Name  string
+		count int
+	}
+	ts := []Server{{Name: "a", count: 2}, {Name: "b", count: 1}}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	defer rows.Close()
+
+	_, err = rows.Distinct("count")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	_, err = rows.Min("count")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	_, err = rows.Max("count")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	_, err = rows.Avg("count")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	_, err = rows.Sum("count")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	var counts []string
+	err = rows.Pluck("count", &counts)
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	assert.Equal(t, []string(nil), counts)
+	n, err := rows.Count("count")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, n)
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// truthiness maps the case-insensitive tokens accepted when text is set into
+// bool fields, besides the ones of strconv.ParseBool, to their value, see
+// ParseBool.
+var truthiness = map[string]bool{
+	"y":        true,
+	"yes":      true,
+	"on":       true,
+	"ok":       true,
+	"enable":   true,
+	"enabled":  true,
+	"n":        false,
+	"no":       false,
+	"off":      false,
+	"disable":  false,
+	"disabled": false,
+}
+
+/*   F u n c t i o n s   */
+
+// ParseBool returns the truth value of text x, as used when text is set into
+// bool fields, e.g. by Set, ScanFromEnv or ApplyDefaults. Leading and trailing
+// spaces and case are ignored.
+//
+//	true:  "1", "t", "true", "y", "yes", "on", "ok", "enable", "enabled"
+//	false: "0", "f", "false", "n", "no", "off", "disable", "disabled"
+//
+// Other numbers, e.g. "-1" or "0.5", are true unless zero. Any other text,
+// including an empty one, returns an error.
+func ParseBool(x string) (bool, error) {
+	x = strings.ToLower(strings.TrimSpace(x))
+	if b, err := strconv.ParseBool(x); err == nil {
+		return b, nil
+	}
+	if b, ok := truthiness[x]; ok {
+		return b, nil
+	}
+	if n, err := strconv.ParseFloat(x, 64); err == nil {
+		return isTruthy(n)
+	}
+	return false, errors.Errorf("invalid bool value %q; want: true, false, yes, no, on, off, enabled, disabled or a number", x)
+}
+
+/*   U n e x p o r t e d   */
+
+// isTruthy returns true if number n is not zero. NaN is neither true nor false.
+func isTruthy(n float64) (bool, error) {
+	if math.IsNaN(n) {
+		return false, errors.New("invalid bool value NaN")
+	}
+	return n != 0, nil
+}
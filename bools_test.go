@@ -0,0 +1,65 @@
+package structs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBool(t *testing.T) {
+	for _, x := range []string{"1", "t", "TRUE", "True", "y", "Yes", "on", "ok", "enable", "Enabled", " on ", "2", "-1", "0.5"} {
+		b, err := ParseBool(x)
+		assert.Equal(t, nil, err, x)
+		assert.Equal(t, true, b, x)
+	}
+	for _, x := range []string{"0", "f", "FALSE", "n", "No", "off", "disable", "Disabled", "0.0", "-0"} {
+		b, err := ParseBool(x)
+		assert.Equal(t, nil, err, x)
+		assert.Equal(t, false, b, x)
+	}
+	for _, x := range []string{"", " ", "maybe", "NaN", "yess"} {
+		_, err := ParseBool(x)
+		assert.NotEqual(t, nil, err, x)
+	}
+}
+
+func TestFieldSetBool(t *testing.T) {
+	type testStruct struct {
+		Enabled bool
+		Debug   *bool
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Enabled")
+	tests := []struct {
+		x    interface{}
+		want bool
+	}{
+		{1, true},
+		{0, false},
+		{-3, true},
+		{uint(1), true},
+		{uint8(0), false},
+		{1.0, true},
+		{0.0, false},
+		{float32(0.25), true},
+		{"on", true},
+		{"Disabled", false},
+		{"enabled", true},
+		{true, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, nil, f.Set(tt.x), "%v", tt.x)
+		assert.Equal(t, tt.want, ts.Enabled, "%v", tt.x)
+	}
+	assert.NotEqual(t, nil, f.Set("maybe"))
+	assert.NotEqual(t, nil, f.Set(""))
+	assert.Equal(t, true, ts.Enabled)
+	assert.NotEqual(t, nil, f.Set(math.NaN()))
+
+	assert.Equal(t, nil, s.Field("Debug").Set("yes"))
+	assert.Equal(t, true, *ts.Debug)
+}
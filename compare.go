@@ -0,0 +1,335 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// FieldComparison represents the result of comparing one field of two structs.
+// Pointer fields are compared and reported by the values they point to.
+type FieldComparison struct {
+	Name  string      // Path of the field prefixed with the top level struct name, e.g. "Server.Program.Name".
+	Path  string      // Path of the field from the top level struct, e.g. "Program.Name".
+	Equal bool        // Whether both field values are equal, according to Field.Equal.
+	A     interface{} // Value of the field in the first struct.
+	B     interface{} // Value of the field in the second struct.
+}
+
+// Comparer reports whether values a and b, both of the type it was registered
+// for, are semantically equal.
+type Comparer func(a, b interface{}) bool
+
+var (
+	comparers   = map[reflect.Type]Comparer{}
+	comparersMu sync.RWMutex
+)
+
+// Comparison represents the per-field results of comparing two structs, in the
+// declared order of fields. Nested structs are compared field by field.
+type Comparison []*FieldComparison
+
+/*   F u n c t i o n s   */
+
+// RegisterComparer registers the comparer fn for values of type t, consulted by
+// Field.Equal, Diff and CompareDetailed instead of the default comparison, e.g.
+// so that types with unexported internals, such as decimals or protobuf messages,
+// compare by semantic equality:
+//
+//	structs.RegisterComparer(reflect.TypeOf(decimal.Decimal{}), func(a, b interface{}) bool {
+//		return a.(decimal.Decimal).Equal(b.(decimal.Decimal))
+//	})
+//
+// Comparers also apply to pointers to t, when both are non-nil. Registering a nil
+// comparer removes it.
+func RegisterComparer(t reflect.Type, fn Comparer) {
+	comparersMu.Lock()
+	defer comparersMu.Unlock()
+	if fn == nil {
+		delete(comparers, t)
+		return
+	}
+	comparers[t] = fn
+}
+
+// CompareDetailed compares structs a and b field by field, recursively, and
+// returns the result for each of their exported fields, so that tests can report
+// exactly what differs. Both a and b must be singular structs of the same type.
+func CompareDetailed(a, b interface{}) (Comparison, error) {
+	ctx := "could not compare structs"
+	s1, err := New(a)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	s2, err := New(b)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	ctx = fmt.Sprintf("could not compare structs %q", s1.Name())
+	if s1.Type() != s2.Type() {
+		return nil, errors.Wrap(errors.Errorf("struct types differ: want: %q, got: %q", s1.Type(), s2.Type()), ctx)
+	}
+	if s1.Multiple() {
+		return nil, errors.Wrap(errors.Errorf("source is a slice of struct %s", s1.Name()), ctx)
+	}
+	if s2.Multiple() {
+		return nil, errors.Wrap(errors.Errorf("target is a slice of struct %s", s2.Name()), ctx)
+	}
+	return s1.compare(s2), nil
+}
+
+// CompareFields returns the paths of the fields of structs a and b which are not
+// equal, e.g. "Program.Name", in declared order, a middle ground between the
+// bool Compare and the full CompareDetailed report, suited to concise log lines.
+// Structs which cannot be compared field by field, e.g. of different types, are
+// reported as nil, like equal ones; use CompareDetailed to get the reason.
+func CompareFields(a, b interface{}) []string {
+	c, err := CompareDetailed(a, b)
+	if err != nil {
+		return nil
+	}
+	return c.Differences().Paths()
+}
+
+// PrintDiff writes the differences between structs a and b to w in a unified
+// diff style, for test failure output and change reviews: each field which is
+// not equal is printed as a pair of lines, its value in a prefixed with "-" and
+// its value in b prefixed with "+", under the names of its nested structs,
+// indented:
+//
+//	--- Server
+//	+++ Server
+//	- Port: 80
+//	+ Port: 8080
+//	  Program:
+//	-   Name: "Apache"
+//	+   Name: "Nginx"
+//
+// Values are rendered as json, see Sprint. Nothing is written when a and b are
+// equal. Both a and b must be singular structs of the same type, see
+// CompareDetailed.
+func PrintDiff(w io.Writer, a, b interface{}) error {
+	c, err := CompareDetailed(a, b)
+	if err != nil {
+		return err
+	}
+	diffs := c.Differences()
+	if len(diffs) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	name := strings.SplitN(diffs[0].Name, ".", 2)[0]
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", name, name)
+	var parents []string
+	for _, fc := range diffs {
+		path := strings.Split(fc.Path, ".")
+		n := 0
+		for n < len(parents) && n < len(path)-1 && parents[n] == path[n] {
+			n++
+		}
+		for i := n; i < len(path)-1; i++ {
+			fmt.Fprintf(&sb, "  %s%s:\n", strings.Repeat("  ", i), path[i])
+		}
+		parents = path[:len(path)-1]
+		indent := strings.Repeat("  ", len(parents))
+		leaf := path[len(path)-1]
+		fmt.Fprintf(&sb, "- %s%s: %s\n", indent, leaf, SprintCompact(fc.A))
+		fmt.Fprintf(&sb, "+ %s%s: %s\n", indent, leaf, SprintCompact(fc.B))
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Wrap(err, "could not write differences")
+	}
+	return nil
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Equal returns true if all the compared fields are equal.
+func (c Comparison) Equal() bool {
+	for _, fc := range c {
+		if !fc.Equal {
+			return false
+		}
+	}
+	return true
+}
+
+// Paths returns the paths of the compared fields.
+func (c Comparison) Paths() []string {
+	paths := make([]string, len(c))
+	for i, fc := range c {
+		paths[i] = fc.Path
+	}
+	return paths
+}
+
+// Differences returns the results of the fields which are not equal.
+func (c Comparison) Differences() Comparison {
+	diffs := make(Comparison, 0)
+	for _, fc := range c {
+		if !fc.Equal {
+			diffs = append(diffs, fc)
+		}
+	}
+	return diffs
+}
+
+// String returns one line per field which is not equal, e.g.
+// `Server.Name: "Roninzo" != "Apache"`.
+func (c Comparison) String() string {
+	lines := make([]string, 0)
+	for _, fc := range c.Differences() {
+		lines = append(lines, fc.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String returns the comparison of the field values, e.g.
+// `Server.Name: "Roninzo" != "Apache"`.
+func (fc *FieldComparison) String() string {
+	op := "!="
+	if fc.Equal {
+		op = "=="
+	}
+	return fmt.Sprintf("%s: %#v %s %#v", fc.Name, fc.A, op, fc.B)
+}
+
+/*   U n e x p o r t e d   */
+
+// compare returns the per-field comparison of structs s and c, which are of the
+// same type.
+func (s *StructValue) compare(c *StructValue) Comparison {
+	results := make(Comparison, 0)
+	for _, f1 := range s.Fields() {
+		if !f1.IsExported() {
+			continue
+		}
+		f2 := c.Field(f1.Index())
+		if f1.CanStruct() && f2.CanStruct() && comparerOf(f1.value.Type()) == nil {
+			results = append(results, f1.Struct().compare(f2.Struct())...)
+			continue
+		}
+		results = append(results, &FieldComparison{
+			Name:  s.root().Name() + "." + f1.Namespace(),
+			Path:  f1.Namespace(),
+			Equal: f1.Equal(f2),
+			A:     snapshot(f1.value),
+			B:     snapshot(f2.value),
+		})
+	}
+	return results
+}
+
+// comparerOf returns the comparer registered for type t, or for the type t
+// points to, if any.
+func comparerOf(t reflect.Type) Comparer {
+	comparersMu.RLock()
+	defer comparersMu.RUnlock()
+	if fn, ok := comparers[t]; ok {
+		return fn
+	}
+	if t.Kind() == reflect.Ptr {
+		return comparers[t.Elem()]
+	}
+	return nil
+}
+
+// compareWith compares values v and x, of the same type, using comparer fn.
+// Nil pointers are only equal to nil pointers.
+func compareWith(fn Comparer, v, x reflect.Value) bool {
+	if v.Kind() == reflect.Ptr && comparerOf(v.Type().Elem()) != nil {
+		if v.IsNil() || x.IsNil() {
+			return v.IsNil() && x.IsNil()
+		}
+		v, x = v.Elem(), x.Elem()
+	}
+	return fn(v.Interface(), x.Interface())
+}
+
+// timesEqual reports whether times a and b are the same instant, to the precision
+// set by SetTimePrecision, regardless of their locations and monotonic clock
+// readings.
+func timesEqual(a, b time.Time) bool {
+	if p := getTimePrecision(); p > 0 {
+		a, b = a.Truncate(p), b.Truncate(p)
+	}
+	return a.Equal(b)
+}
+
+// floatsEqual reports whether floats a and b are equal, within tolerance eps,
+// e.g. the one set by SetFloatEpsilon for comparisons. A zero eps requires
+// exact equality.
+func floatsEqual(a, b, eps float64) bool {
+	if eps > 0 {
+		return math.Abs(a-b) <= eps
+	}
+	return a == b
+}
+
+// valuesEqual reports whether values v and x, of the same type, are equal. Unlike
+// reflect.DeepEqual, slices, arrays and maps are compared element-wise and
+// pointers are dereferenced, so that elements follow the same rules as fields,
+// i.e. registered comparers, time precision and float tolerance eps apply, e.g.
+// to []*time.Time or map[string]float64 fields.
+func valuesEqual(v, x reflect.Value, eps float64) bool {
+	if fn := comparerOf(v.Type()); fn != nil && v.CanInterface() && x.CanInterface() {
+		return compareWith(fn, v, x)
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() || x.IsNil() {
+			return v.IsNil() && x.IsNil()
+		}
+		v, x = v.Elem(), x.Elem()
+		if v.Type() != x.Type() {
+			return false
+		}
+		return valuesEqual(v, x, eps)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() != x.IsNil() {
+			return false
+		}
+		if v.Len() != x.Len() {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !valuesEqual(v.Index(i), x.Index(i), eps) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if v.IsNil() != x.IsNil() || v.Len() != x.Len() {
+			return false
+		}
+		for _, k := range v.MapKeys() {
+			e := x.MapIndex(k)
+			if !e.IsValid() || !valuesEqual(v.MapIndex(k), e, eps) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		return floatsEqual(v.Float(), x.Float(), eps)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) && v.CanInterface() && x.CanInterface() {
+			return timesEqual(v.Interface().(time.Time), x.Interface().(time.Time))
+		}
+	}
+	if v.CanInterface() && x.CanInterface() {
+		return reflect.DeepEqual(v.Interface(), x.Interface())
+	}
+	return false
+}
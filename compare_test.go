@@ -0,0 +1,206 @@
+package structs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareDetailed(t *testing.T) {
+	type Program struct {
+		Name    string
+		Version *int
+	}
+	type Server struct {
+		Name    string
+		Port    int
+		Program Program
+		Tags    []string
+		hidden  bool
+	}
+
+	v1, v2 := 1, 2
+	a := Server{Name: "Roninzo", Port: 80, Program: Program{Name: "Apache", Version: &v1}, Tags: []string{"web"}}
+	b := Server{Name: "Roninzo", Port: 8080, Program: Program{Name: "Apache", Version: &v2}, Tags: []string{"web"}, hidden: true}
+
+	c, err := CompareDetailed(&a, &b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, len(c))
+	assert.Equal(t, false, c.Equal())
+	assert.Equal(t, Comparison{
+		{Name: "Server.Port", Path: "Port", Equal: false, A: 80, B: 8080},
+		{Name: "Server.Program.Version", Path: "Program.Version", Equal: false, A: 1, B: 2},
+	}, c.Differences())
+	assert.Equal(t, "Server.Port: 80 != 8080\nServer.Program.Version: 1 != 2", c.String())
+
+	b.Port, v2 = 80, 1
+	c, err = CompareDetailed(&a, &b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, c.Equal())
+	assert.Equal(t, "", c.String())
+
+	type Cluster struct {
+		Primary Program
+		Backup  Program
+	}
+	c, err = CompareDetailed(&Cluster{Backup: Program{Name: "Apache"}}, &Cluster{Backup: Program{Name: "Nginx"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Comparison{
+		{Name: "Cluster.Backup.Name", Path: "Backup.Name", Equal: false, A: "Apache", B: "Nginx"},
+	}, c.Differences())
+
+	_, err = CompareDetailed(&a, &Program{})
+	assert.EqualError(t, err, `could not compare structs "Server": struct types differ: want: "structs.Server", got: "structs.Program"`)
+}
+
+func TestCompareFields(t *testing.T) {
+	type Program struct {
+		Name string
+	}
+	type Server struct {
+		Name    string
+		Port    int
+		Program Program
+	}
+
+	a := Server{Name: "Roninzo", Port: 80, Program: Program{Name: "Apache"}}
+	b := Server{Name: "Roninzo", Port: 8080, Program: Program{Name: "Nginx"}}
+	assert.Equal(t, []string{"Port", "Program.Name"}, CompareFields(&a, &b))
+	assert.Equal(t, []string{}, CompareFields(a, a))
+	assert.Equal(t, []string(nil), CompareFields(&a, &Program{}))
+}
+
+func TestRegisterComparer(t *testing.T) {
+	type Money struct {
+		cents int64
+		unit  string
+	}
+	type Order struct {
+		ID    int
+		Total Money
+		Tax   *Money
+	}
+
+	RegisterComparer(reflect.TypeOf(Money{}), func(a, b interface{}) bool {
+		return a.(Money).cents == b.(Money).cents
+	})
+	defer RegisterComparer(reflect.TypeOf(Money{}), nil)
+
+	a := Order{ID: 1, Total: Money{100, "USD"}, Tax: &Money{10, "USD"}}
+	b := Order{ID: 1, Total: Money{100, "usd"}, Tax: &Money{10, "usd"}}
+	c, err := CompareDetailed(&a, &b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, c.Equal())
+	assert.Equal(t, 3, len(c))
+
+	s1, _ := New(&a)
+	s2, _ := New(&b)
+	assert.Equal(t, true, s1.Field("Total").Equal(s2.Field("Total")))
+	assert.Equal(t, true, s1.Field("Tax").Equal(s2.Field("Tax")))
+
+	b.Tax = nil
+	assert.Equal(t, false, s1.Field("Tax").Equal(s2.Field("Tax")))
+
+	RegisterComparer(reflect.TypeOf(Money{}), nil)
+	assert.Equal(t, false, s1.Field("Total").Equal(s2.Field("Total")))
+}
+
+func TestCompareElements(t *testing.T) {
+	type Event struct {
+		Names  []*string
+		Ptrs   map[string]*int
+		Times  []time.Time
+		Scores map[string]float64
+		Nested [][]*string
+	}
+
+	a1, a2, b := "a", "a", "b"
+	i1, i2 := 1, 1
+	now := time.Now()
+	e1 := Event{
+		Names:  []*string{&a1, nil},
+		Ptrs:   map[string]*int{"x": &i1},
+		Times:  []time.Time{now},
+		Scores: map[string]float64{"x": 0.1},
+		Nested: [][]*string{{&a1}},
+	}
+	e2 := Event{
+		Names:  []*string{&a2, nil},
+		Ptrs:   map[string]*int{"x": &i2},
+		Times:  []time.Time{now.Round(0).UTC()},
+		Scores: map[string]float64{"x": 0.1000001},
+		Nested: [][]*string{{&a2}},
+	}
+
+	s1, err := New(&e1)
+	assert.Equal(t, nil, err)
+	s2, err := New(&e2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, s1.Field("Names").Equal(s2.Field("Names")))
+	assert.Equal(t, true, s1.Field("Ptrs").Equal(s2.Field("Ptrs")))
+	assert.Equal(t, true, s1.Field("Times").Equal(s2.Field("Times")))
+	assert.Equal(t, false, s1.Field("Scores").Equal(s2.Field("Scores")))
+	assert.Equal(t, true, s1.Field("Nested").Equal(s2.Field("Nested")))
+
+	SetFloatEpsilon(1e-3)
+	defer SetFloatEpsilon(0)
+	assert.Equal(t, true, s1.Field("Scores").Equal(s2.Field("Scores")))
+
+	e2.Names[1] = &b
+	assert.Equal(t, false, s1.Field("Names").Equal(s2.Field("Names")))
+	e2.Ptrs["y"] = &i2
+	assert.Equal(t, false, s1.Field("Ptrs").Equal(s2.Field("Ptrs")))
+	e2.Nested = nil
+	assert.Equal(t, false, s1.Field("Nested").Equal(s2.Field("Nested")))
+}
+
+func TestPrintDiff(t *testing.T) {
+	type Version struct {
+		Major int
+		Minor int
+	}
+	type Program struct {
+		Name    string
+		Version Version
+	}
+	type Server struct {
+		Name    string
+		Count   int
+		Program Program
+		Tags    []string
+		Phase   complex128
+	}
+
+	a := Server{Name: "Roninzo", Count: 5, Program: Program{Name: "Apache", Version: Version{2, 4}}, Tags: []string{"web"}}
+	b := Server{Name: "Roninzo", Count: 6, Program: Program{Name: "Nginx", Version: Version{1, 4}}, Phase: 1i}
+
+	var sb strings.Builder
+	err := PrintDiff(&sb, &a, &b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `--- Server
++++ Server
+- Count: 5
++ Count: 6
+  Program:
+-   Name: "Apache"
++   Name: "Nginx"
+    Version:
+-     Major: 2
++     Major: 1
+- Tags: ["web"]
++ Tags: null
+- Phase: "(0+0i)"
++ Phase: "(0+1i)"
+`, sb.String())
+
+	sb.Reset()
+	err = PrintDiff(&sb, &a, &a)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", sb.String())
+
+	err = PrintDiff(&sb, &a, &Program{})
+	assert.EqualError(t, err, `could not compare structs "Server": struct types differ: want: "structs.Server", got: "structs.Program"`)
+}
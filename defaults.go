@@ -0,0 +1,131 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// DefaultFunc generates a default value, e.g. for a default struct tag declared
+// as `default:"env(PORT)"`, the DefaultFunc registered as "env" is called with
+// argument arg equal to "PORT". When it returns a string, the default value is
+// parsed according to the field kind, otherwise it is set as is.
+type DefaultFunc func(arg string) (interface{}, error)
+
+var (
+	defaultFuncs = map[string]DefaultFunc{
+		"now":  defaultNow,
+		"uuid": defaultUUID,
+		"env":  defaultEnv,
+	}
+	defaultFuncsMu sync.RWMutex
+	defaultFuncRe  = regexp.MustCompile(`^(\w+)\((.*)\)$`)
+)
+
+/*   F u n c t i o n s   */
+
+// RegisterDefaultFunc registers the generator fn under name, so that default
+// struct tags such as `default:"name()"` or `default:"name(arg)"` are computed
+// by ApplyDefaults. Registering a nil generator removes it. The following are
+// registered out of the box:
+//
+//	now()     the current local time.
+//	uuid()    a random (version 4) UUID string.
+//	env(KEY)  the value of environment variable KEY.
+func RegisterDefaultFunc(name string, fn DefaultFunc) {
+	defaultFuncsMu.Lock()
+	defer defaultFuncsMu.Unlock()
+	if fn == nil {
+		delete(defaultFuncs, name)
+		return
+	}
+	defaultFuncs[name] = fn
+}
+
+/*   U n e x p o r t e d   */
+
+// defaultFunc returns the generated value of the field default struct tag,
+// when it refers to a DefaultFunc, such as `default:"now()"`. Otherwise, ok
+// is false.
+func (f *StructField) defaultFunc() (x interface{}, ok bool, err error) {
+	tag, _ := f.Tag("default")
+	m := defaultFuncRe.FindStringSubmatch(strings.TrimSpace(tag))
+	if m == nil {
+		return nil, false, nil
+	}
+	defaultFuncsMu.RLock()
+	fn, found := defaultFuncs[m[1]]
+	defaultFuncsMu.RUnlock()
+	if !found {
+		return nil, true, errors.Errorf("unknown default func %q for field %s", m[1], f.FullName())
+	}
+	x, err = fn(strings.TrimSpace(m[2]))
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "could not compute default value %q for field %s", tag, f.FullName())
+	}
+	return x, true, nil
+}
+
+// decimalDefault returns the default value d of a field of integer type t in
+// base 10, so that defaults can also be written with a base prefix, such as
+// "0x1F", "0o17" or "0b101", see strconv.ParseInt. Range checks are left to
+// parseInt and parseUint. Nullable database types, such as sql.NullInt64, are
+// dealt with as their value type. Other values and durations are returned as is.
+func decimalDefault(t reflect.Type, d string) string {
+	if i, ok := nullType(t); ok {
+		t = t.Field(i).Type
+	}
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return d
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(d, 0, 64); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, err := strconv.ParseUint(d, 0, 64); err == nil {
+			return strconv.FormatUint(n, 10)
+		}
+	}
+	return d
+}
+
+// defaultNow is the DefaultFunc returning the current time.
+func defaultNow(string) (interface{}, error) {
+	return time.Now(), nil
+}
+
+// defaultUUID is the DefaultFunc returning a random (version 4) UUID.
+func defaultUUID(string) (interface{}, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// defaultEnv is the DefaultFunc returning the value of environment variable key.
+// It returns nil if the variable is not set.
+func defaultEnv(key string) (interface{}, error) {
+	if x, ok := os.LookupEnv(key); ok {
+		return x, nil
+	}
+	return nil, nil
+}
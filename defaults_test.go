@@ -0,0 +1,100 @@
+package structs
+
+import (
+	"database/sql"
+	"net/netip"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDefaultsFuncs(t *testing.T) {
+	type Server struct {
+		ID        string    `default:"uuid()"`
+		Port      int       `default:"env(TEST_DEFAULTS_PORT)"`
+		Host      string    `default:"env(TEST_DEFAULTS_UNSET)"`
+		Name      string    `default:"upper(roninzo)"`
+		CreatedAt time.Time `default:"now()"`
+		UpdatedAt time.Time `default:"now()"`
+	}
+
+	t.Setenv("TEST_DEFAULTS_PORT", "8080")
+	RegisterDefaultFunc("upper", func(arg string) (interface{}, error) {
+		return strings.ToUpper(arg), nil
+	})
+	defer RegisterDefaultFunc("upper", nil)
+
+	before := time.Now()
+	updated := time.Date(2021, time.August, 3, 13, 59, 35, 0, time.UTC)
+	server := Server{UpdatedAt: updated}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.ApplyDefaults())
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), server.ID)
+	assert.Equal(t, 8080, server.Port)
+	assert.Equal(t, "", server.Host)
+	assert.Equal(t, "RONINZO", server.Name)
+	assert.Equal(t, false, server.CreatedAt.Before(before))
+	assert.Equal(t, updated, server.UpdatedAt)
+
+	type Unknown struct {
+		Name string `default:"unknown()"`
+	}
+	s, err = New(&Unknown{})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, nil, s.ApplyDefaults())
+}
+
+func TestApplyDefaultsBases(t *testing.T) {
+	type Flags struct {
+		Mode    uint32        `default:"0o644"`
+		Mask    int           `default:"0x1F"`
+		Bits    uint8         `default:"0b101"`
+		Port    int           `default:"8080"`
+		Timeout time.Duration `default:"5s"`
+		Codes   []int         `default:"0x10,010,7"`
+		Over    int8          `default:"0x1FF"`
+	}
+	flags := Flags{Over: 1}
+	s, err := New(&flags)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.ApplyDefaults())
+	assert.Equal(t, Flags{Mode: 0644, Mask: 31, Bits: 5, Port: 8080, Timeout: 5 * time.Second, Codes: []int{16, 8, 7}, Over: 1}, flags)
+
+	flags = Flags{}
+	s, err = New(&flags)
+	assert.Equal(t, nil, err)
+	var overflow *OverflowError
+	assert.Equal(t, true, errors.As(s.ApplyDefaults(), &overflow))
+}
+
+func TestApplyDefaultsNullable(t *testing.T) {
+	type Row struct {
+		Name  sql.NullString `default:"dflt"`
+		Count sql.NullInt64  `default:"0x10"`
+		Ratio sql.NullFloat64
+	}
+	var row Row
+	s, err := New(&row)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.ApplyDefaults())
+	assert.Equal(t, sql.NullString{String: "dflt", Valid: true}, row.Name)
+	assert.Equal(t, sql.NullInt64{Int64: 16, Valid: true}, row.Count)
+	assert.Equal(t, sql.NullFloat64{}, row.Ratio)
+}
+
+func TestApplyDefaultsText(t *testing.T) {
+	type Host struct {
+		Addr  netip.Addr `default:"127.0.0.1"`
+		Level testLevel  `default:"debug"`
+	}
+	var host Host
+	s, err := New(&host)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.ApplyDefaults())
+	assert.Equal(t, Host{Addr: netip.MustParseAddr("127.0.0.1"), Level: 1}, host)
+}
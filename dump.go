@@ -0,0 +1,205 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// dumpObject is a JSON object whose members are encoded in order, e.g. struct
+// fields in declared order.
+type dumpObject []dumpMember
+
+// dumpMember is a single member of a dumpObject.
+type dumpMember struct {
+	key   string
+	value interface{}
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o dumpObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+/*   U n e x p o r t e d   */
+
+// sprint returns the JSON encoding of dest, indented or not. Values that the
+// json package cannot encode, such as complex numbers, channels, functions,
+// NaN or cyclic pointers, are rendered by walking dest instead, see dumpValue,
+// so that a rendering of the data is always returned rather than an error.
+func sprint(dest interface{}, indent bool) string {
+	marshal := func(x interface{}) ([]byte, error) {
+		if indent {
+			return json.MarshalIndent(x, " ", "\t")
+		}
+		return json.Marshal(x)
+	}
+	b, err := marshal(dest)
+	if err != nil {
+		b, err = marshal(dumpValue(reflect.ValueOf(dest), map[uintptr]bool{}))
+	}
+	if err != nil {
+		return fmt.Sprintf("%+v", dest)
+	}
+	return string(b)
+}
+
+// dumpValue returns a value the json package can encode in place of v, which
+// it cannot: struct fields are kept in declared order, following the json
+// struct tags, map keys are sorted, and complex numbers, NaN, infinities,
+// channels and functions are rendered as strings, the latter as their type.
+// Pointers already being walked, i.e. cycles, are rendered as "cycle".
+func dumpValue(v reflect.Value, seen map[uintptr]bool) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		if b, err := json.Marshal(v.Interface()); err == nil {
+			return json.RawMessage(b)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			if seen[v.Pointer()] {
+				return "cycle"
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		return dumpValue(v.Elem(), seen)
+	case reflect.Struct:
+		return dumpStruct(v, seen)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		o := make(dumpObject, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			o = append(o, dumpMember{key: fmt.Sprint(iter.Key()), value: dumpValue(iter.Value(), seen)})
+		}
+		sort.SliceStable(o, func(i, j int) bool { return o[i].key < o[j].key })
+		return o
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		a := make([]interface{}, v.Len())
+		for i := range a {
+			a[i] = dumpValue(v.Index(i), seen)
+		}
+		return a
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.String:
+		return v.String()
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'g', -1, v.Type().Bits())
+		}
+		return f
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Type().String()
+	}
+	return fmt.Sprint(v)
+}
+
+// dumpStruct returns the exported fields of struct v as a dumpObject, see
+// dumpValue. Fields of embedded structs without json name are promoted.
+func dumpStruct(v reflect.Value, seen map[uintptr]bool) dumpObject {
+	o := make(dumpObject, 0, v.NumField())
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		x := v.Field(i)
+		if sf.Anonymous && name == "" {
+			e := x
+			if e.Kind() == reflect.Ptr {
+				if e.IsNil() {
+					continue
+				}
+				e = e.Elem()
+			}
+			if e.Kind() == reflect.Struct {
+				o = append(o, dumpStruct(e, seen)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyDump(x) {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		o = append(o, dumpMember{key: name, value: dumpValue(x, seen)})
+	}
+	return o
+}
+
+// isEmptyDump returns true if v is empty as defined by the omitempty option of
+// json struct tags.
+func isEmptyDump(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	}
+	return v.IsZero() && v.Kind() != reflect.Struct
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDumpBase struct {
+	ID   int    `json:"id"`
+	Note string `json:"note,omitempty"`
+}
+
+type testDumpNode struct {
+	Name string
+	Next *testDumpNode `json:"next,omitempty"`
+}
+
+type testDump struct {
+	testDumpBase
+	Zeta    string               `json:"zeta"`
+	Alpha   complex128           `json:"alpha"`
+	Ratio   float64              `json:"ratio"`
+	Hidden  string               `json:"-"`
+	Empty   []int                `json:"empty,omitempty"`
+	Phases  map[string]complex64 `json:"phases"`
+	Done    chan bool            `json:"done"`
+	Handler func()               `json:"handler,omitempty"`
+	Nil     func()               `json:"nil"`
+	Extra   map[int]interface{}  `json:"extra,omitempty"`
+	private complex128
+}
+
+func TestDump(t *testing.T) {
+	ts := testDump{
+		testDumpBase: testDumpBase{ID: 7},
+		Zeta:         "last declared first",
+		Alpha:        complex(1, -2),
+		Ratio:        math.NaN(),
+		Hidden:       "secret",
+		Phases:       map[string]complex64{"b": 2i, "a": 1},
+		Done:         make(chan bool),
+		Handler:      func() {},
+		private:      3i,
+	}
+	want := `{"id":7,"zeta":"last declared first","alpha":"(1-2i)","ratio":"NaN","phases":{"a":"(1+0i)","b":"(0+2i)"},"done":"chan bool","handler":"func()","nil":null}`
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, want, SprintCompact(ts))
+	}
+
+	ts.Extra = map[int]interface{}{10: math.Inf(-1), 2: "two", 1: []complex64{1i}}
+	assert.Contains(t, SprintCompact(&ts), `"extra":{"1":["(0+1i)"],"10":"-Inf","2":"two"}`)
+	assert.Contains(t, Sprint(ts), "\n \t\"alpha\": \"(1-2i)\",\n")
+}
+
+func TestDumpCycle(t *testing.T) {
+	n := &testDumpNode{Name: "a"}
+	n.Next = &testDumpNode{Name: "b", Next: n}
+	assert.Equal(t, `{"Name":"a","next":{"Name":"b","next":"cycle"}}`, SprintCompact(n))
+}
+
+func TestDumpMarshalable(t *testing.T) {
+	ts := testDumpBase{ID: 1, Note: "unchanged"}
+	assert.Equal(t, `{"id":1,"note":"unchanged"}`, SprintCompact(ts))
+	assert.Equal(t, "{\n \t\"id\": 1,\n \t\"note\": \"unchanged\"\n }", Sprint(ts))
+	assert.Equal(t, "null", SprintCompact(nil))
+	assert.Equal(t, `"(0+1i)"`, SprintCompact(1i))
+}
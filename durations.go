@@ -0,0 +1,229 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TagDurationUnit is the struct tag naming the unit in which numbers, and texts
+// without unit, are interpreted when set into time.Duration fields, e.g.
+// `durationUnit:"s"` for a timeout read in seconds from a configuration file.
+// Without it, numbers are interpreted as nanoseconds.
+const TagDurationUnit = "durationUnit"
+
+var (
+	durationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"µs": time.Microsecond, // U+00B5 = micro symbol
+		"μs": time.Microsecond, // U+03BC = Greek letter mu
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+	durationUnitsMu sync.RWMutex
+)
+
+/*   F u n c t i o n s   */
+
+// SetDurationUnit registers the unit suffix, e.g. "d" or "mo", accepted when
+// text is parsed into time.Duration fields, see ParseDuration. On top of the
+// time package units, "d" (24h) and "w" (7d) are registered by default.
+// Registering a non-positive d unregisters the unit.
+func SetDurationUnit(unit string, d time.Duration) {
+	durationUnitsMu.Lock()
+	defer durationUnitsMu.Unlock()
+	if d <= 0 {
+		delete(durationUnits, unit)
+		return
+	}
+	durationUnits[unit] = d
+}
+
+// DurationUnits returns the unit suffixes accepted when parsing durations and
+// the duration each one stands for.
+func DurationUnits() map[string]time.Duration {
+	durationUnitsMu.RLock()
+	defer durationUnitsMu.RUnlock()
+	units := make(map[string]time.Duration, len(durationUnits))
+	for unit, d := range durationUnits {
+		units[unit] = d
+	}
+	return units
+}
+
+// ParseDuration parses a duration string like time.ParseDuration does, i.e. a
+// possibly signed sequence of decimal numbers, each with optional fraction and
+// a unit suffix, such as "300ms", "-1.5h" or "1h30m", but also accepts the
+// units registered with SetDurationUnit, e.g. "2d12h".
+func ParseDuration(x string) (time.Duration, error) {
+	units := DurationUnits()
+	s := x
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, errors.Errorf("invalid duration %q", x)
+	}
+	var d uint64
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, errors.Errorf("invalid duration %q", x)
+		}
+		number := s[:i]
+		s = s[i:]
+		j := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' || r == '.' })
+		if j < 0 {
+			j = len(s)
+		}
+		unit, ok := units[s[:j]]
+		if !ok {
+			return 0, errors.Errorf("unknown unit %q in duration %q; want: %s", s[:j], x, strings.Join(sortedDurationUnits(units), ", "))
+		}
+		s = s[j:]
+		n, err := unitDuration(number, unit)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid duration %q", x)
+		}
+		d += n
+		if d > 1<<63 {
+			return 0, errors.Errorf("invalid duration %q: out of range", x)
+		}
+	}
+	if neg {
+		return -time.Duration(d), nil
+	}
+	if d > 1<<63-1 {
+		return 0, errors.Errorf("invalid duration %q: out of range", x)
+	}
+	return time.Duration(d), nil
+}
+
+/*   U n e x p o r t e d   */
+
+// durationUnit returns the unit of time.Duration field f, as named by its
+// TagDurationUnit struct tag, or time.Nanosecond.
+func (f *StructField) durationUnit() (time.Duration, error) {
+	name, ok := f.Tag(TagDurationUnit)
+	if !ok || name == "" {
+		return time.Nanosecond, nil
+	}
+	unit, ok := DurationUnits()[name]
+	if !ok {
+		return 0, errors.Errorf("unknown duration unit %q of field %s", name, f.FullName())
+	}
+	return unit, nil
+}
+
+// parseDuration parses text x into a duration, see ParseDuration. Text without
+// unit is a number of the unit of field f, see durationUnit.
+func (f *StructField) parseDuration(x string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(x, 10, 64); err == nil {
+		return f.intDuration(n)
+	}
+	if n, err := strconv.ParseFloat(x, 64); err == nil {
+		return f.numberDuration(n)
+	}
+	return ParseDuration(x)
+}
+
+// intDuration returns the duration of n times the unit of field f.
+func (f *StructField) intDuration(n int64) (time.Duration, error) {
+	unit, err := f.durationUnit()
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(n) * unit
+	if n != 0 && d/unit != time.Duration(n) {
+		return 0, errors.Errorf("invalid duration %d%s: out of range", n, f.durationUnitName())
+	}
+	return d, nil
+}
+
+// durationUnitName returns the TagDurationUnit struct tag of field f, or "ns".
+func (f *StructField) durationUnitName() string {
+	if name, ok := f.Tag(TagDurationUnit); ok && name != "" {
+		return name
+	}
+	return "ns"
+}
+
+// numberDuration returns the duration of n times the unit of field f.
+func (f *StructField) numberDuration(n float64) (time.Duration, error) {
+	unit, err := f.durationUnit()
+	if err != nil {
+		return 0, err
+	}
+	return durationOf(n*float64(unit), strconv.FormatFloat(n, 'g', -1, 64)+f.durationUnitName())
+}
+
+// durationOf converts d nanoseconds into a duration, checking that it is in
+// range. Text x is the value d was parsed from, for error messages.
+func durationOf(d float64, x string) (time.Duration, error) {
+	if math.IsNaN(d) || d > math.MaxInt64 || d < math.MinInt64 {
+		return 0, errors.Errorf("invalid duration %q: out of range", x)
+	}
+	return time.Duration(math.Round(d)), nil
+}
+
+// unitDuration returns the number of nanoseconds in number units, where number
+// is a decimal number with optional fraction, e.g. "1.5".
+func unitDuration(number string, unit time.Duration) (uint64, error) {
+	whole, frac := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		whole, frac = number[:i], number[i:]
+	}
+	if whole == "" && (frac == "" || frac == ".") {
+		return 0, errors.Errorf("invalid number %q", number)
+	}
+	var n uint64
+	if whole != "" {
+		w, err := strconv.ParseUint(whole, 10, 64)
+		if err != nil || w > (1<<63)/uint64(unit) {
+			return 0, errors.New("out of range")
+		}
+		n = w * uint64(unit)
+	}
+	if frac != "" && frac != "." {
+		f, err := strconv.ParseFloat("0"+frac, 64)
+		if err != nil {
+			return 0, errors.Errorf("invalid number %q", number)
+		}
+		n += uint64(math.Round(f * float64(unit)))
+	}
+	return n, nil
+}
+
+// sortedDurationUnits returns the unit suffixes of units, sorted by duration.
+func sortedDurationUnits(units map[string]time.Duration) []string {
+	names := make([]string, 0, len(units))
+	for unit := range units {
+		names = append(names, unit)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if units[names[i]] != units[names[j]] {
+			return units[names[i]] < units[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
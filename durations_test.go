@@ -0,0 +1,82 @@
+package structs
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"300ms", 300 * time.Millisecond},
+		{"1h30m", 90 * time.Minute},
+		{"-1.5h", -90 * time.Minute},
+		{"2d", 48 * time.Hour},
+		{"1w2d12h", 9*24*time.Hour + 12*time.Hour},
+		{".5s", 500 * time.Millisecond},
+		{"2562047h47m16.854775807s", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		assert.Equal(t, nil, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{"", "-", "1", "h", "1x", "1.2.3s", "2562047h47m16.854775808s", "300000000w"} {
+		_, err := ParseDuration(in)
+		assert.NotEqual(t, nil, err, in)
+	}
+
+	SetDurationUnit("mo", 30*24*time.Hour)
+	defer SetDurationUnit("mo", 0)
+	d, err := ParseDuration("1mo1d")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 31*24*time.Hour, d)
+	assert.Equal(t, 30*24*time.Hour, DurationUnits()["mo"])
+}
+
+func TestFieldDurationUnit(t *testing.T) {
+	type testStruct struct {
+		Timeout  time.Duration `durationUnit:"s"`
+		Interval time.Duration
+		TTL      *time.Duration `durationUnit:"d"`
+		Invalid  time.Duration  `durationUnit:"parsec"`
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Timeout")
+	assert.Equal(t, nil, f.Set(30))
+	assert.Equal(t, 30*time.Second, ts.Timeout)
+	assert.Equal(t, nil, f.Set(uint8(2)))
+	assert.Equal(t, 2*time.Second, ts.Timeout)
+	assert.Equal(t, nil, f.Set(1.5))
+	assert.Equal(t, 1500*time.Millisecond, ts.Timeout)
+	assert.Equal(t, nil, f.Set("45"))
+	assert.Equal(t, 45*time.Second, ts.Timeout)
+	assert.Equal(t, nil, f.Set("1h30m"))
+	assert.Equal(t, 90*time.Minute, ts.Timeout)
+	assert.Equal(t, nil, f.Set(time.Minute))
+	assert.Equal(t, time.Minute, ts.Timeout)
+	assert.NotEqual(t, nil, f.Set(int64(math.MaxInt64)))
+
+	f = s.Field("Interval")
+	assert.Equal(t, nil, f.Set(1000))
+	assert.Equal(t, 1000*time.Nanosecond, ts.Interval)
+	assert.Equal(t, nil, f.Set("2d"))
+	assert.Equal(t, 48*time.Hour, ts.Interval)
+	assert.NotEqual(t, nil, f.Set("2 days"))
+
+	f = s.Field("TTL")
+	assert.Equal(t, nil, f.Set(7))
+	assert.Equal(t, 7*24*time.Hour, *ts.TTL)
+
+	assert.NotEqual(t, nil, s.Field("Invalid").Set(1))
+}
@@ -25,6 +25,10 @@ type Unembeddeds struct {
 // The catalog will of course contain all fields at the base of the top level struct. However, any encountered
 // anonymous/embedded fields will be recursively scanned to also include their fields too, in the same collection.
 //
+// Embedded pointers to structs are expanded the same way as embedded structs. The
+// fields of nil ones are exposed as non-settable zero-values, so that exploding
+// never modifies v; it is up to the caller to allocate them when set.
+//
 // // NOTE(roninzo): Explode avoids scanning potential embedded struct from third party types
 // // (such as time.Time, reflect.Value, etc.) by only expanding on structs that are declared locally to the current package.
 // // Hence, the use of the namespace in the program.
@@ -47,7 +51,9 @@ func Explode(v reflect.Value, t reflect.Type, m map[int]Unembeddeds, namespace *
 		x[n-1] = i
 		sv := v.Field(i)
 		sf := t.Field(i)
-		if sf.Anonymous { // && nameSpace(sf.Type) == *namespace {
+		if sf.Anonymous && isStructPtr(sf.Type) {
+			Explode(indirect(sv), sf.Type.Elem(), m, namespace, c, x)
+		} else if sf.Anonymous { // && nameSpace(sf.Type) == *namespace {
 			Explode(sv, sf.Type, m, namespace, c, x)
 		} else {
 			tmp := make([]int, n)
@@ -74,3 +80,17 @@ func nameSpace(t reflect.Type) string {
 	s := fmt.Sprintf("%v", t)
 	return strings.Split(s, ".")[0]
 }
+
+// isStructPtr returns true if t is a pointer to a struct.
+func isStructPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
+// indirect returns the struct that pointer v points to. If v is nil, indirect
+// returns a non-settable zero-value struct, without allocating it.
+func indirect(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
@@ -0,0 +1,112 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedPointer(t *testing.T) {
+	type Base struct {
+		ID      int
+		Created string
+	}
+	type base struct {
+		Version int
+	}
+	type Server struct {
+		*Base
+		*base
+		Name string
+	}
+
+	server := Server{Name: "Roninzo"}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"ID", "Created", "Version", "Name"}, s.Fields().Names())
+	assert.Equal(t, (*Base)(nil), server.Base)
+	assert.Equal(t, int64(0), s.Field("ID").Int())
+	assert.Equal(t, (*Base)(nil), server.Base)
+	assert.Equal(t, true, s.Field("ID").CanSet())
+	assert.Equal(t, nil, s.Field("ID").Set(42))
+	assert.Equal(t, 42, server.ID)
+	assert.Equal(t, int64(42), s.Field("ID").Int())
+	assert.Equal(t, (*base)(nil), server.base)
+	assert.Equal(t, false, s.Field("Version").CanSet())
+	assert.Equal(t, int64(0), s.Field("Version").Int())
+
+	server = Server{Base: &Base{ID: 7}, base: &base{Version: 2}}
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(7), s.Field("ID").Int())
+	assert.Equal(t, int64(2), s.Field("Version").Int())
+}
+
+func TestEmbeddedPointerReadOnly(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type Server struct {
+		*Base
+		Name string
+	}
+
+	server := Server{Name: "Roninzo"}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	s.Freeze()
+	assert.Equal(t, false, s.Field("ID").CanSet())
+	assert.Equal(t, ErrReadOnly, errors.Cause(s.Field("ID").Set(1)))
+	assert.Equal(t, (*Base)(nil), server.Base)
+
+	servers := []Server{{Base: &Base{ID: 1}, Name: "a"}, {Name: "b"}}
+	s, err = New(&servers)
+	assert.Equal(t, nil, err)
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	defer rows.Close()
+	ids := make([]int64, 0)
+	for rows.Next() {
+		ids = append(ids, rows.Field("ID").Int())
+	}
+	assert.Equal(t, nil, rows.Err())
+	assert.Equal(t, []int64{1, 0}, ids)
+	assert.Equal(t, (*Base)(nil), servers[1].Base)
+	groups, err := GroupBy(&servers, "ID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, (*Base)(nil), servers[1].Base)
+}
+
+func TestEmbeddedMode(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type Server struct {
+		Base
+		Name string
+	}
+
+	server := Server{Base: Base{ID: 7}, Name: "Roninzo"}
+
+	SetEmbeddedMode(EmbeddedPrefix)
+	defer SetEmbeddedMode(EmbeddedPromote)
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"Base.ID", "Name"}, s.Fields().Names())
+	assert.Equal(t, nil, s.Field("Base.ID").Set(8))
+	assert.Equal(t, 8, server.ID)
+
+	SetEmbeddedMode(EmbeddedKeep)
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"Base", "Name"}, s.Fields().Names())
+	assert.Equal(t, true, s.Field("Base").IsEmbedded())
+	assert.Equal(t, int64(8), s.Field("Base").Struct().Field("ID").Int())
+
+	SetEmbeddedMode(EmbeddedPromote)
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"ID", "Name"}, s.Fields().Names())
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   F u n c t i o n s   */
+
+// ScanFromEnv sets the fields of struct dest from environment variables.
+//
+// Variable names are looked up in the env struct tag, e.g. `env:"PORT"`, and
+// default to the field names in upper case under_score style. A default value
+// can be declared with the default option, which must come last in the tag,
+// e.g. `env:"PORT,default=8080"`. Fields whose variable is not set and without
+// any default value are left untouched.
+//
+// Nested struct fields prefix the variable names of their own fields with their
+// variable name, e.g. APP_SERVER_PORT for the PORT variable of the SERVER nested
+// struct, when prefix is "APP". Slice fields are set from comma separated values.
+func ScanFromEnv(dest interface{}, prefix ...string) error {
+	ctx := "could not scan struct from environment variables"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if !s.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s.Name()), ctx)
+	}
+	if s.Multiple() {
+		return errors.Wrap(errors.Errorf("source is a slice of struct %s", s.Name()), ctx)
+	}
+	return s.scanFromEnv(envPrefix(prefix))
+}
+
+// ToEnv returns the fields of struct dest as a map of environment variable names
+// to their string values, following the same naming rules as ScanFromEnv.
+// Slice fields are exported as comma separated values and nil pointers are neglected.
+func ToEnv(dest interface{}, prefix ...string) (map[string]string, error) {
+	s, err := New(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not export struct to environment variables")
+	}
+	env := make(map[string]string)
+	s.toEnv(env, envPrefix(prefix))
+	return env, nil
+}
+
+/*   U n e x p o r t e d   */
+
+// scanFromEnv sets the struct fields from the environment variables named after
+// them, prepended with prefix.
+func (s *StructValue) scanFromEnv(prefix string) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		name, def, ok := f.envName()
+		if !ok {
+			continue
+		}
+		name = prefix + name
+		if f.canStructType() {
+			if f.IsNil() {
+				if !hasEnvPrefix(name + "_") {
+					continue
+				}
+				utils.PresetIndirect(f.value)
+			}
+			if err := f.Struct().scanFromEnv(name + "_"); err != nil {
+				return err
+			}
+			continue
+		}
+		x, ok := os.LookupEnv(name)
+		if !ok {
+			if def == nil {
+				continue
+			}
+			x = *def
+		}
+		values := []string{x}
+		if f.CanSlice() && !f.CanBytes() {
+			values = strings.Split(x, ",")
+		}
+		if err := f.setStrings(values); err != nil {
+			return errors.Wrapf(err, "could not set environment variable %q in %s", name, s.Name())
+		}
+	}
+	return nil
+}
+
+// toEnv adds the struct fields to env under their variable names, prepended
+// with prefix.
+func (s *StructValue) toEnv(env map[string]string, prefix string) {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		name, _, ok := f.envName()
+		if !ok {
+			continue
+		}
+		name = prefix + name
+		switch {
+		case f.CanStruct():
+			f.Struct().toEnv(env, name+"_")
+		case f.canStructType(), f.IsNil():
+			continue
+		default:
+			env[name] = formatStrings(f.Indirect(), ",")
+		}
+	}
+}
+
+// envName returns the environment variable name of the field and its default
+// value, if any. It returns false if the field is tagged `env:"-"`.
+func (f *StructField) envName() (name string, def *string, ok bool) {
+	tag, _ := f.Tag("env")
+	if i := strings.Index(tag, ",default="); i != OutOfRange {
+		x := tag[i+len(",default="):]
+		tag, def = tag[:i], &x
+	}
+	name = strings.TrimSpace(strings.Split(tag, ",")[0])
+	switch name {
+	case "-":
+		return "", nil, false
+	case "":
+		name = strings.ToUpper(utils.CamelCaseToUnderscore(f.Name()))
+	}
+	return name, def, true
+}
+
+// envPrefix returns the first of the optional prefixes, followed by an
+// underscore, else returns zero-value string.
+func envPrefix(prefix []string) string {
+	if len(prefix) > 0 && prefix[0] != "" {
+		return strings.TrimSuffix(prefix[0], "_") + "_"
+	}
+	return ""
+}
+
+// hasEnvPrefix returns true if any environment variable name starts with prefix.
+func hasEnvPrefix(prefix string) bool {
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package structs
+
+import (
+	"database/sql"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanFromEnv(t *testing.T) {
+	type Server struct {
+		Host    string        `env:"HOST,default=localhost"`
+		Port    int           `env:"PORT,default=8080"`
+		Timeout time.Duration `env:"TIMEOUT"`
+	}
+
+	type Config struct {
+		Name    string
+		Debug   bool     `env:"DEBUG"`
+		Tags    []string `env:"TAGS"`
+		Secret  string   `env:"-"`
+		Server  Server   `env:"SERVER"`
+		Backup  *Server  `env:"BACKUP"`
+		Missing *Server  `env:"MISSING"`
+	}
+
+	env := map[string]string{
+		"APP_NAME":           "Roninzo",
+		"APP_DEBUG":          "true",
+		"APP_TAGS":           "web,prod",
+		"APP_SECRET":         "abcdefg",
+		"APP_SERVER_PORT":    "9090",
+		"APP_SERVER_TIMEOUT": "5s",
+		"APP_BACKUP_HOST":    "backup",
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	var c Config
+	err := ScanFromEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Roninzo", c.Name)
+	assert.Equal(t, true, c.Debug)
+	assert.Equal(t, []string{"web", "prod"}, c.Tags)
+	assert.Equal(t, "", c.Secret)
+	assert.Equal(t, "localhost", c.Server.Host)
+	assert.Equal(t, 9090, c.Server.Port)
+	assert.Equal(t, 5*time.Second, c.Server.Timeout)
+	assert.Equal(t, "backup", c.Backup.Host)
+	assert.Equal(t, 8080, c.Backup.Port)
+	assert.Equal(t, (*Server)(nil), c.Missing)
+
+	got, err := ToEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{
+		"APP_NAME":           "Roninzo",
+		"APP_DEBUG":          "true",
+		"APP_TAGS":           "web,prod",
+		"APP_SERVER_HOST":    "localhost",
+		"APP_SERVER_PORT":    "9090",
+		"APP_SERVER_TIMEOUT": "5s",
+		"APP_BACKUP_HOST":    "backup",
+		"APP_BACKUP_PORT":    "8080",
+		"APP_BACKUP_TIMEOUT": "0s",
+	}, got)
+
+	t.Setenv("APP_SERVER_PORT", "abc")
+	err = ScanFromEnv(&c, "APP")
+	assert.NotEqual(t, nil, err)
+
+	err = ScanFromEnv(c, "APP")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestScanFromEnvNullable(t *testing.T) {
+	type Config struct {
+		Name  sql.NullString `env:"NAME"`
+		Count sql.NullInt64  `env:"COUNT"`
+		Ratio sql.NullFloat64
+	}
+
+	t.Setenv("APP_NAME", "Roninzo")
+	t.Setenv("APP_COUNT", "42")
+
+	var c Config
+	err := ScanFromEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sql.NullString{String: "Roninzo", Valid: true}, c.Name)
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, c.Count)
+	assert.Equal(t, sql.NullFloat64{}, c.Ratio)
+
+	got, err := ToEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{
+		"APP_NAME":  "Roninzo",
+		"APP_COUNT": "42",
+	}, got)
+
+	t.Setenv("APP_COUNT", "abc")
+	err = ScanFromEnv(&c, "APP")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestScanFromEnvText(t *testing.T) {
+	type Config struct {
+		Addr  netip.Addr `env:"ADDR"`
+		Level testLevel  `env:"LEVEL,default=debug"`
+	}
+
+	t.Setenv("APP_ADDR", "192.168.0.1")
+
+	var c Config
+	err := ScanFromEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, netip.MustParseAddr("192.168.0.1"), c.Addr)
+	assert.Equal(t, testLevel(1), c.Level)
+
+	got, err := ToEnv(&c, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{"APP_ADDR": "192.168.0.1", "APP_LEVEL": "debug"}, got)
+}
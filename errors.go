@@ -12,6 +12,10 @@ var (
 	ErrNotExported = errors.New("struct field is not exported")
 	ErrNotSettable = errors.New("struct field is not settable")
 	ErrNotNillable = errors.New("struct field is not nillable")
+	ErrNotSlice    = errors.New("struct field is not a slice")
+	ErrNotMap      = errors.New("struct field is not a map")
+	ErrNotString   = errors.New("struct field is not a string")
+	ErrOutOfRange  = errors.New("struct field index out of range")
 	ErrNoStruct    = errors.New("struct not found")
 	ErrNoStructs   = errors.New("structs not found")
 	ErrNoField     = errors.New("struct field not found")
@@ -20,4 +24,7 @@ var (
 	ErrNoRows      = errors.New("struct rows not found")
 	ErrRowsClosed  = errors.New("struct rows are closed")
 	ErrNotReplaced = errors.New("struct field old and new value types does not match") // could not replace value in struct
+	ErrReadOnly    = errors.New("struct is read-only")
+	ErrStrict      = errors.New("struct field value is not assignable in strict mode")
+	ErrFractional  = errors.New("struct field value has a fractional part")
 )
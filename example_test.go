@@ -1,8 +1,12 @@
 package structs_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -74,7 +78,6 @@ func ExampleNew() {
 	//  	"Bool": true,
 	//  	"Int": 5
 	//  }.
-	// Set[Error]: wrong kind of value for field T.Bool. got: "int" want: "bool".
 	// Value of String    : Roninzo.
 	// Value of Uint      : 654321.
 	// Value of Int       : 6.
@@ -381,6 +384,43 @@ func ExampleStructValue_Sprint() {
 	//  }
 }
 
+func ExampleStructValue_Pretty() {
+	type Server struct {
+		Name    string     `json:"name"`
+		ID      uint       `json:"id"`
+		Phase   complex128 `json:"phase"`
+		Aliases []string   `json:"aliases"`
+	}
+
+	server := Server{
+		Name:    "Roninzo",
+		ID:      123456,
+		Phase:   1i,
+		Aliases: []string{"web"},
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// structs.WithColor() highlights keys and values in a terminal
+	err = s.Pretty(os.Stdout, structs.WithIndent("  "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Output:
+	// {
+	//   "name": "Roninzo",
+	//   "id": 123456,
+	//   "phase": "(0+1i)",
+	//   "aliases": [
+	//     "web"
+	//   ]
+	// }
+}
+
 func ExampleStructValue_CanSet() {
 	type Program struct {
 		Name string `json:"name,omitempty"`
@@ -1010,7 +1050,7 @@ func ExampleStructValue_Diff() {
 	// Output:
 	// Diff[bool]: false.
 	// Diff[bytes]: "QnllIGJ5ZSB3b3JsZA==".
-	// Diff[complex]: json: unsupported type: complex128.
+	// Diff[complex]: "(-67-42i)".
 	// Diff[duration]: 30000000000.
 	// Diff[error]: "not compliant".
 	// Diff[float]: 7622.5.
@@ -1018,7 +1058,7 @@ func ExampleStructValue_Diff() {
 	// Diff[int]: 3.
 	// Diff[interface]: 3.99.
 	// Diff[map_bool]: {"D":false,"E":true}.
-	// Diff[map_complex]: json: unsupported type: complex128.
+	// Diff[map_complex]: {"D":"(1+4i)","E":"(1+5i)","F":"(1+6i)"}.
 	// Diff[map_float]: {"D":1.4,"E":1.5,"F":1.6}.
 	// Diff[map_interface]: {"D":4,"E":"five","F":6}.
 	// Diff[map_string]: {"D":"four","E":"five","F":"six"}.
@@ -1026,7 +1066,7 @@ func ExampleStructValue_Diff() {
 	// Diff[mapint]: {"D":4,"E":5,"F":6}.
 	// Diff[nested_struct]: {"uint":443211,"string":"Microsoft IIS"}.
 	// Diff[pointer_bool]: false.
-	// Diff[pointer_complex]: json: unsupported type: complex128.
+	// Diff[pointer_complex]: "(-67-42i)".
 	// Diff[pointer_duration]: 30000000000.
 	// Diff[pointer_error]: "not compliant".
 	// Diff[pointer_float]: 7622.5.
@@ -1036,12 +1076,12 @@ func ExampleStructValue_Diff() {
 	// Diff[pointer_time]: "2021-08-31T14:11:11Z".
 	// Diff[pointer_uint]: 654321.
 	// Diff[slice_bool]: [false,true].
-	// Diff[slice_complex]: json: unsupported type: complex128.
+	// Diff[slice_complex]: ["(1+4i)","(1+5i)","(1+6i)"].
 	// Diff[slice_float]: [1.4,1.5,1.6].
 	// Diff[slice_int]: [4,5,6].
 	// Diff[slice_interface]: [4,"five",6].
 	// Diff[slice_pointer_bool]: [false,true].
-	// Diff[slice_pointer_complex]: json: unsupported type: complex128.
+	// Diff[slice_pointer_complex]: ["(1+4i)","(1+5i)","(1+6i)"].
 	// Diff[slice_pointer_float]: [1.4,1.5,1.6].
 	// Diff[slice_pointer_int]: [4,5,6].
 	// Diff[slice_pointer_string]: ["four","five","six"].
@@ -1053,103 +1093,58 @@ func ExampleStructValue_Diff() {
 	// Diff[uint]: 654321.
 }
 
-/*   S t r u c t F i e l d   */
-
-func ExampleStructField_Indirect() {
+func ExampleStructValue_WhereClause() {
 	type Server struct {
-		Name    *string `json:"name"`
-		ID      *uint   `json:"id"`
-		Enabled *bool   `json:"enabled"`
-		Count   *int32  `json:"count"`
+		Name     string `db:"name"`
+		ID       uint   `db:"id"`
+		Enabled  bool   `db:"enabled"`
+		Count    int32  `db:"count"`
+		Password string `db:"-"`
 	}
 
 	server := Server{
-		Name:    pointers.String("Roninzo"),
-		ID:      pointers.Uint(uint(123456)),
-		Enabled: pointers.Bool(true),
-		Count:   pointers.Int32(int32(5)),
+		Name:     "Roninzo",
+		ID:       123456,
+		Password: "abcdefg",
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error          : %v\n", err)
-	}
-
-	fmt.Printf("Name           : %v\n", s.Name())
-	fmt.Printf("Value of ID    : %v\n", s.Field("ID").Indirect())
-	fmt.Printf("Value of 0     : %v\n", s.Field(0).Indirect())
-	fmt.Printf("Value of Count : %v\n", s.Field("Count").Indirect())
-	fmt.Printf("Sprint: %s.\n", s.Sprint())
-
-	err = s.Field("ID").Set(pointers.Uint(uint(654321)))
-	if err != nil {
-		fmt.Printf("Error          : %v\n", err)
-	}
-
-	err = s.Field("Count").Set(pointers.Int32(int32(6)))
-	if err != nil {
-		fmt.Printf("Error          : %v\n", err)
+		fmt.Printf("Error: %v\n", err)
 	}
 
-	err = s.Field("Enabled").Set(pointers.Int32(int32(6))) // not compatible with bool
-	if err != nil {
-		fmt.Printf("Error          : %v\n", err)
-	}
+	where, args := s.WhereClause("db")
 
-	fmt.Printf("Value of Name  : %v\n", s.Field("Name").Indirect())
-	fmt.Printf("Value of ID    : %v\n", s.Field("ID").Indirect())
-	fmt.Printf("Value of Count : %v\n", s.Field("Count").Indirect())
-	fmt.Printf("Sprint: %s.\n", s.Sprint())
-	fmt.Printf("\nVerification   :\n")
-	fmt.Printf("server.Name    : %s\n", *server.Name)
-	fmt.Printf("server.ID      : %d\n", *server.ID)
-	fmt.Printf("server.Count   : %d\n", *server.Count)
+	fmt.Printf("Where: %s\n", where)
+	fmt.Printf("Args: %v\n", args)
 
 	// Output:
-	// Name           : Server
-	// Value of ID    : 123456
-	// Value of 0     : Roninzo
-	// Value of Count : 5
-	// Sprint: {
-	//  	"name": "Roninzo",
-	//  	"id": 123456,
-	//  	"enabled": true,
-	//  	"count": 5
-	//  }.
-	// Error          : wrong kind of value for field Server.Enabled. got: "*int32" want: "*bool"
-	// Value of Name  : Roninzo
-	// Value of ID    : 654321
-	// Value of Count : 6
-	// Sprint: {
-	//  	"name": "Roninzo",
-	//  	"id": 654321,
-	//  	"enabled": true,
-	//  	"count": 6
-	//  }.
-	//
-	// Verification   :
-	// server.Name    : Roninzo
-	// server.ID      : 654321
-	// server.Count   : 6
+	// Where: name = ? AND id = ?
+	// Args: [Roninzo 123456]
 }
 
-func ExampleStructField_IsValid() {
+func ExampleStructValue_ToValues() {
+	type Program struct {
+		Name string `url:"name"`
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name     string   `url:"name,omitempty"`
+		ID       uint     `url:"id,omitempty"`
+		Enabled  bool     `url:"enabled,omitempty"`
+		Count    int32    `url:"count,omitempty"`
+		Tags     []string `url:"tags,omitempty"`
+		Password string   `url:"-"`
+		Program  *Program `url:"program,omitempty"`
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:     "Roninzo",
+		ID:       123456,
+		Enabled:  true,
+		Tags:     []string{"web", "prod"},
+		Password: "abcdefg",
+		Program:  &Program{Name: "Apache"},
 	}
 
 	s, err := structs.New(&server)
@@ -1157,34 +1152,38 @@ func ExampleStructField_IsValid() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := &structs.StructField{}
+	values := s.ToValues("url")
 
-	fmt.Printf("IsValid: %v\n", f1.IsValid())
-	fmt.Printf("IsValid: %v\n", f2.IsValid())
+	fmt.Printf("Query: %s\n", values.Encode())
 
 	// Output:
-	// IsValid: true
-	// IsValid: false
+	// Query: enabled=true&id=123456&name=Roninzo&program.name=Apache&tags=web&tags=prod
 }
 
-func ExampleStructField_Name() {
+func ExampleStructValue_ToStringMap() {
+	type Program struct {
+		Name    string
+		Timeout time.Duration
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name      string
+		ID        uint
+		Enabled   bool
+		Ratio     float64
+		Tags      []string
+		CreatedAt time.Time
+		Program   *Program
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:      "Roninzo",
+		ID:        123456,
+		Enabled:   true,
+		Ratio:     0.25,
+		Tags:      []string{"web", "prod"},
+		CreatedAt: time.Date(2021, time.August, 3, 13, 59, 35, 0, time.UTC),
+		Program:   &Program{Name: "Apache", Timeout: 90 * time.Second},
 	}
 
 	s, err := structs.New(&server)
@@ -1192,42 +1191,42 @@ func ExampleStructField_Name() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Enabled")
-
-	fmt.Printf("Field: %v\n", f1.Name())
-	fmt.Printf("Field: %v\n", f2.Name())
+	m := s.ToStringMap()
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("%s: %s\n", k, m[k])
+	}
 
 	// Output:
-	// Field: Name
-	// Field: Enabled
+	// CreatedAt: 2021-08-03T13:59:35Z
+	// Enabled: true
+	// ID: 123456
+	// Name: Roninzo
+	// Program.Name: Apache
+	// Program.Timeout: 1m30s
+	// Ratio: 0.25
+	// Tags: web,prod
 }
 
-func ExampleStructField_Namespace() {
+func ExampleStructValue_MissingRequired() {
 	type Program struct {
-		Name string `json:"name,omitempty"`
+		Name string `required:"true"`
 	}
 
 	type Server struct {
-		Name     string   `json:"name,omitempty"`
-		ID       uint     `json:"id,omitempty"`
-		Enabled  bool     `json:"enabled,omitempty"`
-		Count    int32    `json:"count,omitempty"`
-		Password string   `json:"-"`
-		Program  *Program `json:"program,omitempty"`
-	}
-
-	program := Program{
-		Name: "Apache",
+		Name    string   `validate:"required,max=8"`
+		ID      uint     `required:"true"`
+		Enabled bool     `required:"false"`
+		Program *Program `required:"true"`
 	}
 
 	server := Server{
-		Name:     "Roninzo",
-		ID:       123456,
-		Enabled:  true,
-		Count:    0,
-		Password: "abcdefg",
-		Program:  &program,
+		ID:      123456,
+		Program: &Program{},
 	}
 
 	s, err := structs.New(&server)
@@ -1235,34 +1234,31 @@ func ExampleStructField_Namespace() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := s.FindStruct("Program").Field(0)
-
-	fmt.Printf("FullName: %v\n", f1.FullName())
-	fmt.Printf("FullName: %v\n", f2.FullName())
+	fmt.Printf("MissingRequired: %v\n", s.MissingRequired())
 
 	// Output:
-	// FullName: Server.Name
-	// FullName: Server.Program.Name
+	// MissingRequired: [Name Program.Name]
 }
 
-func ExampleStructField_Value() {
+func ExampleStructValue_ApplyDefaults() {
+	type Program struct {
+		Name    string        `default:"Apache"`
+		Timeout time.Duration `default:"30s"`
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name      string    `default:"'Roninzo'"`
+		ID        uint      `default:"123456"`
+		Enabled   bool      `default:"true"`
+		Count     *int32    `default:"5"`
+		Ratio     float64   `default:"0.75"`
+		Tags      []string  `default:"web,prod"`
+		CreatedAt time.Time `default:"2021-08-03T13:59:35Z"`
+		Program   Program
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		ID: 654321,
 	}
 
 	s, err := structs.New(&server)
@@ -1270,34 +1266,51 @@ func ExampleStructField_Value() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Enabled")
+	err = s.ApplyDefaults()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
 
-	fmt.Printf("Value: %v\n", f1.Value())
-	fmt.Printf("Value: %v\n", f2.Value())
+	fmt.Printf("Name: %v\n", server.Name)
+	fmt.Printf("ID: %v\n", server.ID)
+	fmt.Printf("Enabled: %v\n", server.Enabled)
+	fmt.Printf("Count: %v\n", *server.Count)
+	fmt.Printf("Ratio: %v\n", server.Ratio)
+	fmt.Printf("Tags: %v\n", server.Tags)
+	fmt.Printf("CreatedAt: %v\n", server.CreatedAt.Format(time.RFC3339))
+	fmt.Printf("Program.Name: %v\n", server.Program.Name)
+	fmt.Printf("Program.Timeout: %v\n", server.Program.Timeout)
 
 	// Output:
-	// Value: Roninzo
-	// Value: true
+	// Name: Roninzo
+	// ID: 654321
+	// Enabled: true
+	// Count: 5
+	// Ratio: 0.75
+	// Tags: [web prod]
+	// CreatedAt: 2021-08-03T13:59:35Z
+	// Program.Name: Apache
+	// Program.Timeout: 30s
 }
 
-func ExampleStructField_Type() {
+func ExampleStructValue_ZeroFields() {
+	type Program struct {
+		Name     string
+		Password string
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name     string
+		Password string
+		Token    string
+		Program  *Program
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:     "Roninzo",
+		Password: "abcdefg",
+		Token:    "123456",
+		Program:  &Program{Name: "Apache", Password: "hijklmn"},
 	}
 
 	s, err := structs.New(&server)
@@ -1305,71 +1318,91 @@ func ExampleStructField_Type() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Enabled")
+	err = s.ZeroFields("Password", "Token")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	fmt.Printf("ZeroFields: %q %q %q %q\n", server.Password, server.Token, server.Program.Name, server.Program.Password)
 
-	fmt.Printf("Type: %v\n", s.Type())
-	fmt.Printf("Type: %v\n", f1.Type())
-	fmt.Printf("Type: %v\n", f2.Type())
+	err = s.ZeroFieldsDeep("Password")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	fmt.Printf("ZeroFieldsDeep: %q %q %q %q\n", server.Password, server.Token, server.Program.Name, server.Program.Password)
+
+	err = s.ZeroFields("Unknown")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	err = s.SetZero()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	fmt.Printf("SetZero: %+v\n", server)
 
 	// Output:
-	// Type: structs_test.Server
-	// Type: string
-	// Type: bool
+	// ZeroFields: "" "" "Apache" "hijklmn"
+	// ZeroFieldsDeep: "" "" "Apache" ""
+	// Error: could not set field Server.Unknown to zero-value: struct field not found
+	// SetZero: {Name: Password: Token: Program:<nil>}
 }
 
-func ExampleStructField_Kind() {
-	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+func ExampleStructValue_InitNils() {
+	type Program struct {
+		Name  *string
+		Ports *[]int
 	}
 
-	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+	type Server struct {
+		Name    *string
+		Labels  *map[string]string
+		Program *Program
 	}
 
+	server := Server{}
+
 	s, err := structs.New(&server)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Enabled")
-
-	fmt.Printf("Kind: %v\n", f1.Kind())
-	fmt.Printf("Kind: %v\n", f2.Kind())
+	err = s.InitNils(true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	(*server.Labels)["env"] = "prod"
+
+	fmt.Printf("Name: %q\n", *server.Name)
+	fmt.Printf("Labels: %v\n", *server.Labels)
+	fmt.Printf("Program.Name: %q\n", *server.Program.Name)
+	fmt.Printf("Program.Ports: %v\n", *server.Program.Ports)
 
 	// Output:
-	// Kind: string
-	// Kind: bool
+	// Name: ""
+	// Labels: map[env:prod]
+	// Program.Name: ""
+	// Program.Ports: []
 }
 
-func ExampleStructField_Tag() {
+func ExampleStructValue_PruneZero() {
+	type Program struct {
+		Name *string `json:"name,omitempty"`
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name    *string            `json:"name,omitempty"`
+		Count   *int               `json:"count,omitempty"`
+		Labels  *map[string]string `json:"labels,omitempty"`
+		Program *Program           `json:"program,omitempty"`
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:    pointers.String("Roninzo"),
+		Count:   pointers.Int(0),
+		Labels:  &map[string]string{},
+		Program: &Program{Name: pointers.String("")},
 	}
 
 	s, err := structs.New(&server)
@@ -1377,42 +1410,38 @@ func ExampleStructField_Tag() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	t1, _ := s.Field(0).Tag("json")
-	t2, _ := s.Field("Enabled").Tag("json")
+	fmt.Printf("Before: %s\n", structs.SprintCompact(server))
 
-	fmt.Printf("Tag: %v\n", t1)
-	fmt.Printf("Tag: %v\n", t2)
+	err = s.PruneZero(true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	fmt.Printf("After: %s\n", structs.SprintCompact(server))
 
 	// Output:
-	// Tag: name,omitempty
-	// Tag: enabled,omitempty
+	// Before: {"name":"Roninzo","count":0,"labels":{},"program":{"name":""}}
+	// After: {"name":"Roninzo"}
 }
 
-func ExampleStructField_CanSet() {
+func ExampleStructValue_ZeroFieldNames() {
 	type Program struct {
-		Name string `json:"name,omitempty"`
+		Name string
+		Port int
 	}
 
 	type Server struct {
-		Name     string  `json:"name,omitempty"`
-		ID       uint    `json:"id,omitempty"`
-		Enabled  bool    `json:"enabled,omitempty"`
-		Count    int32   `json:"count,omitempty"`
-		Password string  `json:"-"`
-		Program  Program `json:"program,omitempty"`
-	}
-
-	program := Program{
-		Name: "Apache",
+		Name    string
+		ID      uint
+		Enabled bool
+		Program Program
+		Backup  *Program
 	}
 
 	server := Server{
-		Name:     "Roninzo",
-		ID:       123456,
-		Enabled:  true,
-		Count:    0,
-		Password: "abcdefg",
-		Program:  program,
+		Name:    "Roninzo",
+		Enabled: true,
+		Program: Program{Name: "Apache"},
 	}
 
 	s, err := structs.New(&server)
@@ -1420,403 +1449,1357 @@ func ExampleStructField_CanSet() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field(0)
+	fmt.Printf("ZeroFieldNames: %v\n", s.ZeroFieldNames())
+	fmt.Printf("NonZeroFieldNames: %v\n", s.NonZeroFieldNames())
 
-	fmt.Printf("CanSet: %v\n", f.CanSet())
+	// Output:
+	// ZeroFieldNames: [ID Program.Port Backup]
+	// NonZeroFieldNames: [Name Enabled Program.Name]
+}
 
-	s, err = structs.New(server)
+func ExampleStructValue_Track() {
+	type Server struct {
+		Name    string
+		ID      uint
+		Enabled bool
+		Count   int32
+	}
+
+	server := Server{
+		Name:    "Roninzo",
+		ID:      123456,
+		Enabled: true,
+		Count:   5,
+	}
+
+	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	f = s.Field(0)
+	s.Track()
+	s.Field("Enabled").SetBool(false)
+	s.Field("Count").SetInt(6)
+	s.Field("Count").SetInt(7)
 
-	fmt.Printf("CanSet: %v\n", f.CanSet())
+	fmt.Printf("Changed: %v.\n", s.Changed())
+	for _, c := range s.ChangedValues() {
+		fmt.Printf("%s: %v => %v.\n", c.Name, c.Old, c.New)
+	}
 
 	// Output:
-	// CanSet: true
-	// CanSet: false
+	// Changed: [Enabled Count].
+	// Enabled: true => false.
+	// Count: 5 => 7.
 }
 
-// TODO: No example of Anonymous fields?
-
-func ExampleStructField_IsAnonymous() {
-	type T3 struct {
-		Config string `json:"config,omitempty"`
+func ExampleStructValue_OnSet() {
+	type Server struct {
+		Name    string
+		ID      uint
+		Enabled bool
+		Count   int32
 	}
 
-	type T2 struct {
-		Options string `json:"options,omitempty"`
-		T3
+	server := Server{
+		Name:    "Roninzo",
+		ID:      123456,
+		Enabled: true,
+		Count:   5,
 	}
 
-	type T1 struct {
-		Name     string `json:"name,omitempty"`
-		ID       uint   `json:"id,omitempty"`
-		Enabled  bool   `json:"enabled,omitempty"`
-		Count    int32  `json:"count,omitempty"`
-		Password string `json:"-"`
-		Nested   T2     `json:"t2,omitempty"`
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	t := T1{
-		"Roninzo",
-		123456,
-		true,
-		0,
-		"abcdefg",
-		T2{"654321", T3{"cfg"}},
-	}
+	s.OnSet(func(f *structs.StructField, old, new interface{}) {
+		fmt.Printf("OnSet: %s: %v => %v.\n", f.FullName(), old, new)
+	})
 
-	s, err := structs.New(&t)
+	err = s.Field("Name").Set("Apache")
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("Set[Error]: %v.\n", err)
 	}
-
-	f1 := s.Field(0)
-	f2 := s.Field("Password")
-	f3 := s.Field("Nested").Struct().Field(0)
-
-	fmt.Printf("IsAnonymous: %v\n", f1.IsAnonymous())
-	fmt.Printf("IsAnonymous: %v\n", f2.IsAnonymous())
-	fmt.Printf("IsAnonymous: %v\n", f3.IsAnonymous())
+	err = s.Field("Count").Set("many")
+	if err != nil {
+		fmt.Printf("Set[Error]: %v.\n", err)
+	}
+	s.Field("Enabled").SetBool(false)
 
 	// Output:
-	// IsAnonymous: false
-	// IsAnonymous: false
-	// IsAnonymous: false
+	// OnSet: Server.Name: Roninzo => Apache.
+	// Set[Error]: invalid value for field Server.Count: strconv.ParseInt: parsing "many": invalid syntax.
+	// OnSet: Server.Enabled: true => false.
 }
 
-// TODO: No example of Embedded fields?
-
-func ExampleStructField_IsEmbedded() {
-	type T3 struct {
-		Config string `json:"config,omitempty"`
+func ExampleStructValue_Freeze() {
+	type Server struct {
+		Name    string
+		ID      uint
+		Enabled bool
+		Count   int32
 	}
 
-	type T2 struct {
-		Options string `json:"options,omitempty"`
-		T3
+	server := Server{
+		Name:    "Roninzo",
+		ID:      123456,
+		Enabled: true,
+		Count:   5,
 	}
 
-	type T1 struct {
-		Name     string `json:"name,omitempty"`
-		ID       uint   `json:"id,omitempty"`
-		Enabled  bool   `json:"enabled,omitempty"`
-		Count    int32  `json:"count,omitempty"`
-		Password string `json:"-"`
-		Nested   T2     `json:"t2,omitempty"`
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	t := T1{
-		"Roninzo",
-		123456,
-		true,
-		0,
-		"abcdefg",
-		T2{"654321", T3{"cfg"}},
-	}
+	s.Freeze()
 
-	s, err := structs.New(&t)
+	err = s.Field("Name").Set("Apache")
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("Set[Error]: %v.\n", err)
+	}
+	err = s.SetZero()
+	if err != nil {
+		fmt.Printf("SetZero[Error]: %v.\n", err)
 	}
+	s.Field("Count").SetInt(6)
 
-	f1 := s.Field(0)
-	f2 := s.Field("Password")
-	f3 := s.Field("Nested").Struct().Field(0)
+	count, err := s.Field("Count").StringE()
+	if err != nil {
+		fmt.Printf("StringE[Error]: %v.\n", err)
+	}
 
-	fmt.Printf("IsEmbedded: %v\n", f1.IsEmbedded())
-	fmt.Printf("IsEmbedded: %v\n", f2.IsEmbedded())
-	fmt.Printf("IsEmbedded: %v\n", f3.IsEmbedded())
+	fmt.Printf("IsFrozen: %v.\n", s.IsFrozen())
+	fmt.Printf("CanSet  : %v.\n", s.Field("Name").CanSet())
+	fmt.Printf("Count   : %s.\n", count)
+	fmt.Printf("Server  : %+v.\n", server)
 
 	// Output:
-	// IsEmbedded: false
-	// IsEmbedded: false
-	// IsEmbedded: false
+	// Set[Error]: could not set field Server.Name: struct is read-only.
+	// SetZero[Error]: could not set struct Server to zero-value: struct is read-only.
+	// IsFrozen: true.
+	// CanSet  : false.
+	// Count   : 5.
+	// Server  : {Name:Roninzo ID:123456 Enabled:true Count:5}.
 }
 
-func ExampleStructField_IsExported() {
+func ExampleStructValue_ParentField() {
+	type Program struct {
+		Name string
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name    string
+		Backup  *Program `json:"backup,omitempty"`
+		Current Program  `json:"current"`
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:    "Roninzo",
+		Backup:  &Program{Name: "Apache"},
+		Current: Program{Name: "Nginx"},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	fmt.Printf("IsExported: %v\n", s.Field(0).IsExported())
-	fmt.Printf("IsExported: %v\n", s.Field("Password").IsExported())
-	fmt.Printf("IsExported: %v\n", s.Field("unexported").IsExported())
+	for _, name := range []string{"Backup", "Current"} {
+		p := s.Field(name).Struct()
+		f := p.ParentField()
+		tag, _ := f.Tag("json")
+		fmt.Printf("%s: %s (json: %q).\n", f.Name(), p.Field("Name").String(), tag)
+	}
+	fmt.Printf("Root: %v.\n", s.ParentField() == nil)
 
 	// Output:
-	// IsExported: true
-	// IsExported: true
-	// IsExported: false
+	// Backup: Apache (json: "backup,omitempty").
+	// Current: Nginx (json: "current").
+	// Root: true.
 }
 
-func ExampleStructField_IsHidden() {
+func ExampleStructValue_MapFields() {
+	type Program struct {
+		Name string `normalize:"lower"`
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name    string `normalize:"lower"`
+		Label   string
+		Program Program
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:    "RONINZO",
+		Label:   "Main Server",
+		Program: Program{Name: "Apache"},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	fmt.Printf("IsHidden: %v\n", s.Field(0).IsHidden())
-	fmt.Printf("IsHidden: %v\n", s.Field("Password").IsHidden())
-	fmt.Printf("IsHidden: %v\n", s.Field("unexported").IsHidden())
+	_, err = s.MapFields(func(f *structs.StructField) error {
+		if tag, _ := f.Tag("normalize"); tag == "lower" {
+			return f.Set(strings.ToLower(f.String()))
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("MapFields[Error]: %v.\n", err)
+	}
+
+	fmt.Printf("Server: %+v.\n", server)
 
 	// Output:
-	// IsHidden: false
-	// IsHidden: true
-	// IsHidden: false
+	// Server: {Name:roninzo Label:Main Server Program:{Name:apache}}.
 }
 
-func ExampleStructField_Zero() {
+func ExampleStructValue_MapDeep() {
+	type Program struct {
+		Name string
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name     string
+		Programs []Program
+		Backups  map[string]*Program
+		Tags     []string
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      5,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:     "Roninzo",
+		Programs: []Program{{Name: "Apache"}, {Name: "PHP"}},
+		Backups:  map[string]*Program{"web": {Name: "Nginx"}},
+		Tags:     []string{"web", "prod"},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	f1 := s.Field("Enabled")
-	f2 := s.Field("Count")
+	_, err = s.MapDeep(func(v reflect.Value) error {
+		if v.Kind() == reflect.String {
+			v.SetString(strings.ToUpper(v.String()))
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("MapDeep[Error]: %v.\n", err)
+	}
 
-	fmt.Printf("Zero: %v\n", f1.Zero())
-	fmt.Printf("Zero: %v\n", f2.Zero())
+	fmt.Printf("Name    : %s.\n", server.Name)
+	fmt.Printf("Programs: %+v.\n", server.Programs)
+	fmt.Printf("Backups : %+v.\n", *server.Backups["web"])
+	fmt.Printf("Tags    : %v.\n", server.Tags)
 
 	// Output:
-	// Zero: false
-	// Zero: 0
+	// Name    : RONINZO.
+	// Programs: [{Name:APACHE} {Name:PHP}].
+	// Backups : {Name:NGINX}.
+	// Tags    : [web prod].
 }
 
-func ExampleStructField_IsZero() {
+func ExampleStructValue_IndexOf() {
+	type Program struct {
+		Name string
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name     string
+		Program  Program
+		Programs []Program
+		Labels   map[string]string
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:     "Roninzo",
+		Program:  Program{Name: "Apache"},
+		Programs: []Program{{Name: "Nginx"}, {Name: "PHP"}},
+		Labels:   map[string]string{"env": "prod"},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Count")
-
-	fmt.Printf("IsZero: %v\n", f1.IsZero())
-	fmt.Printf("IsZero: %v\n", f2.IsZero())
+	for _, x := range []interface{}{"Apache", "PHP", "prod", Program{Name: "Nginx"}, "MySQL"} {
+		path, ok := s.IndexOf(x)
+		fmt.Printf("IndexOf(%v): %q, %v.\n", x, path, ok)
+	}
 
 	// Output:
-	// IsZero: false
-	// IsZero: true
+	// IndexOf(Apache): "Program.Name", true.
+	// IndexOf(PHP): "Programs[1].Name", true.
+	// IndexOf(prod): "Labels[env]", true.
+	// IndexOf({Nginx}): "Programs[0]", true.
+	// IndexOf(MySQL): "", false.
 }
 
-func ExampleStructField_IsNil() {
-	type Server struct {
-		Name       string  `json:"name,omitempty"`
-		ID         *uint   `json:"id,omitempty"`
-		Enabled    bool    `json:"enabled,omitempty"`
-		Count      *int32  `json:"count,omitempty"`
-		Password   *string `json:"-"`
-		unexported bool
+func ExampleStructValue_Pick() {
+	type User struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
 	}
 
-	var id uint = 5
-	server := Server{
-		Name:       "Roninzo",
-		ID:         &id,
-		Enabled:    true,
-		Count:      nil,
-		Password:   nil,
-		unexported: true,
+	user := User{ID: 1, Name: "Roninzo", Password: "s3cr3t"}
+
+	s, err := structs.New(&user)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	s, err := structs.New(&server)
+	p, err := s.Pick("Name")
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("Pick[Error]: %v.\n", err)
+		return
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("ID")
-	f3 := s.Field("Count")
-	f4 := s.Field("Password")
+	o, err := s.Omit("Password")
+	if err != nil {
+		fmt.Printf("Omit[Error]: %v.\n", err)
+		return
+	}
 
-	fmt.Printf("IsNil: %v\n", f1.IsNil())
-	fmt.Printf("IsNil: %v\n", f2.IsNil())
-	fmt.Printf("IsNil: %v\n", f3.IsNil())
-	fmt.Printf("IsNil: %v\n", f4.IsNil())
+	b1, _ := json.Marshal(p)
+	b2, _ := json.Marshal(o)
+
+	fmt.Printf("Pick: %s.\n", b1)
+	fmt.Printf("Omit: %s.\n", b2)
 
 	// Output:
-	// IsNil: false
-	// IsNil: false
-	// IsNil: true
-	// IsNil: true
+	// Pick: {"name":"Roninzo"}.
+	// Omit: {"id":1,"name":"Roninzo"}.
 }
 
-func ExampleStructField_Index() {
+func ExampleStructValue_Pairs() {
+	type Program struct {
+		Name string
+	}
+
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name    string
+		ID      uint
+		Enabled bool
+		Program Program
 	}
 
 	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+		Name:    "Roninzo",
+		ID:      123456,
+		Enabled: true,
+		Program: Program{Name: "Apache"},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	f1 := s.Field(0)
-	f2 := s.Field("Count")
+	for _, p := range s.Pairs() {
+		fmt.Printf("%s: %v.\n", p.Key, p.Value)
+	}
 
-	fmt.Printf("Index: %v\n", f1.Index())
-	fmt.Printf("Index: %v\n", f2.Index())
+	// Output:
+	// Name: Roninzo.
+	// ID: 123456.
+	// Enabled: true.
+	// Program.Name: Apache.
+}
+
+func ExampleStructValue_Addr() {
+	type Server struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	}
+	servers := []Server{{Name: "Apache"}, {Name: "Nginx"}}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if rows.Field("Name").String() == "Nginx" {
+			err = json.Unmarshal([]byte(`{"enabled":true}`), rows.Addr())
+			if err != nil {
+				fmt.Printf("Unmarshal[Error]: %v.\n", err)
+			}
+		}
+	}
+	fmt.Printf("Servers: %v.\n", servers)
+	s, _ = structs.New(servers[0])
+	fmt.Printf("Addr: %v.\n", s.Addr())
 
 	// Output:
-	// Index: 0
-	// Index: 3
+	// Servers: [{Apache false} {Nginx true}].
+	// Addr: <nil>.
 }
 
-func ExampleStructField_Set() {
+func ExampleStructValue_NewElem() {
 	type Server struct {
-		Name       string `json:"name,omitempty"`
-		ID         uint   `json:"id,omitempty"`
-		Enabled    bool   `json:"enabled,omitempty"`
-		Count      int32  `json:"count,omitempty"`
-		Password   string `json:"-"`
-		unexported bool
+		Name    string
+		Enabled bool
+	}
+	servers := []*Server{{Name: "Apache"}}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
 	}
+	e := s.NewElem()
+	if err := s.Err(); err != nil {
+		fmt.Printf("NewElem[Error]: %v.\n", err)
+	}
+	e.Field("Name").Set("Nginx")
+	e.Field("Enabled").Set(true)
+	fmt.Printf("Elem: %+v.\n", e.Addr())
+	s, _ = structs.New(servers[0])
+	s.NewElem()
+	fmt.Printf("NewElem[Error]: %v.\n", s.Err())
 
-	server := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Count:      0,
-		Password:   "abcdefg",
-		unexported: true,
+	// Output:
+	// Elem: &{Name:Nginx Enabled:true}.
+	// NewElem[Error]: structs not found.
+}
+
+func ExampleStructValue_Append() {
+	type Server struct {
+		Name    string
+		Enabled bool
 	}
+	servers := []*Server{{Name: "Apache"}}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	e := s.NewElem()
+	e.Field("Name").Set("Nginx")
+	err = s.Append(e, Server{Name: "Caddy", Enabled: true})
+	if err != nil {
+		fmt.Printf("Append[Error]: %v.\n", err)
+	}
+	for _, server := range servers {
+		fmt.Printf("Server: %+v.\n", *server)
+	}
+	err = s.Append("Traefik")
+	fmt.Printf("Append[Error]: %v.\n", err)
+
+	// Output:
+	// Server: {Name:Apache Enabled:false}.
+	// Server: {Name:Nginx Enabled:false}.
+	// Server: {Name:Caddy Enabled:true}.
+	// Append[Error]: could not append value 0 to slice of Server: invalid value of type string; want: *structs_test.Server.
+}
 
+func ExampleStructValue_Set() {
+	type Program struct {
+		Name    string
+		Version string
+	}
+	type Server struct {
+		Name    string
+		Program Program
+	}
+	server := Server{Name: "Apache", Program: Program{Name: "httpd", Version: "2.2"}}
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	p := s.Field("Program").Struct()
+	err = p.Set(Program{Name: "nginx", Version: "1.21"})
+	if err != nil {
+		fmt.Printf("Set[Error]: %v.\n", err)
 	}
+	fmt.Printf("Server: %+v.\n", server)
+	err = p.Set(server)
+	fmt.Printf("Set[Error]: %v.\n", err)
 
-	f1 := s.Field(0)
-	f2 := s.Field("Count")
-	f3 := s.Field("unexported")
+	// Output:
+	// Server: {Name:Apache Program:{Name:nginx Version:1.21}}.
+	// Set[Error]: could not set struct Server.Program: invalid value of type structs_test.Server; want: structs_test.Program.
+}
 
-	fmt.Printf("Value: %v.\n", f1.Value())
-	fmt.Printf("Value: %v.\n", f2.Value())
-	fmt.Printf("Value: %v.\n", f3.Value())
+func ExampleStructValue_Names() {
+	type Program struct {
+		Name    string `json:"name"`
+		Version string `json:"-"`
+	}
+	type Server struct {
+		Name    string   `json:"name"`
+		Program *Program `json:"program"`
+		count   int
+	}
+	server := Server{Name: "Apache", Program: &Program{Name: "httpd"}}
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	fmt.Printf("Names: %v.\n", s.Names())
+	fmt.Printf("Names: %v.\n", s.Names(structs.Tagged("json"), structs.ExportedOnly(), structs.Recursive()))
 
-	err1 := f1.Set("Unknown")
-	err2 := f2.Set(10)
-	err3 := f3.Set(false)
+	// Output:
+	// Names: [Name Program count].
+	// Names: [name program.name].
+}
 
-	fmt.Printf("Value: %v.\n", f1.Value())
-	fmt.Printf("Value: %v.\n", f2.Value())
-	fmt.Printf("Value: %v.\n", f3.Value())
-	fmt.Printf("Error: %v.\n", err1)
-	fmt.Printf("Error: %v.\n", err2)
-	fmt.Printf("Error: %v.\n", err3)
+func ExampleStructValue_FieldsDeep() {
+	type Program struct {
+		Name    string
+		Version string
+	}
+	type Server struct {
+		Name    string
+		Program Program
+		Backup  *Program
+	}
+	server := Server{Name: "Apache", Program: Program{Name: "httpd", Version: "2.4"}}
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	for _, f := range s.FieldsDeep() {
+		fmt.Printf("%s of %s: %v.\n", f.Namespace(), f.Parent.Name(), f.Value())
+	}
 
 	// Output:
-	// Value: Roninzo.
-	// Value: 0.
-	// Value: false.
-	// Value: Unknown.
-	// Value: 10.
-	// Value: false.
-	// Error: <nil>.
-	// Error: <nil>.
-	// Error: could not set field Server.unexported: struct field is not settable.
+	// Name of Server: Apache.
+	// Program.Name of Program: httpd.
+	// Program.Version of Program: 2.4.
 }
 
-/*   S t r u c t F i e l d   -   L o c a l   V a r i a b l e s   */
+func ExampleStructValue_Values_shallow() {
+	type Program struct {
+		Name    string
+		Version string
+	}
+	type Server struct {
+		Name    string
+		Program Program
+		count   int
+	}
+	server := Server{Name: "Roninzo", Program: Program{Name: "Apache", Version: "2.4"}, count: 3}
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v.\n", err)
+	}
+	fmt.Printf("Values: %v.\n", s.Values())
+	for i, value := range s.Values(structs.Shallow()) {
+		fmt.Printf("Values[%d]: %+v.\n", i, value.Interface())
+	}
+	fmt.Printf("NumField: %d.\n", s.NumField())
+	fmt.Printf("IndirectValues: %d.\n", len(s.IndirectValues(structs.Shallow())))
 
-type structTest struct {
-	String          string                 `json:"string,omitempty"`
-	Bool            bool                   `json:"bool,omitempty"`
-	Int             int                    `json:"int,omitempty"`
-	Uint            uint                   `json:"uint,omitempty"`
-	Float           float32                `json:"float,omitempty"`
-	Complex         complex128             `json:"complex,omitempty"` // `json:"-"` // crashes Sprint!
-	Bytes           []byte                 `json:"bytes,omitempty"`
-	Interface       interface{}            `json:"interface,omitempty"`
+	// Output:
+	// Values: [Roninzo Apache 2.4].
+	// Values[0]: Roninzo.
+	// Values[1]: {Name:Apache Version:2.4}.
+	// NumField: 3.
+	// IndirectValues: 3.
+}
+
+func ExampleStructValue_InterfaceValues() {
+	type Server struct {
+		Name    string
+		ID      uint
+		Enabled *bool
+		count   int
+	}
+	server := Server{Name: "Roninzo", ID: 123456, count: 3}
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v.\n", err)
+	}
+	values := s.InterfaceValues()
+	fmt.Printf("InterfaceValues: %v.\n", values)
+	fmt.Println(values...)
+
+	// Output:
+	// InterfaceValues: [Roninzo 123456 <nil>].
+	// Roninzo 123456 <nil>
+}
+
+/*   S t r u c t F i e l d   */
+
+func ExampleStructField_Indirect() {
+	type Server struct {
+		Name    *string `json:"name"`
+		ID      *uint   `json:"id"`
+		Enabled *bool   `json:"enabled"`
+		Count   *int32  `json:"count"`
+	}
+
+	server := Server{
+		Name:    pointers.String("Roninzo"),
+		ID:      pointers.Uint(uint(123456)),
+		Enabled: pointers.Bool(true),
+		Count:   pointers.Int32(int32(5)),
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error          : %v\n", err)
+	}
+
+	fmt.Printf("Name           : %v\n", s.Name())
+	fmt.Printf("Value of ID    : %v\n", s.Field("ID").Indirect())
+	fmt.Printf("Value of 0     : %v\n", s.Field(0).Indirect())
+	fmt.Printf("Value of Count : %v\n", s.Field("Count").Indirect())
+	fmt.Printf("Sprint: %s.\n", s.Sprint())
+
+	err = s.Field("ID").Set(pointers.Uint(uint(654321)))
+	if err != nil {
+		fmt.Printf("Error          : %v\n", err)
+	}
+
+	err = s.Field("Count").Set(pointers.Int32(int32(6)))
+	if err != nil {
+		fmt.Printf("Error          : %v\n", err)
+	}
+
+	err = s.Field("Enabled").Set(pointers.Int32(int32(6))) // not compatible with bool
+	if err != nil {
+		fmt.Printf("Error          : %v\n", err)
+	}
+
+	fmt.Printf("Value of Name  : %v\n", s.Field("Name").Indirect())
+	fmt.Printf("Value of ID    : %v\n", s.Field("ID").Indirect())
+	fmt.Printf("Value of Count : %v\n", s.Field("Count").Indirect())
+	fmt.Printf("Sprint: %s.\n", s.Sprint())
+	fmt.Printf("\nVerification   :\n")
+	fmt.Printf("server.Name    : %s\n", *server.Name)
+	fmt.Printf("server.ID      : %d\n", *server.ID)
+	fmt.Printf("server.Count   : %d\n", *server.Count)
+
+	// Output:
+	// Name           : Server
+	// Value of ID    : 123456
+	// Value of 0     : Roninzo
+	// Value of Count : 5
+	// Sprint: {
+	//  	"name": "Roninzo",
+	//  	"id": 123456,
+	//  	"enabled": true,
+	//  	"count": 5
+	//  }.
+	// Error          : wrong kind of value for field Server.Enabled. got: "*int32" want: "*bool"
+	// Value of Name  : Roninzo
+	// Value of ID    : 654321
+	// Value of Count : 6
+	// Sprint: {
+	//  	"name": "Roninzo",
+	//  	"id": 654321,
+	//  	"enabled": true,
+	//  	"count": 6
+	//  }.
+	//
+	// Verification   :
+	// server.Name    : Roninzo
+	// server.ID      : 654321
+	// server.Count   : 6
+}
+
+func ExampleStructField_IsValid() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := &structs.StructField{}
+
+	fmt.Printf("IsValid: %v\n", f1.IsValid())
+	fmt.Printf("IsValid: %v\n", f2.IsValid())
+
+	// Output:
+	// IsValid: true
+	// IsValid: false
+}
+
+func ExampleStructField_Name() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Enabled")
+
+	fmt.Printf("Field: %v\n", f1.Name())
+	fmt.Printf("Field: %v\n", f2.Name())
+
+	// Output:
+	// Field: Name
+	// Field: Enabled
+}
+
+func ExampleStructField_Namespace() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	type Server struct {
+		Name     string   `json:"name,omitempty"`
+		ID       uint     `json:"id,omitempty"`
+		Enabled  bool     `json:"enabled,omitempty"`
+		Count    int32    `json:"count,omitempty"`
+		Password string   `json:"-"`
+		Program  *Program `json:"program,omitempty"`
+	}
+
+	program := Program{
+		Name: "Apache",
+	}
+
+	server := Server{
+		Name:     "Roninzo",
+		ID:       123456,
+		Enabled:  true,
+		Count:    0,
+		Password: "abcdefg",
+		Program:  &program,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.FindStruct("Program").Field(0)
+
+	fmt.Printf("FullName: %v\n", f1.FullName())
+	fmt.Printf("FullName: %v\n", f2.FullName())
+
+	// Output:
+	// FullName: Server.Name
+	// FullName: Server.Program.Name
+}
+
+func ExampleStructField_Value() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Enabled")
+
+	fmt.Printf("Value: %v\n", f1.Value())
+	fmt.Printf("Value: %v\n", f2.Value())
+
+	// Output:
+	// Value: Roninzo
+	// Value: true
+}
+
+func ExampleStructField_Type() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Enabled")
+
+	fmt.Printf("Type: %v\n", s.Type())
+	fmt.Printf("Type: %v\n", f1.Type())
+	fmt.Printf("Type: %v\n", f2.Type())
+
+	// Output:
+	// Type: structs_test.Server
+	// Type: string
+	// Type: bool
+}
+
+func ExampleStructField_Kind() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Enabled")
+
+	fmt.Printf("Kind: %v\n", f1.Kind())
+	fmt.Printf("Kind: %v\n", f2.Kind())
+
+	// Output:
+	// Kind: string
+	// Kind: bool
+}
+
+func ExampleStructField_Tag() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	t1, _ := s.Field(0).Tag("json")
+	t2, _ := s.Field("Enabled").Tag("json")
+
+	fmt.Printf("Tag: %v\n", t1)
+	fmt.Printf("Tag: %v\n", t2)
+
+	// Output:
+	// Tag: name,omitempty
+	// Tag: enabled,omitempty
+}
+
+func ExampleStructField_CanSet() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	type Server struct {
+		Name     string  `json:"name,omitempty"`
+		ID       uint    `json:"id,omitempty"`
+		Enabled  bool    `json:"enabled,omitempty"`
+		Count    int32   `json:"count,omitempty"`
+		Password string  `json:"-"`
+		Program  Program `json:"program,omitempty"`
+	}
+
+	program := Program{
+		Name: "Apache",
+	}
+
+	server := Server{
+		Name:     "Roninzo",
+		ID:       123456,
+		Enabled:  true,
+		Count:    0,
+		Password: "abcdefg",
+		Program:  program,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field(0)
+
+	fmt.Printf("CanSet: %v\n", f.CanSet())
+
+	s, err = structs.New(server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f = s.Field(0)
+
+	fmt.Printf("CanSet: %v\n", f.CanSet())
+
+	// Output:
+	// CanSet: true
+	// CanSet: false
+}
+
+// TODO: No example of Anonymous fields?
+
+func ExampleStructField_IsAnonymous() {
+	type T3 struct {
+		Config string `json:"config,omitempty"`
+	}
+
+	type T2 struct {
+		Options string `json:"options,omitempty"`
+		T3
+	}
+
+	type T1 struct {
+		Name     string `json:"name,omitempty"`
+		ID       uint   `json:"id,omitempty"`
+		Enabled  bool   `json:"enabled,omitempty"`
+		Count    int32  `json:"count,omitempty"`
+		Password string `json:"-"`
+		Nested   T2     `json:"t2,omitempty"`
+	}
+
+	t := T1{
+		"Roninzo",
+		123456,
+		true,
+		0,
+		"abcdefg",
+		T2{"654321", T3{"cfg"}},
+	}
+
+	s, err := structs.New(&t)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Password")
+	f3 := s.Field("Nested").Struct().Field(0)
+
+	fmt.Printf("IsAnonymous: %v\n", f1.IsAnonymous())
+	fmt.Printf("IsAnonymous: %v\n", f2.IsAnonymous())
+	fmt.Printf("IsAnonymous: %v\n", f3.IsAnonymous())
+
+	// Output:
+	// IsAnonymous: false
+	// IsAnonymous: false
+	// IsAnonymous: false
+}
+
+// TODO: No example of Embedded fields?
+
+func ExampleStructField_IsEmbedded() {
+	type T3 struct {
+		Config string `json:"config,omitempty"`
+	}
+
+	type T2 struct {
+		Options string `json:"options,omitempty"`
+		T3
+	}
+
+	type T1 struct {
+		Name     string `json:"name,omitempty"`
+		ID       uint   `json:"id,omitempty"`
+		Enabled  bool   `json:"enabled,omitempty"`
+		Count    int32  `json:"count,omitempty"`
+		Password string `json:"-"`
+		Nested   T2     `json:"t2,omitempty"`
+	}
+
+	t := T1{
+		"Roninzo",
+		123456,
+		true,
+		0,
+		"abcdefg",
+		T2{"654321", T3{"cfg"}},
+	}
+
+	s, err := structs.New(&t)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Password")
+	f3 := s.Field("Nested").Struct().Field(0)
+
+	fmt.Printf("IsEmbedded: %v\n", f1.IsEmbedded())
+	fmt.Printf("IsEmbedded: %v\n", f2.IsEmbedded())
+	fmt.Printf("IsEmbedded: %v\n", f3.IsEmbedded())
+
+	// Output:
+	// IsEmbedded: false
+	// IsEmbedded: false
+	// IsEmbedded: false
+}
+
+func ExampleStructField_IsExported() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	fmt.Printf("IsExported: %v\n", s.Field(0).IsExported())
+	fmt.Printf("IsExported: %v\n", s.Field("Password").IsExported())
+	fmt.Printf("IsExported: %v\n", s.Field("unexported").IsExported())
+
+	// Output:
+	// IsExported: true
+	// IsExported: true
+	// IsExported: false
+}
+
+func ExampleStructField_IsHidden() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	fmt.Printf("IsHidden: %v\n", s.Field(0).IsHidden())
+	fmt.Printf("IsHidden: %v\n", s.Field("Password").IsHidden())
+	fmt.Printf("IsHidden: %v\n", s.Field("unexported").IsHidden())
+
+	// Output:
+	// IsHidden: false
+	// IsHidden: true
+	// IsHidden: false
+}
+
+func ExampleStructField_Zero() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      5,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field("Enabled")
+	f2 := s.Field("Count")
+
+	fmt.Printf("Zero: %v\n", f1.Zero())
+	fmt.Printf("Zero: %v\n", f2.Zero())
+
+	// Output:
+	// Zero: false
+	// Zero: 0
+}
+
+func ExampleStructField_IsZero() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Count")
+
+	fmt.Printf("IsZero: %v\n", f1.IsZero())
+	fmt.Printf("IsZero: %v\n", f2.IsZero())
+
+	// Output:
+	// IsZero: false
+	// IsZero: true
+}
+
+func ExampleStructField_IsNil() {
+	type Server struct {
+		Name       string  `json:"name,omitempty"`
+		ID         *uint   `json:"id,omitempty"`
+		Enabled    bool    `json:"enabled,omitempty"`
+		Count      *int32  `json:"count,omitempty"`
+		Password   *string `json:"-"`
+		unexported bool
+	}
+
+	var id uint = 5
+	server := Server{
+		Name:       "Roninzo",
+		ID:         &id,
+		Enabled:    true,
+		Count:      nil,
+		Password:   nil,
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("ID")
+	f3 := s.Field("Count")
+	f4 := s.Field("Password")
+
+	fmt.Printf("IsNil: %v\n", f1.IsNil())
+	fmt.Printf("IsNil: %v\n", f2.IsNil())
+	fmt.Printf("IsNil: %v\n", f3.IsNil())
+	fmt.Printf("IsNil: %v\n", f4.IsNil())
+
+	// Output:
+	// IsNil: false
+	// IsNil: false
+	// IsNil: true
+	// IsNil: true
+}
+
+func ExampleStructField_Index() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Count")
+
+	fmt.Printf("Index: %v\n", f1.Index())
+	fmt.Printf("Index: %v\n", f2.Index())
+
+	// Output:
+	// Index: 0
+	// Index: 3
+}
+
+func ExampleStructField_Set() {
+	type Server struct {
+		Name       string `json:"name,omitempty"`
+		ID         uint   `json:"id,omitempty"`
+		Enabled    bool   `json:"enabled,omitempty"`
+		Count      int32  `json:"count,omitempty"`
+		Password   string `json:"-"`
+		unexported bool
+	}
+
+	server := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Count:      0,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f1 := s.Field(0)
+	f2 := s.Field("Count")
+	f3 := s.Field("unexported")
+
+	fmt.Printf("Value: %v.\n", f1.Value())
+	fmt.Printf("Value: %v.\n", f2.Value())
+	fmt.Printf("Value: %v.\n", f3.Value())
+
+	err1 := f1.Set("Unknown")
+	err2 := f2.Set(10)
+	err3 := f3.Set(false)
+
+	fmt.Printf("Value: %v.\n", f1.Value())
+	fmt.Printf("Value: %v.\n", f2.Value())
+	fmt.Printf("Value: %v.\n", f3.Value())
+	fmt.Printf("Error: %v.\n", err1)
+	fmt.Printf("Error: %v.\n", err2)
+	fmt.Printf("Error: %v.\n", err3)
+
+	// Output:
+	// Value: Roninzo.
+	// Value: 0.
+	// Value: false.
+	// Value: Unknown.
+	// Value: 10.
+	// Value: false.
+	// Error: <nil>.
+	// Error: <nil>.
+	// Error: could not set field Server.unexported: struct field is not settable.
+}
+
+/*   S t r u c t F i e l d   -   L o c a l   V a r i a b l e s   */
+
+type structTest struct {
+	String          string                 `json:"string,omitempty"`
+	Bool            bool                   `json:"bool,omitempty"`
+	Int             int                    `json:"int,omitempty"`
+	Uint            uint                   `json:"uint,omitempty"`
+	Float           float32                `json:"float,omitempty"`
+	Complex         complex128             `json:"complex,omitempty"`
+	Bytes           []byte                 `json:"bytes,omitempty"`
+	Interface       interface{}            `json:"interface,omitempty"`
 	Error           error                  `json:"error,omitempty"`
 	Time            time.Time              `json:"time,omitempty"`
 	Duration        time.Duration          `json:"duration,omitempty"`
@@ -1826,7 +2809,7 @@ type structTest struct {
 	PtrInt          *int                   `json:"pointer_int,omitempty"`
 	PtrUint         *uint                  `json:"pointer_uint,omitempty"`
 	PtrFloat        *float32               `json:"pointer_float,omitempty"`
-	PtrComplex      *complex128            `json:"pointer_complex,omitempty"` // `json:"-"` // crashes Sprint!
+	PtrComplex      *complex128            `json:"pointer_complex,omitempty"`
 	PtrError        *error                 `json:"pointer_error,omitempty"`
 	PtrTime         *time.Time             `json:"pointer_time,omitempty"`
 	PtrDuration     *time.Duration         `json:"pointer_duration,omitempty"`
@@ -1836,14 +2819,14 @@ type structTest struct {
 	MapInt          map[string]int         `json:"mapint,omitempty"`
 	MapUint         map[string]uint        `json:"map_uint,omitempty"`
 	MapFloat        map[string]float32     `json:"map_float,omitempty"`
-	MapComplex      map[string]complex128  `json:"map_complex,omitempty"` // `json:"-"` // crashes Sprint!
+	MapComplex      map[string]complex128  `json:"map_complex,omitempty"`
 	MapInterface    map[string]interface{} `json:"map_interface,omitempty"`
 	SliceString     []string               `json:"slice_string,omitempty"`
 	SliceBool       []bool                 `json:"slice_bool,omitempty"`
 	SliceInt        []int                  `json:"slice_int,omitempty"`
 	SliceUint       []uint                 `json:"slice_uint,omitempty"`
 	SliceFloat      []float32              `json:"slice_float,omitempty"`
-	SliceComplex    []complex128           `json:"slice_complex,omitempty"` // `json:"-"` // crashes Sprint!
+	SliceComplex    []complex128           `json:"slice_complex,omitempty"`
 	SliceInterface  []interface{}          `json:"slice_interface,omitempty"`
 	SlicePtrString  []*string              `json:"slice_pointer_string,omitempty"`
 	SlicePtrBool    []*bool                `json:"slice_pointer_bool,omitempty"`
@@ -2588,6 +3571,47 @@ func ExampleStructField_Get() {
 	// Get   Password   : abcdefg.
 }
 
+func ExampleStructField_Set_textToNumber() {
+	type Config struct {
+		Port    int
+		Workers uint8
+		Ratio   float64
+		Phase   complex128
+	}
+
+	c := Config{}
+
+	s, err := structs.New(&c)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
+	}
+
+	for name, value := range map[string]string{"Port": "8080", "Workers": "4", "Ratio": "0.75", "Phase": "(1+2i)"} {
+		err := s.Field(name).Set(value)
+		if err != nil {
+			fmt.Printf("Set[Error]: %v.\n", err)
+		}
+	}
+
+	err = s.Field("Workers").Set("512")
+	if err != nil {
+		fmt.Printf("Set[Error]: %v.\n", err)
+	}
+
+	fmt.Printf("Port   : %d.\n", c.Port)
+	fmt.Printf("Workers: %d.\n", c.Workers)
+	fmt.Printf("Ratio  : %v.\n", c.Ratio)
+	fmt.Printf("Phase  : %v.\n", c.Phase)
+
+	// Output:
+	// Set[Error]: invalid value for field Config.Workers: value 512 overflows uint8.
+	// Port   : 8080.
+	// Workers: 4.
+	// Ratio  : 0.75.
+	// Phase  : (1+2i).
+}
+
 func ExampleStructField_SetZero() {
 	type Server struct {
 		Name       string `json:"name,omitempty"`
@@ -3481,54 +4505,245 @@ func ExampleStructField_Interface() {
 	}
 
 	// Output:
-	// Interface: 654321.
+	// Interface: 654321.
+}
+
+func ExampleStructField_Struct() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	type Server struct {
+		Name     string  `json:"name,omitempty"`
+		ID       uint    `json:"id,omitempty"`
+		Enabled  bool    `json:"enabled,omitempty"`
+		Count    int32   `json:"count,omitempty"`
+		Password string  `json:"-"`
+		Program  Program `json:"program,omitempty"`
+	}
+
+	program := Program{
+		Name: "Apache",
+	}
+
+	server := Server{
+		Name:     "Roninzo",
+		ID:       123456,
+		Enabled:  true,
+		Count:    0,
+		Password: "abcdefg",
+		Program:  program,
+	}
+
+	s1, _ := structs.New(&server)
+	s2 := s1.Field("Program").Struct()
+
+	fmt.Printf("Struct: %v\n", s1.Name())
+	fmt.Printf("Struct: %v\n", s2.Name())
+
+	// Output:
+	// Struct: Server
+	// Struct: Program
+}
+
+func ExampleStructField_SetTime() {
+	type Server struct {
+		ChangedAt time.Time `json:"changed_at,omitempty"`
+	}
+
+	server := Server{
+		ChangedAt: time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("ChangedAt")
+
+	if f.CanTime() {
+		fmt.Printf("Time: %v.\n", f.Time())
+		t := time.Date(2021, time.August, 31, 12, 30, 11, 0, time.UTC)
+		f.SetTime(t)
+		fmt.Printf("SetTime: %v.\n", f.Time())
+	}
+
+	// Output:
+	// Time: 2021-08-03 16:44:46 +0000 UTC.
+	// SetTime: 2021-08-31 12:30:11 +0000 UTC.
+}
+
+func ExampleStructField_SetDuration() {
+	type Server struct {
+		TimeOut time.Duration `json:"time_out,omitempty"`
+	}
+
+	server := Server{
+		TimeOut: 5 * time.Second,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("TimeOut")
+
+	if f.CanDuration() {
+		fmt.Printf("Duration: %v.\n", f.Duration())
+		f.SetDuration(30 * time.Second)
+		fmt.Printf("SetDuration: %v.\n", f.Duration())
+	}
+
+	// Output:
+	// Duration: 5s.
+	// SetDuration: 30s.
+}
+
+func ExampleStructField_SetError() {
+	type Server struct {
+		Err error `json:"err,omitempty"`
+	}
+
+	server := Server{
+		Err: errors.New("rows not found"),
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("Err")
+
+	if f.CanError() {
+		fmt.Printf("Error: %v.\n", f.Error())
+		err := errors.Wrap(f.Error(), "empty table")
+		f.SetError(err)
+		fmt.Printf("SetError: %v.\n", f.Error())
+	}
+
+	// Output:
+	// Error: rows not found.
+	// SetError: empty table: rows not found.
+}
+
+func ExampleStructField_SetString() {
+	type Server struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	server := Server{
+		Name: "Roninzo",
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("Name")
+
+	if f.CanString() {
+		fmt.Printf("String: %v.\n", f.String())
+		f.SetString("ozninoR")
+		fmt.Printf("SetString: %v.\n", f.String())
+	}
+
+	// Output:
+	// String: Roninzo.
+	// SetString: ozninoR.
+}
+
+func ExampleStructField_SetBool() {
+	type Server struct {
+		Enabled bool `json:"enabled,omitempty"`
+	}
+
+	server := Server{
+		Enabled: true,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("Enabled")
+
+	if f.CanBool() {
+		fmt.Printf("Bool: %v.\n", f.Bool())
+		f.SetBool(false)
+		fmt.Printf("SetBool: %v.\n", f.Bool())
+	}
+
+	// Output:
+	// Bool: true.
+	// SetBool: false.
+}
+
+func ExampleStructField_SetInt() {
+	type Server struct {
+		Count int `json:"count,omitempty"`
+	}
+
+	server := Server{
+		Count: 123456,
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	f := s.Field("Count")
+
+	if f.CanInt() {
+		fmt.Printf("Int: %v.\n", f.Int())
+		f.SetInt(654321)
+		fmt.Printf("SetInt: %v.\n", f.Int())
+	}
+
+	// Output:
+	// Int: 123456.
+	// SetInt: 654321.
 }
 
-func ExampleStructField_Struct() {
-	type Program struct {
-		Name string `json:"name,omitempty"`
-	}
-
+func ExampleStructField_SetUint() {
 	type Server struct {
-		Name     string  `json:"name,omitempty"`
-		ID       uint    `json:"id,omitempty"`
-		Enabled  bool    `json:"enabled,omitempty"`
-		Count    int32   `json:"count,omitempty"`
-		Password string  `json:"-"`
-		Program  Program `json:"program,omitempty"`
+		ID uint `json:"id,omitempty"`
 	}
 
-	program := Program{
-		Name: "Apache",
+	server := Server{
+		ID: 123456,
 	}
 
-	server := Server{
-		Name:     "Roninzo",
-		ID:       123456,
-		Enabled:  true,
-		Count:    0,
-		Password: "abcdefg",
-		Program:  program,
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
 	}
 
-	s1, _ := structs.New(&server)
-	s2 := s1.Field("Program").Struct()
+	f := s.Field("ID")
 
-	fmt.Printf("Struct: %v\n", s1.Name())
-	fmt.Printf("Struct: %v\n", s2.Name())
+	if f.CanUint() {
+		fmt.Printf("Uint: %v.\n", f.Uint())
+		f.SetUint(654321)
+		fmt.Printf("SetUint: %v.\n", f.Uint())
+	}
 
 	// Output:
-	// Struct: Server
-	// Struct: Program
+	// Uint: 123456.
+	// SetUint: 654321.
 }
 
-func ExampleStructField_SetTime() {
+func ExampleStructField_SetFloat() {
 	type Server struct {
-		ChangedAt time.Time `json:"changed_at,omitempty"`
+		Price float32 `json:"price,omitempty"`
 	}
 
 	server := Server{
-		ChangedAt: time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
+		Price: 22.50,
 	}
 
 	s, err := structs.New(&server)
@@ -3536,27 +4751,26 @@ func ExampleStructField_SetTime() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("ChangedAt")
+	f := s.Field("Price")
 
-	if f.CanTime() {
-		fmt.Printf("Time: %v.\n", f.Time())
-		t := time.Date(2021, time.August, 31, 12, 30, 11, 0, time.UTC)
-		f.SetTime(t)
-		fmt.Printf("SetTime: %v.\n", f.Time())
+	if f.CanFloat() {
+		fmt.Printf("Float: %v.\n", f.Float())
+		f.SetFloat(450.50)
+		fmt.Printf("SetUint: %v.\n", f.Float())
 	}
 
 	// Output:
-	// Time: 2021-08-03 16:44:46 +0000 UTC.
-	// SetTime: 2021-08-31 12:30:11 +0000 UTC.
+	// Float: 22.5.
+	// SetUint: 450.5.
 }
 
-func ExampleStructField_SetDuration() {
+func ExampleStructField_SetComplex() {
 	type Server struct {
-		TimeOut time.Duration `json:"time_out,omitempty"`
+		Complex complex128 `json:"complex,omitempty"`
 	}
 
 	server := Server{
-		TimeOut: 5 * time.Second,
+		Complex: complex(22, 50),
 	}
 
 	s, err := structs.New(&server)
@@ -3564,26 +4778,26 @@ func ExampleStructField_SetDuration() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("TimeOut")
+	f := s.Field("Complex")
 
-	if f.CanDuration() {
-		fmt.Printf("Duration: %v.\n", f.Duration())
-		f.SetDuration(30 * time.Second)
-		fmt.Printf("SetDuration: %v.\n", f.Duration())
+	if f.CanComplex() {
+		fmt.Printf("Complex: %v.\n", f.Complex())
+		f.SetComplex(complex(77, 2))
+		fmt.Printf("SetComplex: %v.\n", f.Complex())
 	}
 
 	// Output:
-	// Duration: 5s.
-	// SetDuration: 30s.
+	// Complex: (22+50i).
+	// SetComplex: (77+2i).
 }
 
-func ExampleStructField_SetError() {
+func ExampleStructField_SetBytes() {
 	type Server struct {
-		Err error `json:"err,omitempty"`
+		Stream []byte `json:"stream,omitempty"`
 	}
 
 	server := Server{
-		Err: errors.New("rows not found"),
+		Stream: []byte("Hello world"),
 	}
 
 	s, err := structs.New(&server)
@@ -3591,27 +4805,32 @@ func ExampleStructField_SetError() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("Err")
+	f := s.Field("Stream")
 
-	if f.CanError() {
-		fmt.Printf("Error: %v.\n", f.Error())
-		err := errors.Wrap(f.Error(), "empty table")
-		f.SetError(err)
-		fmt.Printf("SetError: %v.\n", f.Error())
+	if f.CanBytes() {
+		b := f.Bytes()
+		fmt.Printf("Bytes: %v.\n", b)
+		fmt.Printf("BytesString: %v.\n", string(b))
+		f.SetBytes([]byte("Bye bye world"))
+		b = f.Bytes()
+		fmt.Printf("SetBytes: %v.\n", b)
+		fmt.Printf("SetBytesString: %v.\n", string(b))
 	}
 
 	// Output:
-	// Error: rows not found.
-	// SetError: empty table: rows not found.
+	// Bytes: [72 101 108 108 111 32 119 111 114 108 100].
+	// BytesString: Hello world.
+	// SetBytes: [66 121 101 32 98 121 101 32 119 111 114 108 100].
+	// SetBytesString: Bye bye world.
 }
 
-func ExampleStructField_SetString() {
+func ExampleStructField_SetInterface() {
 	type Server struct {
-		Name string `json:"name,omitempty"`
+		Anything interface{} `json:"anything,omitempty"`
 	}
 
 	server := Server{
-		Name: "Roninzo",
+		Anything: 654321,
 	}
 
 	s, err := structs.New(&server)
@@ -3619,26 +4838,34 @@ func ExampleStructField_SetString() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("Name")
+	f := s.Field("Anything")
 
-	if f.CanString() {
-		fmt.Printf("String: %v.\n", f.String())
-		f.SetString("ozninoR")
-		fmt.Printf("SetString: %v.\n", f.String())
+	if f.CanInterface() {
+		fmt.Printf("Interface: %v.\n", f.Interface())
+		f.SetInterface(123456)
+		fmt.Printf("SetInterface: %v.\n", f.Interface())
 	}
 
 	// Output:
-	// String: Roninzo.
-	// SetString: ozninoR.
+	// Interface: 654321.
+	// SetInterface: 123456.
 }
 
-func ExampleStructField_SetBool() {
+func ExampleStructField_SetStruct() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+
 	type Server struct {
-		Enabled bool `json:"enabled,omitempty"`
+		Program *Program `json:"program,omitempty"`
+	}
+
+	program := Program{
+		Name: "Apache",
 	}
 
 	server := Server{
-		Enabled: true,
+		Program: &program,
 	}
 
 	s, err := structs.New(&server)
@@ -3646,167 +4873,360 @@ func ExampleStructField_SetBool() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("Enabled")
+	f := s.Field("Program")
 
-	if f.CanBool() {
-		fmt.Printf("Bool: %v.\n", f.Bool())
-		f.SetBool(false)
-		fmt.Printf("SetBool: %v.\n", f.Bool())
+	if f.CanStruct() {
+		fmt.Printf("Struct: %v.\n", server.Program.Name)
+		program2 := Program{
+			Name: "Microsoft IIS",
+		}
+		p, err := structs.New(&program2)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		f.SetStruct(p)
+		fmt.Printf("SetStruct: %v.\n", server.Program.Name)
 	}
 
 	// Output:
-	// Bool: true.
-	// SetBool: false.
+	// Struct: Apache.
+	// SetStruct: Microsoft IIS.
 }
 
-func ExampleStructField_SetInt() {
+func ExampleStructField_Equal() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	program1 := Program{Name: "Apache"}
+	program2 := Program{Name: "Apache"}
+
 	type Server struct {
-		Count int `json:"count,omitempty"`
+		Name       string        `json:"name,omitempty"`
+		ID         uint          `json:"id,omitempty"`
+		Enabled    bool          `json:"enabled,omitempty"`
+		Err        error         `json:"err,omitempty"`
+		ChangedAt  time.Time     `json:"changed_at,omitempty"`
+		TimeOut    time.Duration `json:"time_out,omitempty"`
+		Count      int           `json:"count,omitempty"`
+		Price      float32       `json:"price,omitempty"`
+		Complex    complex128    `json:"complex,omitempty"`
+		Stream     []byte        `json:"stream,omitempty"`
+		Anything   interface{}   `json:"anything,omitempty"`
+		Program    *Program      `json:"program,omitempty"`
+		Password   string        `json:"-"`
+		unexported bool
 	}
 
-	server := Server{
-		Count: 123456,
+	server1 := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Err:        errors.New("rows not found"),
+		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
+		TimeOut:    5 * time.Second,
+		Count:      8,
+		Price:      1922.50,
+		Complex:    complex(22, 50),
+		Stream:     []byte("Hello world"),
+		Anything:   654321,
+		Program:    &program1,
+		Password:   "abcdefg",
+		unexported: true,
 	}
 
-	s, err := structs.New(&server)
+	server2 := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Err:        errors.New("rows not found"),
+		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
+		TimeOut:    5 * time.Second,
+		Count:      8,
+		Price:      1922.50,
+		Complex:    complex(22, 50),
+		Stream:     []byte("Hello world"),
+		Anything:   654321,
+		Program:    &program2,
+		Password:   "abcdefg",
+		unexported: true,
+	}
+
+	s1, err := structs.New(&server1)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("Count")
+	s2, err := structs.New(&server2)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	for i, f1 := range s1.Fields() {
+		f2 := s2.Field(i)
+		fmt.Printf("Equal: %v.\n", f1.Equal(f2))
+	}
+
+	// Output:
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: true.
+	// Equal: false.
+}
 
-	if f.CanInt() {
-		fmt.Printf("Int: %v.\n", f.Int())
-		f.SetInt(654321)
-		fmt.Printf("SetInt: %v.\n", f.Int())
+func ExampleStructField_Equal_different() {
+	type Program struct {
+		Name string `json:"name,omitempty"`
 	}
 
-	// Output:
-	// Int: 123456.
-	// SetInt: 654321.
-}
+	program1 := Program{Name: "Apache"}
+	program2 := Program{Name: "Microsoft IIS"}
 
-func ExampleStructField_SetUint() {
 	type Server struct {
-		ID uint `json:"id,omitempty"`
+		Name       string        `json:"name,omitempty"`
+		ID         uint          `json:"id,omitempty"`
+		Enabled    bool          `json:"enabled,omitempty"`
+		Err        error         `json:"err,omitempty"`
+		ChangedAt  time.Time     `json:"changed_at,omitempty"`
+		TimeOut    time.Duration `json:"time_out,omitempty"`
+		Count      int           `json:"count,omitempty"`
+		Price      float32       `json:"price,omitempty"`
+		Complex    complex128    `json:"complex,omitempty"`
+		Stream     []byte        `json:"stream,omitempty"`
+		Anything   interface{}   `json:"anything,omitempty"`
+		Program    *Program      `json:"program,omitempty"`
+		Password   string        `json:"-"`
+		unexported bool
 	}
 
-	server := Server{
-		ID: 123456,
+	server1 := Server{
+		Name:       "Roninzo",
+		ID:         123456,
+		Enabled:    true,
+		Err:        errors.New("rows not found"),
+		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
+		TimeOut:    5 * time.Second,
+		Count:      8,
+		Price:      1922.50,
+		Complex:    complex(22, 50),
+		Stream:     []byte("Hello world"),
+		Anything:   654321,
+		Program:    &program1,
+		Password:   "abcdefg",
+		unexported: true,
 	}
 
-	s, err := structs.New(&server)
+	server2 := Server{
+		Name:       "ozninoR",
+		ID:         654321,
+		Enabled:    false,
+		Err:        errors.New("not compliant"),
+		ChangedAt:  time.Date(2021, time.August, 31, 14, 11, 11, 0, time.UTC),
+		TimeOut:    30 * time.Second,
+		Count:      3,
+		Price:      7622.50,
+		Complex:    complex(-67, -42),
+		Stream:     []byte("Bye bye world"),
+		Anything:   123456,
+		Program:    &program2,
+		Password:   "gfedcba",
+		unexported: false,
+	}
+
+	s1, err := structs.New(&server1)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("ID")
+	s2, err := structs.New(&server2)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
 
-	if f.CanUint() {
-		fmt.Printf("Uint: %v.\n", f.Uint())
-		f.SetUint(654321)
-		fmt.Printf("SetUint: %v.\n", f.Uint())
+	for i, f1 := range s1.Fields() {
+		f2 := s2.Field(i)
+		fmt.Printf("Equal: %v.\n", f1.Equal(f2))
 	}
 
 	// Output:
-	// Uint: 123456.
-	// SetUint: 654321.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
+	// Equal: false.
 }
 
-func ExampleStructField_SetFloat() {
-	type Server struct {
-		Price float32 `json:"price,omitempty"`
+func ExampleStructField_Equal_pointerFields() {
+	type Program struct {
+		Name *string `json:"name,omitempty"`
 	}
 
-	server := Server{
-		Price: 22.50,
+	type Server struct {
+		Name       *string        `json:"name,omitempty"`
+		ID         *uint          `json:"id,omitempty"`
+		Enabled    *bool          `json:"enabled,omitempty"`
+		Err        *error         `json:"err,omitempty"`
+		ChangedAt  *time.Time     `json:"changed_at,omitempty"`
+		TimeOut    *time.Duration `json:"time_out,omitempty"`
+		Count      *int           `json:"count,omitempty"`
+		Price      *float32       `json:"price,omitempty"`
+		Stream     *[]byte        `json:"stream,omitempty"`
+		Complex    *complex128    `json:"complex,omitempty"`
+		Anything   interface{}    `json:"anything,omitempty"`
+		Program    *Program       `json:"program,omitempty"`
+		Password   *string        `json:"-"`
+		unexported *bool
 	}
 
-	s, err := structs.New(&server)
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+	program1 := Program{
+		Name: pointers.String("Apache"),
 	}
 
-	f := s.Field("Price")
-
-	if f.CanFloat() {
-		fmt.Printf("Float: %v.\n", f.Float())
-		f.SetFloat(450.50)
-		fmt.Printf("SetUint: %v.\n", f.Float())
+	server1 := Server{
+		Name:       pointers.String("Roninzo"),
+		ID:         pointers.Uint(123456),
+		Enabled:    pointers.Bool(true),
+		Err:        pointers.Error(errors.New("rows not found")),
+		ChangedAt:  pointers.Time(time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC)),
+		TimeOut:    pointers.Duration(5 * time.Second),
+		Count:      pointers.Int(8),
+		Price:      pointers.Float32(1922.50),
+		Stream:     pointers.Bytes([]byte("Hello world")),
+		Complex:    pointers.Complex128(complex(22, 50)),
+		Anything:   "test",
+		Program:    &program1,
+		Password:   pointers.String("abcdefg"),
+		unexported: pointers.Bool(true),
 	}
 
-	// Output:
-	// Float: 22.5.
-	// SetUint: 450.5.
-}
-
-func ExampleStructField_SetComplex() {
-	type Server struct {
-		Complex complex128 `json:"complex,omitempty"`
+	program2 := Program{
+		Name: pointers.String("Microsoft IIS"),
 	}
 
-	server := Server{
-		Complex: complex(22, 50),
+	server2 := Server{
+		Name:       pointers.String("ozninoR"),
+		ID:         pointers.Uint(654321),
+		Enabled:    pointers.Bool(false),
+		Err:        pointers.Error(errors.New("not compliant")),
+		ChangedAt:  pointers.Time(time.Date(2021, time.August, 31, 14, 11, 11, 0, time.UTC)),
+		TimeOut:    pointers.Duration(30 * time.Second),
+		Count:      pointers.Int(3),
+		Price:      pointers.Float32(7022.50),
+		Stream:     pointers.Bytes([]byte("Bye bye world")),
+		Complex:    pointers.Complex128(complex(99, 12)),
+		Anything:   654321,
+		Program:    &program2,
+		Password:   pointers.String("gfedcba"),
+		unexported: pointers.Bool(false),
 	}
 
-	s, err := structs.New(&server)
+	// backup <- server1 via cloning
+	clone, err := structs.Clone(&server1)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("Clone[Error]: %v.\n", err)
+		return
 	}
-
-	f := s.Field("Complex")
-
-	if f.CanComplex() {
-		fmt.Printf("Complex: %v.\n", f.Complex())
-		f.SetComplex(complex(77, 2))
-		fmt.Printf("SetComplex: %v.\n", f.Complex())
+	backup, ok := clone.(*Server)
+	if !ok {
+		fmt.Printf("TypeAssertion[Error]: %v.\n", err)
+		return
 	}
 
-	// Output:
-	// Complex: (22+50i).
-	// SetComplex: (77+2i).
-}
+	s1, err := structs.New(&server1)
+	if err != nil {
+		fmt.Printf("New1[Error]: %v.\n", err)
+		return
+	}
 
-func ExampleStructField_SetBytes() {
-	type Server struct {
-		Stream []byte `json:"stream,omitempty"`
+	s2, err := structs.New(backup)
+	if err != nil {
+		fmt.Printf("New2[Error]: %v.\n", err)
+		return
 	}
 
-	server := Server{
-		Stream: []byte("Hello world"),
+	fmt.Println("Compare server1 and backup (equal)")
+	for i, f1 := range s1.Fields() {
+		f2 := s2.Field(i)
+		fmt.Printf("[%d]Equal: %v.\n", i, f1.Equal(f2))
 	}
 
-	s, err := structs.New(&server)
+	s2, err = structs.New(&server2)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New3[Error]: %v.\n", err)
+		return
 	}
 
-	f := s.Field("Stream")
-
-	if f.CanBytes() {
-		b := f.Bytes()
-		fmt.Printf("Bytes: %v.\n", b)
-		fmt.Printf("BytesString: %v.\n", string(b))
-		f.SetBytes([]byte("Bye bye world"))
-		b = f.Bytes()
-		fmt.Printf("SetBytes: %v.\n", b)
-		fmt.Printf("SetBytesString: %v.\n", string(b))
+	fmt.Println("Compare server1 and server2 (different)")
+	for i, f1 := range s1.Fields() {
+		f2 := s2.Field(i)
+		fmt.Printf("[%d]Equal: %v.\n", i, f1.Equal(f2))
 	}
 
 	// Output:
-	// Bytes: [72 101 108 108 111 32 119 111 114 108 100].
-	// BytesString: Hello world.
-	// SetBytes: [66 121 101 32 98 121 101 32 119 111 114 108 100].
-	// SetBytesString: Bye bye world.
+	// Compare server1 and backup (equal)
+	// [0]Equal: true.
+	// [1]Equal: true.
+	// [2]Equal: true.
+	// [3]Equal: true.
+	// [4]Equal: true.
+	// [5]Equal: true.
+	// [6]Equal: true.
+	// [7]Equal: true.
+	// [8]Equal: true.
+	// [9]Equal: true.
+	// [10]Equal: true.
+	// [11]Equal: true.
+	// [12]Equal: true.
+	// [13]Equal: false.
+	// Compare server1 and server2 (different)
+	// [0]Equal: false.
+	// [1]Equal: false.
+	// [2]Equal: false.
+	// [3]Equal: false.
+	// [4]Equal: false.
+	// [5]Equal: false.
+	// [6]Equal: false.
+	// [7]Equal: false.
+	// [8]Equal: false.
+	// [9]Equal: false.
+	// [10]Equal: false.
+	// [11]Equal: false.
+	// [12]Equal: false.
+	// [13]Equal: false.
 }
 
-func ExampleStructField_SetInterface() {
+func ExampleStructField_IsEmpty() {
 	type Server struct {
-		Anything interface{} `json:"anything,omitempty"`
+		Name   string            `json:"name,omitempty"`
+		Tags   []string          `json:"tags,omitempty"`
+		Labels map[string]string `json:"labels,omitempty"`
+		Count  *int32            `json:"count,omitempty"`
 	}
 
 	server := Server{
-		Anything: 654321,
+		Name:   "Roninzo",
+		Tags:   []string{},
+		Labels: nil,
+		Count:  nil,
 	}
 
 	s, err := structs.New(&server)
@@ -3814,380 +5234,371 @@ func ExampleStructField_SetInterface() {
 		fmt.Printf("Error: %v\n", err)
 	}
 
-	f := s.Field("Anything")
-
-	if f.CanInterface() {
-		fmt.Printf("Interface: %v.\n", f.Interface())
-		f.SetInterface(123456)
-		fmt.Printf("SetInterface: %v.\n", f.Interface())
+	for _, f := range s.Fields() {
+		fmt.Printf("%s: IsZero: %v, IsEmpty: %v\n", f.Name(), f.IsZero(), f.IsEmpty())
 	}
+	fmt.Printf("Server: IsEmpty: %v\n", s.IsEmpty())
 
 	// Output:
-	// Interface: 654321.
-	// SetInterface: 123456.
+	// Name: IsZero: false, IsEmpty: false
+	// Tags: IsZero: false, IsEmpty: true
+	// Labels: IsZero: true, IsEmpty: true
+	// Count: IsZero: true, IsEmpty: true
+	// Server: IsEmpty: false
 }
 
-func ExampleStructField_SetStruct() {
+func ExampleStructField_SetJSON() {
 	type Program struct {
-		Name string `json:"name,omitempty"`
+		Name    string `json:"name"`
+		Version int    `json:"version"`
 	}
 
 	type Server struct {
-		Program *Program `json:"program,omitempty"`
+		Name    string            `json:"name"`
+		Program *Program          `json:"program"`
+		Ports   []int             `json:"ports"`
+		Labels  map[string]string `json:"labels"`
 	}
 
-	program := Program{
-		Name: "Apache",
+	server := Server{Name: "Roninzo"}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
+	}
+
+	err = s.Field("Program").SetJSON([]byte(`{"name":"Apache","version":2}`))
+	if err != nil {
+		fmt.Printf("SetJSON[Error]: %v.\n", err)
+	}
+	err = s.Field("Ports").SetJSON([]byte(`[80,443]`))
+	if err != nil {
+		fmt.Printf("SetJSON[Error]: %v.\n", err)
+	}
+	err = s.Field("Labels").SetJSON([]byte(`{"env":"prod"}`))
+	if err != nil {
+		fmt.Printf("SetJSON[Error]: %v.\n", err)
+	}
+	err = s.Field("Name").SetJSON([]byte(`123`))
+	if err != nil {
+		fmt.Printf("SetJSON[Error]: %v.\n", err)
+	}
+
+	fmt.Printf("Name   : %s.\n", server.Name)
+	fmt.Printf("Program: %+v.\n", *server.Program)
+	fmt.Printf("Ports  : %v.\n", server.Ports)
+	fmt.Printf("Labels : %v.\n", server.Labels)
+
+	// Output:
+	// SetJSON[Error]: could not set field Server.Name from json: json: cannot unmarshal number into Go value of type string.
+	// Name   : Roninzo.
+	// Program: {Name:Apache Version:2}.
+	// Ports  : [80 443].
+	// Labels : map[env:prod].
+}
+
+func ExampleStructField_JSON() {
+	type Program struct {
+		Name    string `json:"name"`
+		Version int    `json:"version"`
+	}
+
+	type Server struct {
+		Name    string   `json:"name"`
+		Program *Program `json:"program"`
+		Ports   []int    `json:"ports"`
 	}
 
 	server := Server{
-		Program: &program,
+		Name:    "Roninzo",
+		Program: &Program{Name: "Apache", Version: 2},
+		Ports:   []int{80, 443},
 	}
 
 	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	f := s.Field("Program")
-
-	if f.CanStruct() {
-		fmt.Printf("Struct: %v.\n", server.Program.Name)
-		program2 := Program{
-			Name: "Microsoft IIS",
-		}
-		p, err := structs.New(&program2)
+	for _, name := range []string{"Name", "Program", "Ports"} {
+		data, err := s.Field(name).JSON()
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
+			fmt.Printf("JSON[Error]: %v.\n", err)
+			continue
 		}
-		f.SetStruct(p)
-		fmt.Printf("SetStruct: %v.\n", server.Program.Name)
+		fmt.Printf("%-7s: %s\n", name, data)
 	}
 
 	// Output:
-	// Struct: Apache.
-	// SetStruct: Microsoft IIS.
+	// Name   : "Roninzo"
+	// Program: {"name":"Apache","version":2}
+	// Ports  : [80,443]
 }
 
-func ExampleStructField_Equal() {
-	type Program struct {
-		Name string `json:"name,omitempty"`
+func ExampleStructField_At() {
+	type Server struct {
+		Name  string
+		Ports []int
 	}
 
-	program1 := Program{Name: "Apache"}
-	program2 := Program{Name: "Apache"}
-
-	type Server struct {
-		Name       string        `json:"name,omitempty"`
-		ID         uint          `json:"id,omitempty"`
-		Enabled    bool          `json:"enabled,omitempty"`
-		Err        error         `json:"err,omitempty"`
-		ChangedAt  time.Time     `json:"changed_at,omitempty"`
-		TimeOut    time.Duration `json:"time_out,omitempty"`
-		Count      int           `json:"count,omitempty"`
-		Price      float32       `json:"price,omitempty"`
-		Complex    complex128    `json:"complex,omitempty"`
-		Stream     []byte        `json:"stream,omitempty"`
-		Anything   interface{}   `json:"anything,omitempty"`
-		Program    *Program      `json:"program,omitempty"`
-		Password   string        `json:"-"`
-		unexported bool
+	server := Server{
+		Name:  "Roninzo",
+		Ports: []int{80, 443, 8080},
 	}
 
-	server1 := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Err:        errors.New("rows not found"),
-		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
-		TimeOut:    5 * time.Second,
-		Count:      8,
-		Price:      1922.50,
-		Complex:    complex(22, 50),
-		Stream:     []byte("Hello world"),
-		Anything:   654321,
-		Program:    &program1,
-		Password:   "abcdefg",
-		unexported: true,
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	server2 := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Err:        errors.New("rows not found"),
-		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
-		TimeOut:    5 * time.Second,
-		Count:      8,
-		Price:      1922.50,
-		Complex:    complex(22, 50),
-		Stream:     []byte("Hello world"),
-		Anything:   654321,
-		Program:    &program2,
-		Password:   "abcdefg",
-		unexported: true,
+	f := s.Field("Ports")
+	fmt.Printf("Len: %d.\n", f.Len())
+	for i := 0; i < f.Len(); i++ {
+		e := f.At(i)
+		fmt.Printf("%s: %v.\n", e.FullName(), e.Interface())
 	}
 
-	s1, err := structs.New(&server1)
+	err = f.SetIndex(2, "8443")
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("SetIndex[Error]: %v.\n", err)
+	}
+	err = f.SetIndex(3, 9000)
+	if err != nil {
+		fmt.Printf("SetIndex[Error]: %v.\n", err)
+	}
+	err = f.Append(9000, "9443")
+	if err != nil {
+		fmt.Printf("Append[Error]: %v.\n", err)
 	}
+	fmt.Printf("Ports: %v.\n", server.Ports)
 
-	s2, err := structs.New(&server2)
+	err = f.Truncate(1)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("Truncate[Error]: %v.\n", err)
 	}
+	fmt.Printf("Ports: %v.\n", server.Ports)
 
-	for i, f1 := range s1.Fields() {
-		f2 := s2.Field(i)
-		fmt.Printf("Equal: %v.\n", f1.Equal(f2))
+	err = s.Field("Name").Append("Apache")
+	if err != nil {
+		fmt.Printf("Append[Error]: %v.\n", err)
 	}
 
 	// Output:
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: true.
-	// Equal: false.
+	// Len: 3.
+	// Server.Ports[0]: 80.
+	// Server.Ports[1]: 443.
+	// Server.Ports[2]: 8080.
+	// SetIndex[Error]: could not get element 3 of field Server.Ports: struct field index out of range.
+	// Ports: [80 443 8443 9000 9443].
+	// Ports: [80].
+	// Append[Error]: could not append to field Server.Name: struct field is not a slice.
 }
 
-func ExampleStructField_Equal_different() {
-	type Program struct {
-		Name string `json:"name,omitempty"`
-	}
-
-	program1 := Program{Name: "Apache"}
-	program2 := Program{Name: "Microsoft IIS"}
-
+func ExampleStructField_SetMapIndex() {
 	type Server struct {
-		Name       string        `json:"name,omitempty"`
-		ID         uint          `json:"id,omitempty"`
-		Enabled    bool          `json:"enabled,omitempty"`
-		Err        error         `json:"err,omitempty"`
-		ChangedAt  time.Time     `json:"changed_at,omitempty"`
-		TimeOut    time.Duration `json:"time_out,omitempty"`
-		Count      int           `json:"count,omitempty"`
-		Price      float32       `json:"price,omitempty"`
-		Complex    complex128    `json:"complex,omitempty"`
-		Stream     []byte        `json:"stream,omitempty"`
-		Anything   interface{}   `json:"anything,omitempty"`
-		Program    *Program      `json:"program,omitempty"`
-		Password   string        `json:"-"`
-		unexported bool
+		Name   string
+		Limits map[string]int
 	}
 
-	server1 := Server{
-		Name:       "Roninzo",
-		ID:         123456,
-		Enabled:    true,
-		Err:        errors.New("rows not found"),
-		ChangedAt:  time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC),
-		TimeOut:    5 * time.Second,
-		Count:      8,
-		Price:      1922.50,
-		Complex:    complex(22, 50),
-		Stream:     []byte("Hello world"),
-		Anything:   654321,
-		Program:    &program1,
-		Password:   "abcdefg",
-		unexported: true,
-	}
+	server := Server{Name: "Roninzo"}
 
-	server2 := Server{
-		Name:       "ozninoR",
-		ID:         654321,
-		Enabled:    false,
-		Err:        errors.New("not compliant"),
-		ChangedAt:  time.Date(2021, time.August, 31, 14, 11, 11, 0, time.UTC),
-		TimeOut:    30 * time.Second,
-		Count:      3,
-		Price:      7622.50,
-		Complex:    complex(-67, -42),
-		Stream:     []byte("Bye bye world"),
-		Anything:   123456,
-		Program:    &program2,
-		Password:   "gfedcba",
-		unexported: false,
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	s1, err := structs.New(&server1)
+	f := s.Field("Limits")
+	err = f.SetMapIndex("connections", "100")
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("SetMapIndex[Error]: %v.\n", err)
 	}
-
-	s2, err := structs.New(&server2)
+	err = f.SetMapIndex("requests", 500)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Printf("SetMapIndex[Error]: %v.\n", err)
+	}
+	err = f.SetMapIndex("timeout", "30s")
+	if err != nil {
+		fmt.Printf("SetMapIndex[Error]: %v.\n", err)
+	}
+	for _, k := range f.Keys() {
+		x, _ := f.MapIndex(k)
+		fmt.Printf("%s: %v.\n", k, x)
 	}
 
-	for i, f1 := range s1.Fields() {
-		f2 := s2.Field(i)
-		fmt.Printf("Equal: %v.\n", f1.Equal(f2))
+	err = f.DeleteKey("requests")
+	if err != nil {
+		fmt.Printf("DeleteKey[Error]: %v.\n", err)
 	}
+	_, ok := f.MapIndex("requests")
+	fmt.Printf("requests: %v.\n", ok)
+	fmt.Printf("Limits: %v.\n", server.Limits)
 
 	// Output:
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
-	// Equal: false.
+	// SetMapIndex[Error]: could not set key timeout of field Server.Limits: invalid value for field Server.Limits[timeout]: strconv.ParseInt: parsing "30s": invalid syntax.
+	// connections: 100.
+	// requests: 500.
+	// requests: false.
+	// Limits: map[connections:100].
 }
 
-func ExampleStructField_Equal_pointerFields() {
-	type Program struct {
-		Name *string `json:"name,omitempty"`
+func ExampleStructField_Each() {
+	type Server struct {
+		Name   string
+		Ports  []int
+		Limits map[string]int
+	}
+
+	server := Server{
+		Name:   "Roninzo",
+		Ports:  []int{80, 443, 8080},
+		Limits: map[string]int{"requests": 500, "connections": 100},
+	}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
+	}
+
+	show := func(key, value reflect.Value) error {
+		fmt.Printf("%v: %v.\n", key, value)
+		return nil
+	}
+	err = s.Field("Ports").Each(show)
+	if err != nil {
+		fmt.Printf("Each[Error]: %v.\n", err)
 	}
+	err = s.Field("Limits").Each(show)
+	if err != nil {
+		fmt.Printf("Each[Error]: %v.\n", err)
+	}
+	err = s.Field("Ports").Each(func(key, value reflect.Value) error {
+		if value.Int() > 443 {
+			return errors.Errorf("port %d is not privileged", value.Int())
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Each[Error]: %v.\n", err)
+	}
+	err = s.Field("Name").Each(show)
+	if err != nil {
+		fmt.Printf("Each[Error]: %v.\n", err)
+	}
+
+	// Output:
+	// 0: 80.
+	// 1: 443.
+	// 2: 8080.
+	// connections: 100.
+	// requests: 500.
+	// Each[Error]: port 8080 is not privileged.
+	// Each[Error]: could not iterate over field Server.Name of kind string.
+}
 
+func ExampleStructField_As() {
 	type Server struct {
-		Name       *string        `json:"name,omitempty"`
-		ID         *uint          `json:"id,omitempty"`
-		Enabled    *bool          `json:"enabled,omitempty"`
-		Err        *error         `json:"err,omitempty"`
-		ChangedAt  *time.Time     `json:"changed_at,omitempty"`
-		TimeOut    *time.Duration `json:"time_out,omitempty"`
-		Count      *int           `json:"count,omitempty"`
-		Price      *float32       `json:"price,omitempty"`
-		Stream     *[]byte        `json:"stream,omitempty"`
-		Complex    *complex128    `json:"complex,omitempty"`
-		Anything   interface{}    `json:"anything,omitempty"`
-		Program    *Program       `json:"program,omitempty"`
-		Password   *string        `json:"-"`
-		unexported *bool
+		Name    string
+		Port    string
+		Timeout *time.Duration
+		Count   int
 	}
 
-	program1 := Program{
-		Name: pointers.String("Apache"),
+	server := Server{
+		Name:  "Roninzo",
+		Port:  "8080",
+		Count: 5,
 	}
 
-	server1 := Server{
-		Name:       pointers.String("Roninzo"),
-		ID:         pointers.Uint(123456),
-		Enabled:    pointers.Bool(true),
-		Err:        pointers.Error(errors.New("rows not found")),
-		ChangedAt:  pointers.Time(time.Date(2021, time.August, 3, 16, 44, 46, 0, time.UTC)),
-		TimeOut:    pointers.Duration(5 * time.Second),
-		Count:      pointers.Int(8),
-		Price:      pointers.Float32(1922.50),
-		Stream:     pointers.Bytes([]byte("Hello world")),
-		Complex:    pointers.Complex128(complex(22, 50)),
-		Anything:   "test",
-		Program:    &program1,
-		Password:   pointers.String("abcdefg"),
-		unexported: pointers.Bool(true),
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
 	}
 
-	program2 := Program{
-		Name: pointers.String("Microsoft IIS"),
+	port, err := s.Field("Port").IntE()
+	if err != nil {
+		fmt.Printf("IntE[Error]: %v.\n", err)
 	}
+	fmt.Printf("Port: %d.\n", port)
 
-	server2 := Server{
-		Name:       pointers.String("ozninoR"),
-		ID:         pointers.Uint(654321),
-		Enabled:    pointers.Bool(false),
-		Err:        pointers.Error(errors.New("not compliant")),
-		ChangedAt:  pointers.Time(time.Date(2021, time.August, 31, 14, 11, 11, 0, time.UTC)),
-		TimeOut:    pointers.Duration(30 * time.Second),
-		Count:      pointers.Int(3),
-		Price:      pointers.Float32(7022.50),
-		Stream:     pointers.Bytes([]byte("Bye bye world")),
-		Complex:    pointers.Complex128(complex(99, 12)),
-		Anything:   654321,
-		Program:    &program2,
-		Password:   pointers.String("gfedcba"),
-		unexported: pointers.Bool(false),
+	_, err = s.Field("Name").IntE()
+	if err != nil {
+		fmt.Printf("IntE[Error]: %v.\n", err)
 	}
 
-	// backup <- server1 via cloning
-	clone, err := structs.Clone(&server1)
+	timeout, err := s.Field("Timeout").DurationE()
 	if err != nil {
-		fmt.Printf("Clone[Error]: %v.\n", err)
-		return
-	}
-	backup, ok := clone.(*Server)
-	if !ok {
-		fmt.Printf("TypeAssertion[Error]: %v.\n", err)
-		return
+		fmt.Printf("DurationE[Error]: %v.\n", err)
 	}
+	fmt.Printf("Timeout: %v.\n", timeout)
 
-	s1, err := structs.New(&server1)
+	var count string
+	err = s.Field("Count").As(&count)
 	if err != nil {
-		fmt.Printf("New1[Error]: %v.\n", err)
-		return
+		fmt.Printf("As[Error]: %v.\n", err)
 	}
+	fmt.Printf("Count: %q.\n", count)
 
-	s2, err := structs.New(backup)
+	// Output:
+	// Port: 8080.
+	// IntE[Error]: invalid value for field Server.Name: strconv.ParseInt: parsing "Roninzo": invalid syntax.
+	// Timeout: 0s.
+	// Count: "5".
+}
+
+func ExampleStructField_Int_pointer() {
+	type Server struct {
+		Name  *string
+		Port  *int
+		Debug *bool
+	}
+
+	server := Server{
+		Name: pointers.String("Roninzo"),
+		Port: pointers.Int(8080),
+	}
+
+	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("New2[Error]: %v.\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
 		return
 	}
 
-	fmt.Println("Compare server1 and backup (equal)")
-	for i, f1 := range s1.Fields() {
-		f2 := s2.Field(i)
-		fmt.Printf("[%d]Equal: %v.\n", i, f1.Equal(f2))
+	fmt.Printf("Name : %s (nil: %v).\n", s.Field("Name").String(), s.Field("Name").IsNil())
+	fmt.Printf("Port : %d (nil: %v).\n", s.Field("Port").Int(), s.Field("Port").IsNil())
+	fmt.Printf("Debug: %v (nil: %v).\n", s.Field("Debug").Bool(), s.Field("Debug").IsNil())
+
+	// Output:
+	// Name : Roninzo (nil: false).
+	// Port : 8080 (nil: false).
+	// Debug: false (nil: true).
+}
+
+func ExampleStructField_Addr() {
+	type Server struct {
+		Name string
+		ID   uint
 	}
 
-	s2, err = structs.New(&server2)
+	server := Server{Name: "Roninzo"}
+
+	s, err := structs.New(&server)
 	if err != nil {
-		fmt.Printf("New3[Error]: %v.\n", err)
+		fmt.Printf("New[Error]: %v.\n", err)
 		return
 	}
 
-	fmt.Println("Compare server1 and server2 (different)")
-	for i, f1 := range s1.Fields() {
-		f2 := s2.Field(i)
-		fmt.Printf("[%d]Equal: %v.\n", i, f1.Equal(f2))
-	}
+	p := s.Field("ID").Addr().(*uint)
+	*p = 123456
+	fmt.Printf("ID: %d.\n", server.ID)
 
 	// Output:
-	// Compare server1 and backup (equal)
-	// [0]Equal: true.
-	// [1]Equal: true.
-	// [2]Equal: true.
-	// [3]Equal: true.
-	// [4]Equal: true.
-	// [5]Equal: true.
-	// [6]Equal: true.
-	// [7]Equal: true.
-	// [8]Equal: true.
-	// [9]Equal: true.
-	// [10]Equal: true.
-	// [11]Equal: true.
-	// [12]Equal: true.
-	// [13]Equal: false.
-	// Compare server1 and server2 (different)
-	// [0]Equal: false.
-	// [1]Equal: false.
-	// [2]Equal: false.
-	// [3]Equal: false.
-	// [4]Equal: false.
-	// [5]Equal: false.
-	// [6]Equal: false.
-	// [7]Equal: false.
-	// [8]Equal: false.
-	// [9]Equal: false.
-	// [10]Equal: false.
-	// [11]Equal: false.
-	// [12]Equal: false.
-	// [13]Equal: false.
+	// ID: 123456.
 }
 
 /*   S t r u c t F i e l d s   */
@@ -4229,6 +5640,39 @@ func ExampleStructFields_Names() {
 	// Fields.Names: [Name ID Enabled Count Password unexported Module]
 }
 
+func ExampleStructFields_Pointers() {
+	type Server struct {
+		Name       string
+		ID         uint
+		Enabled    bool
+		unexported int
+	}
+
+	server := Server{}
+
+	s, err := structs.New(&server)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+		return
+	}
+
+	// e.g. rows.Scan(s.Fields().Pointers()...)
+	scan := func(dest ...interface{}) {
+		*dest[0].(*string) = "Roninzo"
+		*dest[1].(*uint) = 123456
+		*dest[2].(*bool) = true
+	}
+
+	ptrs := s.Fields().Pointers()
+	fmt.Printf("Pointers: %d.\n", len(ptrs))
+	scan(ptrs...)
+	fmt.Printf("Server: %+v.\n", server)
+
+	// Output:
+	// Pointers: 3.
+	// Server: {Name:Roninzo ID:123456 Enabled:true unexported:0}.
+}
+
 /*   S t r u c t R o w s   */
 
 func ExampleStructRows_Index() {
@@ -4463,3 +5907,178 @@ func ExampleStructRows_Err() {
 	// struct row index: -1.
 	// Err[Error]: invalid field name InvalidName.
 }
+
+func ExampleStructRows_Errs() {
+	type Server struct {
+		Name  string
+		Count int32
+	}
+	servers := []Server{
+		{Name: "Roninzo", Count: 5},
+		{Name: "Apache", Count: 6},
+		{Name: "Nginx", Count: 7},
+	}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		name := rows.Field("Name").String()
+		if name != "Apache" {
+			_ = rows.Field("Missing")
+		}
+	}
+	fmt.Printf("Err: %v.\n", rows.Err())
+	for _, e := range rows.Errs() {
+		fmt.Printf("Errs[%d]: %v.\n", e.Row, e.Err)
+	}
+
+	// Output:
+	// Err: row 0: invalid field name Missing.
+	// Errs[0]: invalid field name Missing.
+	// Errs[2]: invalid field name Missing.
+}
+
+func ExampleStructRows_Columns_tagged() {
+	type Server struct {
+		Name     string `json:"name"`
+		Count    int32  `json:"count,omitempty"`
+		Password string `json:"-"`
+		Enabled  bool
+		internal int
+	}
+	servers := []Server{
+		{Name: "Roninzo", Count: 5},
+	}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	cols, _ := rows.Columns()
+	fmt.Printf("Columns: %s.\n", cols)
+	cols, _ = rows.Columns(structs.Tagged("json"), structs.ExportedOnly())
+	fmt.Printf("Columns: %s.\n", cols)
+
+	// Output:
+	// Columns: [Name Count Password Enabled internal].
+	// Columns: [name count Enabled].
+}
+
+func ExampleStructRows_GroupBy() {
+	type Server struct {
+		Name    string
+		Enabled bool
+	}
+	servers := []Server{
+		{Name: "Apache", Enabled: true},
+		{Name: "Nginx", Enabled: false},
+		{Name: "Caddy", Enabled: true},
+	}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	groups, err := rows.GroupBy("Enabled")
+	if err != nil {
+		fmt.Printf("GroupBy[Error]: %v.\n", err)
+	}
+	for _, i := range groups[true] {
+		fmt.Printf("Enabled: %s.\n", servers[i].Name)
+	}
+
+	// Output:
+	// Enabled: Apache.
+	// Enabled: Caddy.
+}
+
+func ExampleStructRows_Distinct() {
+	type Server struct {
+		Name string   `json:"name"`
+		Zone string   `json:"zone"`
+		Tags []string `json:"tags"`
+	}
+	servers := []Server{
+		{Name: "Apache", Zone: "eu", Tags: []string{"web"}},
+		{Name: "Nginx", Zone: "us", Tags: []string{"web", "proxy"}},
+		{Name: "Caddy", Zone: "eu", Tags: []string{"web"}},
+	}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	zones, err := rows.Distinct("Zone")
+	if err != nil {
+		fmt.Printf("Distinct[Error]: %v.\n", err)
+	}
+	fmt.Printf("Zones: %v.\n", zones)
+	tags, err := rows.Distinct("tags", structs.Tagged("json"))
+	if err != nil {
+		fmt.Printf("Distinct[Error]: %v.\n", err)
+	}
+	fmt.Printf("Tags: %v.\n", tags)
+	_, err = rows.Distinct("Tags", structs.Tagged("json"))
+	fmt.Printf("Distinct[Error]: %v.\n", err)
+
+	// Output:
+	// Zones: [eu us].
+	// Tags: [[web] [web proxy]].
+	// Distinct[Error]: could not get distinct values: column Tags: struct field not found.
+}
+
+func ExampleStructRows_ToMaps() {
+	type Program struct {
+		Name string `json:"name"`
+	}
+	type Server struct {
+		Name     string  `json:"name"`
+		Count    int32   `json:"count,omitempty"`
+		Password string  `json:"-"`
+		Program  Program `json:"program"`
+	}
+	servers := []*Server{
+		{Name: "Apache", Count: 5, Password: "s3cr3t", Program: Program{Name: "httpd"}},
+		nil,
+		{Name: "Nginx", Program: Program{Name: "nginx"}},
+	}
+	s, err := structs.New(&servers)
+	if err != nil {
+		fmt.Printf("New[Error]: %v.\n", err)
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		fmt.Printf("Rows[Error]: %v.\n", err)
+	}
+	defer rows.Close()
+	maps, err := rows.ToMaps("json")
+	if err != nil {
+		fmt.Printf("ToMaps[Error]: %v.\n", err)
+	}
+	for i, m := range maps {
+		fmt.Printf("Row %d: %v.\n", i, m)
+	}
+
+	// Output:
+	// Row 0: map[count:5 name:Apache program:map[name:httpd]].
+	// Row 1: map[].
+	// Row 2: map[name:Nginx program:map[name:nginx]].
+}
@@ -6,8 +6,14 @@ package structs
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +30,8 @@ type StructField struct {
 	indexes []int               // absolute indexes of field inside struct.
 	value   reflect.Value       // struct field value.
 	field   reflect.StructField // struct field definition.
+	owner   *StructField        // slice or map field, if element handle.
+	key     string              // element index or map key, if element handle.
 	Parent  *StructValue        // field's own struct reference.
 }
 
@@ -60,6 +68,29 @@ func (s *StructValue) Field(dest interface{}) *StructField {
 	return nil
 }
 
+// FieldE returns the field of the struct that matches argument dest, like Field,
+// along with the error finding it, instead of saving the error in StructValue,
+// so that concurrent lookups do not share error state.
+func (s *StructValue) FieldE(dest interface{}) (*StructField, error) {
+	switch arg := dest.(type) {
+	case nil:
+		return nil, errors.New("invalid nil argument")
+	case int:
+		fields := s.Fields()
+		if arg <= OutOfRange || arg >= len(fields) {
+			return nil, errors.Wrapf(ErrOutOfRange, "invalid field index %d", arg)
+		}
+		return fields[arg], nil
+	case string:
+		if f, ok := s.fieldByName(arg); ok {
+			return f, nil
+		}
+		return nil, errors.Wrapf(ErrNoField, "invalid field name %s", arg)
+	}
+	t := reflect.TypeOf(dest)
+	return nil, errors.Errorf("invalid argument type; want: %q or %q, got: %q", reflect.String, reflect.Int, t.Kind())
+}
+
 /*   I m p l e m e n t a t i o n   */
 
 // IsValid returns true if StructField has been loaded successfully.
@@ -85,9 +116,10 @@ func (f *StructField) Name() string {
 }
 
 // NameJson returns returns the string name of StructField
-// defined in its related json struct tag, else it generates it.
+// defined in its related json struct tag, else in its bson struct tag, for
+// structs shared with the Mongo driver, else it generates it.
 func (f *StructField) NameJson() string {
-	tag, ok := f.Tag("json")
+	tag, ok := f.Tag(f.nameTag())
 	if ok && tag != "-" {
 		tag = strings.TrimSuffix(tag, ",omitempty")
 		return tag
@@ -95,6 +127,41 @@ func (f *StructField) NameJson() string {
 	return utils.CamelCaseToUnderscore(f.field.Name)
 }
 
+// TagName returns the name part of the struct tag key, i.e. what comes before
+// the first comma, e.g. "name" for `json:"name,omitempty"`. When the struct tag
+// key is missing or its name part is empty, TagName falls back to the name of
+// the field. When the name part is "-", the field is meant to be ignored and
+// TagName returns zero-value string.
+func (f *StructField) TagName(key string) string {
+	tag, ok := f.Tag(key)
+	if ok {
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name()
+}
+
+// TagHasOption returns true if the struct tag key has the option opt, i.e. one
+// of the comma separated values following its name part, e.g. "omitempty" for
+// `json:"name,omitempty"`.
+func (f *StructField) TagHasOption(key, opt string) bool {
+	tag, ok := f.Tag(key)
+	if !ok {
+		return false
+	}
+	for _, o := range strings.Split(tag, ",")[1:] {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
 // Default returns returns the string default value of StructField
 // defined in its related default struct tag, else returns empty string.
 func (f *StructField) Default() string {
@@ -118,11 +185,11 @@ func (f *StructField) Default() string {
 // all the way to the top level struct (in a dot separated string).
 func (f *StructField) FullName() (n string) {
 	s := f.Parent
-	n = s.Name()
+	n = s.fullNameSegment()
 	for {
 		if s.IsNested() {
 			s = s.Parent
-			n = fmt.Sprintf("%s.%s", s.Name(), n)
+			n = fmt.Sprintf("%s.%s", s.fullNameSegment(), n)
 		} else {
 			break
 		}
@@ -152,6 +219,14 @@ func (f *StructField) Tag(key string) (string, bool) {
 	return f.field.Tag.Lookup(key)
 }
 
+// Tags returns all the key/value pairs of the tag string, e.g. map[db:name
+// json:name,omitempty] for tag `json:"name,omitempty" db:"name"`, so that
+// several tag systems can be inspected at once. Parsing stops at the first
+// malformed pair, like Tag does.
+func (f *StructField) Tags() map[string]string {
+	return parseTags(f.field.Tag)
+}
+
 // IsAnonymous returns true if the given field is an anonymous field, meaning a field
 // having no name. This obviously related to the use of the Name method.
 func (f *StructField) IsAnonymous() bool {
@@ -177,25 +252,17 @@ func (f *StructField) IsExported() bool {
 
 // TO REVISIT
 
-// IsHidden returns true if the given field is exported and its json tag is
-// not equal to "-". Those fields are neglected for getter and setter methods.
+// IsHidden returns true if the given field is exported and its json tag, else
+// its bson tag, is not equal to "-". Those fields are neglected for getter and
+// setter methods.
 func (f *StructField) IsHidden() bool {
-	if val, ok := f.Tag("json"); ok {
-		if val == "-" {
-			return true
-		}
-		if strings.Contains(val, "omitempty") {
-			if f.IsZero() {
-				return true
-			}
-		}
-	}
-	return false
+	return f.isHiddenBy(f.nameTag())
 }
 
-// Interface returns true if underlying value of the field is modifiable.
+// CanSet returns true if underlying value of the field is modifiable, i.e. it
+// is settable and its struct is not frozen.
 func (f *StructField) CanSet() bool {
-	return f.value.CanSet()
+	return (f.value.CanSet() || f.canAllocate()) && f.isWritable() && !f.Parent.IsFrozen()
 }
 
 // Zero returns field's type specific zero value. For instance, the zero-value
@@ -208,19 +275,65 @@ func (f *StructField) Zero() reflect.Value {
 // IsZero returns true if the given field is a zero-value, i.e. not initialized.
 // Unexported struct fields will be neglected.
 func (f *StructField) IsZero() bool {
-	if f.IsExported() {
+	if _, valid, ok := nullFields(f.Indirect()); ok && !valid.Bool() {
+		return true
+	}
+	if f.isReadable() {
 		return reflect.DeepEqual(f.Interface(), f.Zero().Interface()) // v := f.value; z := utils.Zero(v); return v == z
 	}
 	return false
 }
 
+// IsEmpty returns true if the given field is empty in the sense of the json
+// omitempty option: false, 0, a nil pointer, a nil interface value, and any empty
+// array, slice, map, or string. Unlike IsZero, non-nil but empty slices and maps
+// are also empty. Like for json, structs, including time.Time, are never empty.
+// Unexported struct fields will be neglected.
+func (f *StructField) IsEmpty() bool {
+	if !f.isReadable() {
+		return false
+	}
+	v := f.value
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() == 0
+	case reflect.Interface, reflect.Ptr, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
+// IsNullable returns true if the field is, or points to, a nullable database
+// type, such as sql.NullString or sql.NullTime, i.e. a struct made of a value
+// field and a Valid field implementing sql.Scanner. Getters and setters deal
+// with the value they hold: setting a value sets Valid to true, setting nil sets
+// Valid to false and getters return the zero-value when Valid is false, in
+// which case IsNil and IsZero return true.
+func (f *StructField) IsNullable() bool {
+	_, ok := nullType(f.value.Type())
+	return ok
+}
+
 // IsNil reports whether its argument f is nil. The argument must be a chan, func,
 // interface, map, pointer, or slice value; if it is not, IsNil returns nil.
 // Unexported struct fields will be neglected.
 func (f *StructField) IsNil() bool {
 	v := f.value
-	if utils.CanNil(v) {
-		return v.IsNil()
+	if utils.CanNil(v) && v.IsNil() {
+		return true
+	}
+	if _, valid, ok := nullFields(f.Indirect()); ok {
+		return !valid.Bool()
 	}
 	return false
 }
@@ -232,13 +345,14 @@ func (f *StructField) Equal(x *StructField) bool {
 	if x == nil {
 		return false
 	}
-	return f.equal(x.value) != OutOfRange
+	return f.equal(x.value, getFloatEpsilon()) != OutOfRange
 }
 
 // TO REVISIT
 
 // equal compares field value with reflect value argument and returns field index
-// if they are equal, else returns OutOfRange, i.e. -1.
+// if they are equal, else returns OutOfRange, i.e. -1. Floats are equal within
+// tolerance eps, see floatsEqual.
 //
 // NOTE: Equal might benefit from using reflect.Type.Comparable().
 // if !v.Type().Comparable() {
@@ -247,11 +361,15 @@ func (f *StructField) Equal(x *StructField) bool {
 //
 // case utils.CanPtr(v) && utils.CanPtr(x): v.SetPointer(x.Pointer()); return nil
 // reflect.Invalid, reflect.Slice, reflect.Array, reflect.Map, reflect.Func, reflect.Chan, reflect.Ptr, reflect.Uintptr, reflect.UnsafePointer:
-func (f *StructField) equal(x reflect.Value) int {
+func (f *StructField) equal(x reflect.Value, eps float64) int {
 	v := f.value
 	switch {
-	case !f.IsExported():
+	case !f.isReadable():
 		return OutOfRange
+	case v.Type() == x.Type() && comparerOf(v.Type()) != nil:
+		if compareWith(comparerOf(v.Type()), v, x) {
+			return f.Index()
+		}
 	case utils.CanStruct(v) && utils.CanStruct(x):
 		if reflect.DeepEqual(v.Interface(), x.Interface()) {
 			return f.Index()
@@ -261,7 +379,7 @@ func (f *StructField) equal(x reflect.Value) int {
 			return f.Index()
 		}
 	case utils.CanTime(v) && utils.CanTime(x):
-		if utils.Time(v) == utils.Time(x) {
+		if timesEqual(utils.Time(v), utils.Time(x)) {
 			return f.Index()
 		}
 	case utils.CanError(v) && utils.CanError(x):
@@ -302,7 +420,7 @@ func (f *StructField) equal(x reflect.Value) int {
 		if v.OverflowFloat(float64X) {
 			return OutOfRange
 		}
-		if float64V == float64X {
+		if floatsEqual(float64V, float64X, eps) {
 			return f.Index()
 		}
 	case utils.CanComplex(v) && utils.CanComplex(x):
@@ -318,6 +436,10 @@ func (f *StructField) equal(x reflect.Value) int {
 		if bytes.Equal(v.Bytes(), x.Bytes()) {
 			return f.Index()
 		}
+	case v.Type() == x.Type() && isElemKind(v.Kind()):
+		if valuesEqual(v, x, eps) {
+			return f.Index()
+		}
 	case f.AssignableTo(x), v.CanInterface():
 		if reflect.DeepEqual(v.Interface(), x.Interface()) {
 			return f.Index()
@@ -337,9 +459,21 @@ func (f *StructField) equal(x reflect.Value) int {
 // Unexported struct fields will be neglected.
 func (f *StructField) Get() interface{} {
 	v := f.Indirect()
+	if value, valid, ok := nullFields(v); ok {
+		if !valid.Bool() {
+			return nil
+		}
+		v = value
+	}
 	switch {
-	case !f.IsExported():
+	case !f.isReadable():
 		return nil
+	case v.IsValid() && v.Type() == bigIntType:
+		return f.BigInt()
+	case v.IsValid() && v.Type() == bigFloatType:
+		return f.BigFloat()
+	case canMarshalText(v):
+		return marshalText(v)
 	case utils.CanDuration(v):
 		return utils.Duration(v)
 	case utils.CanTime(v):
@@ -365,23 +499,92 @@ func (f *StructField) Get() interface{} {
 	}
 }
 
-func (f *StructField) Time() time.Time         { v := f.value; return utils.Time(v) }
-func (f *StructField) Duration() time.Duration { v := f.value; return utils.Duration(v) }
-func (f *StructField) Error() error            { v := f.value; return utils.Error(v) }
-func (f *StructField) String() string          { v := f.value; return v.String() }
-func (f *StructField) Bool() bool              { v := f.value; return v.Bool() }
-func (f *StructField) Int() int64              { v := f.value; return v.Int() }
-func (f *StructField) Uint() uint64            { v := f.value; return v.Uint() }
-func (f *StructField) Float() float64          { v := f.value; return v.Float() }
-func (f *StructField) Complex() complex128     { v := f.value; return v.Complex() }
-func (f *StructField) Bytes() []byte           { v := f.value; return v.Bytes() }
+// Typed getters are pointer-transparent: pointer fields, such as *int or *string,
+// are dereferenced and nil pointers return the zero-value of the type they point
+// to. Use IsNil to tell a nil pointer apart from a pointer to a zero-value.
+func (f *StructField) Time() time.Time         { v := f.elemValue(); return utils.Time(v) }
+func (f *StructField) Duration() time.Duration { v := f.elemValue(); return utils.Duration(v) }
+func (f *StructField) Error() error            { v := f.elemValue(); return utils.Error(v) }
+func (f *StructField) String() string          { v := f.elemValue(); return v.String() }
+func (f *StructField) Bool() bool              { v := f.elemValue(); return v.Bool() }
+func (f *StructField) Int() int64              { v := f.elemValue(); return v.Int() }
+func (f *StructField) Uint() uint64            { v := f.elemValue(); return v.Uint() }
+func (f *StructField) Float() float64          { v := f.elemValue(); return v.Float() }
+func (f *StructField) Complex() complex128     { v := f.elemValue(); return v.Complex() }
+func (f *StructField) Bytes() []byte           { v := f.elemValue(); return v.Bytes() }
 func (f *StructField) Interface() interface{}  { v := f.value; return v.Interface() }
 
+// StringOf returns the value of the field as a string, whatever its kind, e.g.
+// for logging fields uniformly: string fields return String, other fields are
+// formatted with their String method, possibly through a pointer receiver, or
+// else with fmt.Sprint. Like typed getters, StringOf is pointer-transparent and
+// nil pointers return "".
+// Unexported struct fields will return "".
+func (f *StructField) StringOf() string {
+	if !f.isReadable() {
+		f.Parent.setErrorsf(ErrNotExported, "could not get value of field %s", f.FullName())
+		return ""
+	}
+	if utils.CanPtr(f.value) && f.value.IsNil() {
+		return ""
+	}
+	v := f.elemValue()
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	if s, ok := f.stringer(); ok {
+		return s.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// As stores the value of the field into target, which must be a non-nil pointer,
+// converting it following the same rules as Set, e.g. an int field can be read
+// into a string target and vice-versa. Unlike getters, As never panics: it
+// returns an error when the field value cannot be represented by target. A nil
+// pointer field stores the zero-value into target.
+// Unexported struct fields will return an error.
+func (f *StructField) As(target interface{}) error {
+	ctx := fmt.Sprintf("could not get value of field %s", f.FullName())
+	if !f.isReadable() {
+		return errors.Wrap(ErrNotExported, ctx)
+	}
+	t := reflect.ValueOf(target)
+	if t.Kind() != reflect.Ptr || t.IsNil() {
+		return errors.Errorf("%s: invalid target %T; want a non-nil pointer", ctx, target)
+	}
+	sf := f.field
+	sf.Type = t.Elem().Type()
+	h := &StructField{index: f.index, indexes: f.indexes, value: t.Elem(), field: sf, Parent: f.Parent}
+	v := f.value
+	if utils.CanPtr(v) {
+		if v.IsNil() {
+			return h.setZero()
+		}
+		v = v.Elem()
+	}
+	return h.set(v.Interface())
+}
+
+// Getter methods with error return the same as their counterparts without the E
+// suffix, e.g. IntE returns the same as Int, except that they never panic. See
+// the As method for the conversion rules.
+func (f *StructField) TimeE() (x time.Time, err error)         { err = f.As(&x); return }
+func (f *StructField) DurationE() (x time.Duration, err error) { err = f.As(&x); return }
+func (f *StructField) StringE() (x string, err error)          { err = f.As(&x); return }
+func (f *StructField) BoolE() (x bool, err error)              { err = f.As(&x); return }
+func (f *StructField) IntE() (x int64, err error)              { err = f.As(&x); return }
+func (f *StructField) UintE() (x uint64, err error)            { err = f.As(&x); return }
+func (f *StructField) FloatE() (x float64, err error)          { err = f.As(&x); return }
+func (f *StructField) ComplexE() (x complex128, err error)     { err = f.As(&x); return }
+func (f *StructField) BytesE() (x []byte, err error)           { err = f.As(&x); return }
+
 // Struct returns nested struct from field or nil if f is not a nested struct.
 func (f *StructField) Struct() *StructValue {
-	s := IndirectStruct(f.value)
-	s.Parent = f.Parent
-	f.Parent.Error = s.Err()
+	s := f.nested()
+	if err := s.Err(); err != nil {
+		f.Parent.setErr(err)
+	}
 	return s
 }
 
@@ -402,7 +605,7 @@ func (f *StructField) CanComplex() bool   { return utils.CanComplex(f.value) }
 func (f *StructField) CanBytes() bool     { return utils.CanBytes(f.value) }
 func (f *StructField) CanSlice() bool     { return utils.CanSlice(f.value) }
 func (f *StructField) CanMap() bool       { return utils.CanMap(f.value) }
-func (f *StructField) CanStruct() bool    { return utils.CanStruct(f.value) }
+func (f *StructField) CanStruct() bool    { return utils.CanStruct(f.value) && !f.isScalar() }
 func (f *StructField) CanInterface() bool { return utils.CanInterface(f.value) }
 
 // S e t t e r s
@@ -412,116 +615,165 @@ func (f *StructField) CanInterface() bool { return utils.CanInterface(f.value) }
 // SetZero sets the field to its zero value.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetZero() error {
-	v, ctx := f.value, fmt.Sprintf("could not set field %s to zero-value", f.FullName())
-	if !v.CanSet() {
-		return errors.Wrap(ErrNotSettable, ctx)
-	}
-	v.Set(utils.Zero(v))
-	return nil
+	return f.mutate(f.setZero)
 }
 
 // SetNil sets the field to its zero value.
 // Unsettable/Un-nillable struct fields will return an error.
 func (f *StructField) SetNil() error {
-	v, ctx := f.value, fmt.Sprintf("could not set field %s to nil", f.FullName())
-	if !v.CanSet() {
-		return errors.Wrap(ErrNotSettable, ctx)
-	}
-	if !utils.CanNil(v) {
-		return errors.Wrap(ErrNotNillable, ctx)
-	}
-	v.Set(utils.Zero(v))
-	return nil
+	return f.mutate(f.setNil)
 }
 
-// SetTime sets the field to the time.Time value x.
+// SetTime sets the field to the time.Time value x, converted to the location
+// set by SetTimeLocation, if any.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetTime(x time.Time) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).Set(reflect.ValueOf(x))
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).Set(reflect.ValueOf(normalizeTime(x)))
+		}
+		return nil
+	})
+}
+
+// SetTimeIn sets the field to the time.Time value x in location loc, regardless
+// of the location set by SetTimeLocation. Like time.Time.In, SetTimeIn panics if
+// loc is nil.
+// Unsettable struct fields will return an error.
+func (f *StructField) SetTimeIn(x time.Time, loc *time.Location) {
+	x = x.In(loc)
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.Preset(v).Set(reflect.ValueOf(x))
+		}
+		return nil
+	})
 }
 
 // SetDuration sets the field to the time.Duration value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetDuration(x time.Duration) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).Set(reflect.ValueOf(x))
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).Set(reflect.ValueOf(x))
+		}
+		return nil
+	})
 }
 
 // SetError sets the field to the error value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetError(x error) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).Set(reflect.ValueOf(x))
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).Set(reflect.ValueOf(x))
+		}
+		return nil
+	})
 }
 
 // SetString sets the field to the string value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetString(x string) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetString(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).SetString(x)
+		}
+		return nil
+	})
 }
 
 // SetBool sets the field to the bool value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetBool(x bool) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetBool(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).SetBool(x)
+		}
+		return nil
+	})
 }
 
-// SetInt sets the field to the int64 value x.
+// SetInt sets the field to the int64 value x. Values out of the range of
+// the field type are clamped when saturating, else the field is left unchanged
+// and an OverflowError is recorded, see SetSaturating and Err.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetInt(x int64) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetInt(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			if err := f.setInt(utils.PresetIndirect(v), reflect.ValueOf(x)); err != nil {
+				return f.Parent.setErrorsf(err, "invalid value for field %s", f.FullName())
+			}
+		}
+		return nil
+	})
 }
 
-// SetUint sets the field to the uint64 value x.
+// SetUint sets the field to the uint64 value x. Values out of the range of
+// the field type are clamped when saturating, else the field is left unchanged
+// and an OverflowError is recorded, see SetSaturating and Err.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetUint(x uint64) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetUint(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			if err := f.setUint(utils.PresetIndirect(v), reflect.ValueOf(x)); err != nil {
+				return f.Parent.setErrorsf(err, "invalid value for field %s", f.FullName())
+			}
+		}
+		return nil
+	})
 }
 
-// SetFloat sets the field to the float64 value x.
+// SetFloat sets the field to the float64 value x. Values out of the range of
+// the field type are clamped when saturating, else the field is left unchanged
+// and an OverflowError is recorded, see SetSaturating and Err.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetFloat(x float64) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetFloat(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			if err := f.setFloat(utils.PresetIndirect(v), reflect.ValueOf(x)); err != nil {
+				return f.Parent.setErrorsf(err, "invalid value for field %s", f.FullName())
+			}
+		}
+		return nil
+	})
 }
 
-// SetComplex sets the field to the complex128 value x.
+// SetComplex sets the field to the complex128 value x. Values out of the range of
+// the field type are clamped when saturating, else the field is left unchanged
+// and an OverflowError is recorded, see SetSaturating and Err.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetComplex(x complex128) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetComplex(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			if err := f.setComplex(utils.PresetIndirect(v), reflect.ValueOf(x)); err != nil {
+				return f.Parent.setErrorsf(err, "invalid value for field %s", f.FullName())
+			}
+		}
+		return nil
+	})
 }
 
 // SetBytes sets the field to the slice of bytes value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetBytes(x []byte) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).SetBytes(x)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).SetBytes(x)
+		}
+		return nil
+	})
 }
 
 // SetInterface sets the field to the interface value x.
@@ -536,31 +788,57 @@ func (f *StructField) SetInterface(x interface{}) { // NOTE: Not used!
 // SetStruct sets the field to the StructValue value x.
 // Unsettable struct fields will return an error.
 func (f *StructField) SetStruct(x *StructValue) {
-	v := f.value
-	if v.CanSet() {
-		utils.PresetIndirect(v).Set(x.value)
-	}
+	f.mutate(func() error {
+		v := f.value
+		if v.CanSet() {
+			utils.PresetIndirect(v).Set(x.value)
+		}
+		return nil
+	})
 }
 
 // TO REVISIT
 
 // Value returns the underlying value of the field.
-// Unexported struct fields will be neglected.
+// Unexported struct fields will be neglected, see ValueE.
 func (f *StructField) Value() reflect.Value {
+	v, err := f.ValueE()
+	if err != nil {
+		f.Parent.setErrorsf(ErrNotExported, "could not get value of field %s", f.FullName())
+	}
+	return v
+}
+
+// ValueE returns the underlying value of the field, like Value, along with the
+// error getting it, instead of saving the error in StructValue, so that
+// concurrent readers do not share error state.
+// Unexported struct fields will return a zero-value and an error.
+func (f *StructField) ValueE() (reflect.Value, error) {
 	v := f.value
-	if f.IsExported() {
-		return v
+	if f.isReadable() {
+		return f.Parent.frozenValue(v), nil
 	}
-	f.Parent.setErrorsf(ErrNotExported, "could not get value of field %s", f.FullName())
-	t := v.Type()
-	return reflect.New(t).Elem()
+	err := errors.Wrapf(ErrNotExported, "could not get value of field %s", f.FullName())
+	return reflect.New(v.Type()).Elem(), err
 }
 
 // Indirect returns the value that StructField f.value points to.
 // If f.value is a nil pointer, Indirect returns a zero Value.
 // If f.value is not a pointer, Indirect returns f.value.
+// Frozen structs return a copy, see Freeze.
 func (f *StructField) Indirect() reflect.Value {
-	return reflect.Indirect(f.value)
+	return f.Parent.frozenValue(reflect.Indirect(f.value))
+}
+
+// Addr returns a pointer to the field, e.g. a *int for an int field, so that it
+// can be handed over to APIs filling values in, such as sql.Rows.Scan.
+// Addr returns nil if the field is unexported, not addressable or frozen.
+func (f *StructField) Addr() interface{} {
+	v := f.value
+	if !f.isReadable() || !v.CanAddr() || f.Parent.IsFrozen() {
+		return nil
+	}
+	return v.Addr().Interface()
 }
 
 // IndirectType returns the type that field f points to.
@@ -618,23 +896,46 @@ func (f *StructField) AssignableTo(x reflect.Value) bool {
 // - number   <- number
 //   number   <- bool
 //   number   <- float (losing decimal point value)
+//   number   <- text
 // - float    <- float
 //   float    <- number
+//   float    <- text
 // - []byte   <- []byte
 //   []byte   <- text
 // - complex  <- complex
+//   complex  <- text
 //
 // NOTE: Set might benefit from using reflect.Type.AssignableTo() or ConvertibleTo().
 func (f *StructField) Set(dest interface{}) error {
+	return f.mutate(func() error { return f.set(dest) })
+}
+
+// SetStrict sets the field to dest like Set does, but without any implicit
+// conversion: dest must be assignable, or convertible following Go rules, to the
+// type of the field, or of the value it points to. Integers are not converted
+// into strings and numbers out of the range of the field type still return an
+// OverflowError. See also StructValue.Strict.
+// Unsettable struct fields, or values of other types, will return an error.
+func (f *StructField) SetStrict(dest interface{}) error {
+	return f.mutate(func() error { return f.setStrict(dest) })
+}
+
+// set is the implementation of Set, which neither checks the struct is frozen
+// nor keeps track of changes.
+func (f *StructField) set(dest interface{}) error {
 	fullname := f.FullName()
 
-	if !f.CanSet() {
+	if !f.value.CanSet() {
 		return errors.Wrapf(ErrNotSettable, "could not set field %s", fullname)
 	}
 
 	// Set(nil) <=> SetNil()
 	if dest == nil {
-		return f.SetNil()
+		return f.setNil()
+	}
+
+	if f.Parent != nil && f.Parent.IsStrict() {
+		return f.setStrict(dest)
 	}
 
 	v := f.value
@@ -644,6 +945,47 @@ func (f *StructField) Set(dest interface{}) error {
 		v = utils.PresetIndirect(v)
 	}
 
+	// Nullable database types, e.g. sql.NullString <- text
+	if value, valid, ok := nullFields(v); ok && v.Type() != x.Type() {
+		h := &StructField{index: f.index, indexes: f.indexes, value: value, field: f.field, Parent: f.Parent}
+		if err := h.set(dest); err != nil {
+			return err
+		}
+		valid.SetBool(true)
+		return nil
+	}
+
+	// Big numbers and registered numeric types, e.g. *big.Int <- text
+	if isNumericType(v.Type()) && v.Type() != x.Type() {
+		if ok, err := setNumeric(utils.Preset(v), dest); ok {
+			if err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
+			}
+			return nil
+		}
+	}
+
+	// Text unmarshalers, e.g. uuid.UUID or netip.Addr <- text
+	if u, ok := textUnmarshaler(v); ok && v.Type() != x.Type() && !utils.CanTime(v) {
+		var text []byte
+		switch {
+		case utils.CanString(x):
+			text = []byte(x.String())
+		case utils.CanBytes(x):
+			text = x.Bytes()
+		}
+		if text != nil {
+			if err := u.UnmarshalText(text); err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
+			}
+			return nil
+		}
+	}
+
+	if utils.CanTime(v) && utils.CanTime(x) {
+		x = reflect.ValueOf(normalizeTime(utils.Time(x)))
+	}
+
 	// Assignables
 	switch {
 	case f.AssignableTo(x):
@@ -658,12 +1000,11 @@ func (f *StructField) Set(dest interface{}) error {
 			v.Set(x)
 			return nil
 		case utils.CanString(x):
-			txt := x.String()
-			t, err := utils.StringToTime(txt)
+			t, err := f.parseTime(strings.TrimSpace(x.String()))
 			if err != nil {
-				return err
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
-			x = reflect.ValueOf(t)
+			x = reflect.ValueOf(normalizeTime(t))
 			v.Set(x)
 			return nil
 		}
@@ -673,24 +1014,29 @@ func (f *StructField) Set(dest interface{}) error {
 			v.Set(x)
 			return nil
 		case utils.CanString(x):
-			t := x.String()
-			if !strings.ContainsAny(t, "nsuµmh") {
-				t = t + "ns"
-			}
-			d, err := time.ParseDuration(t)
+			d, err := f.parseDuration(strings.TrimSpace(x.String()))
 			if err != nil {
-				return errors.Wrapf(err, "Invalid duration value. found: %s; want: [1s, 3h, ... ]", t)
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
 			v.Set(reflect.ValueOf(d))
 			return nil
-		case utils.CanInt(x):
-			v.Set(reflect.ValueOf(time.Duration(x.Int())))
-			return nil
-		case utils.CanUint(x):
-			v.Set(reflect.ValueOf(time.Duration(int64(x.Uint()))))
-			return nil
-		case utils.CanFloat(x):
-			v.Set(reflect.ValueOf(time.Duration(int64(x.Float()))))
+		case utils.CanInt(x), utils.CanUint(x), utils.CanFloat(x):
+			var d time.Duration
+			var err error
+			switch {
+			case utils.CanInt(x):
+				d, err = f.intDuration(x.Int())
+			case utils.CanUint(x) && x.Uint() <= math.MaxInt64:
+				d, err = f.intDuration(int64(x.Uint()))
+			case utils.CanUint(x):
+				err = errors.Errorf("invalid duration %d: out of range", x.Uint())
+			default:
+				d, err = f.numberDuration(x.Float())
+			}
+			if err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
+			}
+			v.Set(reflect.ValueOf(d))
 			return nil
 		}
 	case utils.CanError(v):
@@ -732,51 +1078,30 @@ func (f *StructField) Set(dest interface{}) error {
 			v.SetBool(x.Bool())
 			return nil
 		case utils.CanString(x):
-			switch strings.ToLower(x.String()) {
-			case "true", "yes", "y", "ok", "1":
-				v.SetBool(true)
-				return nil
-			default:
-				v.SetBool(false)
-				return nil
+			b, err := ParseBool(x.String())
+			if err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
+			v.SetBool(b)
+			return nil
 		case utils.CanInt(x):
-			switch x.Int() {
-			case 1:
-				v.SetBool(true)
-				return nil
-			case 0:
-				v.SetBool(true)
-				return nil
-			}
+			v.SetBool(x.Int() != 0)
+			return nil
 		case utils.CanUint(x):
-			switch x.Uint() {
-			case 1:
-				v.SetBool(false)
-				return nil
-			case 0:
-				v.SetBool(false)
-				return nil
-			}
+			v.SetBool(x.Uint() != 0)
+			return nil
 		case utils.CanFloat(x):
-			switch x.Float() {
-			case 1.0:
-				v.SetBool(false)
-				return nil
-			case 0.0:
-				v.SetBool(false)
-				return nil
+			b, err := isTruthy(x.Float())
+			if err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
+			v.SetBool(b)
+			return nil
 		}
 	case utils.CanInt(v):
 		switch {
-		case utils.CanInt(x):
-			int64X := x.Int()
-			if v.OverflowInt(int64X) {
-				return errors.Errorf("field %s(%s) could not represent int64", fullname, x.Type())
-			}
-			v.SetInt(int64X)
-			return nil
+		case utils.CanInt(x), utils.CanUint(x), utils.CanFloat(x):
+			return errors.Wrapf(f.setInt(v, x), "invalid value for field %s", fullname)
 		case utils.CanBool(x):
 			b := x.Bool()
 			if b {
@@ -786,19 +1111,16 @@ func (f *StructField) Set(dest interface{}) error {
 				v.SetInt(0) // var i int64 = 0; v.SetInt(i)
 				return nil
 			}
-		case utils.CanFloat(x):
-			v.SetInt(int64(x.Float()))
+		case utils.CanString(x):
+			if err := f.parseText(v, x.String()); err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
+			}
 			return nil
 		}
 	case utils.CanUint(v):
 		switch {
-		case utils.CanUint(x):
-			uint64X := x.Uint()
-			if v.OverflowUint(uint64X) {
-				return errors.Errorf("field %s(%s) could not represent uint64", fullname, x.Type())
-			}
-			v.SetUint(uint64X)
-			return nil
+		case utils.CanInt(x), utils.CanUint(x), utils.CanFloat(x):
+			return errors.Wrapf(f.setUint(v, x), "invalid value for field %s", fullname)
 		case utils.CanBool(x):
 			b := x.Bool()
 			if b {
@@ -808,24 +1130,20 @@ func (f *StructField) Set(dest interface{}) error {
 				v.SetUint(0) // var i uint64 = 0; v.SetUint(i)
 				return nil
 			}
-		case utils.CanFloat(x):
-			v.SetUint(uint64(x.Float()))
+		case utils.CanString(x):
+			if err := f.parseText(v, x.String()); err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
+			}
 			return nil
 		}
 	case utils.CanFloat(v):
 		switch {
-		case utils.CanFloat(x):
-			float64X := x.Float()
-			if v.OverflowFloat(float64X) {
-				return errors.Errorf("field %s(%s) could not represent float64", fullname, x.Type())
+		case utils.CanFloat(x), utils.CanInt(x), utils.CanUint(x):
+			return errors.Wrapf(f.setFloat(v, x), "invalid value for field %s", fullname)
+		case utils.CanString(x):
+			if err := f.parseText(v, x.String()); err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
-			v.SetFloat(float64X)
-			return nil
-		case utils.CanInt(x):
-			v.SetFloat(float64(x.Int()))
-			return nil
-		case utils.CanUint(x):
-			v.SetFloat(float64(x.Uint()))
 			return nil
 		}
 	case utils.CanBytes(v):
@@ -840,11 +1158,11 @@ func (f *StructField) Set(dest interface{}) error {
 	case utils.CanComplex(v):
 		switch {
 		case utils.CanComplex(x):
-			complex128X := x.Complex()
-			if v.OverflowComplex(complex128X) {
-				return errors.Errorf("field %s(%s) could not represent complex128", fullname, x.Type())
+			return errors.Wrapf(f.setComplex(v, x), "invalid value for field %s", fullname)
+		case utils.CanString(x):
+			if err := f.parseText(v, x.String()); err != nil {
+				return errors.Wrapf(err, "invalid value for field %s", fullname)
 			}
-			v.SetComplex(complex128X)
 			return nil
 		}
 	}
@@ -852,3 +1170,942 @@ func (f *StructField) Set(dest interface{}) error {
 	// Non-Assignables
 	return errors.Errorf("wrong kind of value for field %s. got: %q want: %q", fullname, x.Type(), v.Type())
 }
+
+// SetJSON unmarshals the JSON fragment data into the field, which may be of any
+// kind, including nested struct, slice and map fields. The field is set only
+// once data is fully decoded, leaving it untouched on error.
+// Unsettable struct fields will return an error.
+func (f *StructField) SetJSON(data []byte) error {
+	return f.mutate(func() error {
+		v, ctx := f.value, fmt.Sprintf("could not set field %s from json", f.FullName())
+		if !v.CanSet() {
+			return errors.Wrap(ErrNotSettable, ctx)
+		}
+		x := reflect.New(v.Type())
+		if err := json.Unmarshal(data, x.Interface()); err != nil {
+			return errors.Wrap(err, ctx)
+		}
+		v.Set(x.Elem())
+		return nil
+	})
+}
+
+// JSON returns the JSON encoding of the field value only, e.g. to handle parts
+// of a document independently.
+// Unexported struct fields will return an error.
+func (f *StructField) JSON() ([]byte, error) {
+	ctx := fmt.Sprintf("could not marshal field %s to json", f.FullName())
+	if !f.isReadable() {
+		return nil, errors.Wrap(ErrNotExported, ctx)
+	}
+	data, err := json.Marshal(f.value.Interface())
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	return data, nil
+}
+
+// Len returns the number of elements of a slice, array, map or string field,
+// including the one a pointer field points to. A nil pointer has zero length.
+// If field is of any other kind, Len returns the OutOfRange constant, i.e. -1.
+func (f *StructField) Len() int {
+	v := reflect.Indirect(f.value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len()
+	case reflect.Invalid:
+		return 0
+	}
+	return OutOfRange
+}
+
+// At returns a handle on element i of a slice or array field, named after the
+// field and the element index, e.g. "Items[2]", the counterpart of SetIndex.
+// At returns nil and adds an error to the field struct, if the field is not a
+// slice or if i is out of range.
+func (f *StructField) At(i int) *StructField {
+	e, err := f.elem(i)
+	if err != nil {
+		f.Parent.setErr(err)
+		return nil
+	}
+	return e
+}
+
+// SetIndex sets element i of a slice or array field to dest, following the
+// same rules as Set.
+func (f *StructField) SetIndex(i int, dest interface{}) error {
+	e, err := f.elem(i)
+	if err != nil {
+		return err
+	}
+	return e.Set(dest)
+}
+
+// Append appends values dest to a slice field, following the same rules as Set
+// for each element. On error, the field is left untouched.
+// Unsettable struct fields will return an error.
+func (f *StructField) Append(dest ...interface{}) error {
+	return f.mutate(func() error {
+		v, ctx := f.value, fmt.Sprintf("could not append to field %s", f.FullName())
+		if !v.CanSet() {
+			return errors.Wrap(ErrNotSettable, ctx)
+		}
+		if utils.CanPtr(v) {
+			v = utils.PresetIndirect(v)
+		}
+		if v.Kind() != reflect.Slice {
+			return errors.Wrap(ErrNotSlice, ctx)
+		}
+		n := v.Len()
+		elems := reflect.MakeSlice(v.Type(), 0, n+len(dest))
+		elems = reflect.AppendSlice(elems, v)
+		for i, x := range dest {
+			e := f.elemOf(reflect.New(v.Type().Elem()).Elem(), n+i)
+			if err := e.set(x); err != nil {
+				return errors.Wrap(err, ctx)
+			}
+			elems = reflect.Append(elems, e.value)
+		}
+		v.Set(elems)
+		return nil
+	})
+}
+
+// Truncate shortens a slice field to its first n elements.
+// Unsettable struct fields will return an error.
+func (f *StructField) Truncate(n int) error {
+	return f.mutate(func() error {
+		v, ctx := f.value, fmt.Sprintf("could not truncate field %s", f.FullName())
+		if !v.CanSet() {
+			return errors.Wrap(ErrNotSettable, ctx)
+		}
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Slice {
+			return errors.Wrap(ErrNotSlice, ctx)
+		}
+		if n < 0 || n > v.Len() {
+			return errors.Wrapf(ErrOutOfRange, "%s to length %d", ctx, n)
+		}
+		v.Set(v.Slice(0, n))
+		return nil
+	})
+}
+
+// Keys returns the keys of a map field, sorted by their text representation.
+// If field is not a map, Keys returns nil.
+func (f *StructField) Keys() []interface{} {
+	v := reflect.Indirect(f.value)
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	x := make([]interface{}, len(keys))
+	for i, k := range keys {
+		x[i] = k.Interface()
+	}
+	return x
+}
+
+// MapIndex returns the value associated with key in a map field. The key is
+// converted to the map key type following the same rules as Set, e.g. "1"
+// matches key 1 of a map[int]string. Its second returned value reports
+// whether the key is present.
+func (f *StructField) MapIndex(key interface{}) (interface{}, bool) {
+	v := reflect.Indirect(f.value)
+	if v.Kind() != reflect.Map {
+		return nil, false
+	}
+	k, err := f.mapKey(v, key)
+	if err != nil {
+		return nil, false
+	}
+	x := v.MapIndex(k)
+	if !x.IsValid() {
+		return nil, false
+	}
+	return x.Interface(), true
+}
+
+// SetMapIndex sets the value associated with key in a map field to dest. Both
+// key and dest are converted to the map key and element types following the
+// same rules as Set. A nil map is allocated first.
+// Unsettable struct fields will return an error.
+func (f *StructField) SetMapIndex(key, dest interface{}) error {
+	return f.mutate(func() error {
+		v, ctx := f.value, fmt.Sprintf("could not set key %v of field %s", key, f.FullName())
+		if !v.CanSet() {
+			return errors.Wrap(ErrNotSettable, ctx)
+		}
+		if utils.CanPtr(v) {
+			v = utils.PresetIndirect(v)
+		}
+		if v.Kind() != reflect.Map {
+			return errors.Wrap(ErrNotMap, ctx)
+		}
+		k, err := f.mapKey(v, key)
+		if err != nil {
+			return errors.Wrap(err, ctx)
+		}
+		e := f.elemOf(reflect.New(v.Type().Elem()).Elem(), key)
+		if err := e.set(dest); err != nil {
+			return errors.Wrap(err, ctx)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(k, e.value)
+		return nil
+	})
+}
+
+// DeleteKey deletes key from a map field. The key is converted to the map key
+// type following the same rules as Set. Deleting a missing key is a no-op.
+// Unsettable struct fields will return an error.
+func (f *StructField) DeleteKey(key interface{}) error {
+	return f.mutate(func() error {
+		v, ctx := f.value, fmt.Sprintf("could not delete key %v of field %s", key, f.FullName())
+		if !v.CanSet() {
+			return errors.Wrap(ErrNotSettable, ctx)
+		}
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Map {
+			return errors.Wrap(ErrNotMap, ctx)
+		}
+		k, err := f.mapKey(v, key)
+		if err != nil {
+			return errors.Wrap(err, ctx)
+		}
+		if !v.IsNil() {
+			v.SetMapIndex(k, reflect.Value{})
+		}
+		return nil
+	})
+}
+
+// Each calls fn for each element of a slice or array field, with the element
+// index as key, or for each entry of a map field, in the order of Keys. Each
+// stops at the first error returned by fn and returns it.
+// If field is not a slice, array or map, Each returns an error.
+func (f *StructField) Each(fn func(key, value reflect.Value) error) error {
+	v := reflect.Indirect(f.value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := fn(reflect.ValueOf(i), v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		for _, k := range f.Keys() {
+			key := reflect.ValueOf(k)
+			if err := fn(key, v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Invalid:
+		return nil
+	}
+	return errors.Errorf("could not iterate over field %s of kind %s", f.FullName(), v.Kind())
+}
+
+/*   U n e x p o r t e d   */
+
+// isReadable returns true if the value of the field can be read, i.e. it is
+// exported or access to unexported fields is allowed.
+func (f *StructField) isReadable() bool {
+	return f.IsExported() || f.value.CanInterface()
+}
+
+// isWritable returns true if the field is exported or writing unexported fields
+// is allowed. It does not check the field is settable.
+func (f *StructField) isWritable() bool {
+	return f.IsExported() || unexportedAccess() == UnexportedReadWrite
+}
+
+// canAllocate returns true if the field is promoted from nil embedded struct
+// pointers which can all be allocated, see allocate.
+func (f *StructField) canAllocate() bool {
+	if f.value.CanSet() || len(f.indexes) < 2 {
+		return false
+	}
+	v := f.Parent.value
+	for _, i := range f.indexes[:len(f.indexes)-1] {
+		v = v.Field(i)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v.CanSet()
+			}
+			v = v.Elem()
+		}
+	}
+	return false
+}
+
+// allocate allocates the nil embedded struct pointers the field is promoted
+// from, if any, and binds the field to its settable value. Fields of nil embedded
+// struct pointers are exposed as zero-values when read, and only allocated when
+// set, so that reading never modifies the struct.
+func (f *StructField) allocate() {
+	if !f.canAllocate() {
+		return
+	}
+	v := f.Parent.value
+	for _, i := range f.indexes[:len(f.indexes)-1] {
+		v = v.Field(i)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+	}
+	f.value = unlock(v.Field(f.indexes[len(f.indexes)-1]), f.field)
+}
+
+// mutate runs fn, which changes the value of the field, unless the root struct
+// is frozen. It records the change if the root struct is being tracked and fires
+// its OnSet callbacks.
+func (f *StructField) mutate(fn func() error) error {
+	r := f.Parent.root()
+	if r.frozen {
+		return errors.Wrapf(ErrReadOnly, "could not set field %s", f.FullName())
+	}
+	if f.value.CanSet() && !f.isWritable() { // i.e. readable-only unexported field
+		return errors.Wrapf(ErrNotSettable, "could not set field %s", f.FullName())
+	}
+	f.allocate()
+	if r.tracker == nil && len(r.hooks) == 0 {
+		return fn()
+	}
+	old := snapshot(f.value)
+	if err := fn(); err != nil {
+		return err
+	}
+	new := snapshot(f.value)
+	if r.tracker != nil {
+		r.tracker.record(f.Namespace(), old, new)
+	}
+	for _, hook := range r.hooks {
+		hook(f, old, new)
+	}
+	return nil
+}
+
+// setStrict is the implementation of SetStrict.
+func (f *StructField) setStrict(dest interface{}) error {
+	fullname := f.FullName()
+	if !f.value.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not set field %s", fullname)
+	}
+	if dest == nil {
+		return f.setNil()
+	}
+	v, x := f.value, reflect.ValueOf(dest)
+	if utils.CanPtr(v) && !x.Type().AssignableTo(v.Type()) && canSetStrict(x, v.Type().Elem()) {
+		v = utils.Preset(v)
+	}
+	switch {
+	case x.Type().AssignableTo(v.Type()):
+		v.Set(x)
+		return nil
+	case !canSetStrict(x, v.Type()):
+		return errors.Wrapf(ErrStrict, "could not set field %s of type %s to %s", fullname, v.Type(), x.Type())
+	case utils.CanInt(v):
+		return errors.Wrapf(f.setInt(v, x), "invalid value for field %s", fullname)
+	case utils.CanUint(v):
+		return errors.Wrapf(f.setUint(v, x), "invalid value for field %s", fullname)
+	case utils.CanFloat(v):
+		return errors.Wrapf(f.setFloat(v, x), "invalid value for field %s", fullname)
+	case utils.CanComplex(v):
+		return errors.Wrapf(f.setComplex(v, x), "invalid value for field %s", fullname)
+	}
+	v.Set(x.Convert(v.Type()))
+	return nil
+}
+
+// setZero is the implementation of SetZero.
+func (f *StructField) setZero() error {
+	v, ctx := f.value, fmt.Sprintf("could not set field %s to zero-value", f.FullName())
+	if !v.CanSet() {
+		return errors.Wrap(ErrNotSettable, ctx)
+	}
+	v.Set(utils.Zero(v))
+	return nil
+}
+
+// setNil is the implementation of SetNil.
+func (f *StructField) setNil() error {
+	v, ctx := f.value, fmt.Sprintf("could not set field %s to nil", f.FullName())
+	if !v.CanSet() {
+		return errors.Wrap(ErrNotSettable, ctx)
+	}
+	if _, _, ok := nullFields(v); ok {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if !utils.CanNil(v) {
+		return errors.Wrap(ErrNotNillable, ctx)
+	}
+	v.Set(utils.Zero(v))
+	return nil
+}
+
+// elemValue returns the value the field points to, if it is a pointer, else its
+// own value. For nil pointers, elemValue returns the zero-value of the type the
+// field points to.
+func (f *StructField) elemValue() reflect.Value {
+	v := f.value
+	if utils.CanPtr(v) {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+	if value, valid, ok := nullFields(v); ok {
+		if !valid.Bool() {
+			return reflect.Zero(value.Type())
+		}
+		return value
+	}
+	return v
+}
+
+// stringer returns the field value as a fmt.Stringer, possibly through a
+// pointer receiver if the field is addressable.
+func (f *StructField) stringer() (fmt.Stringer, bool) {
+	v := f.value
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s, true
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		s, ok := v.Addr().Interface().(fmt.Stringer)
+		return s, ok
+	}
+	return nil, false
+}
+
+// canSetStrict returns true if reflect value x can be set into a value of type t
+// in strict mode, i.e. if it is assignable or convertible to t, except integers
+// into strings.
+func canSetStrict(x reflect.Value, t reflect.Type) bool {
+	if x.Type().AssignableTo(t) {
+		return true
+	}
+	if t.Kind() == reflect.String && (utils.CanInt(x) || utils.CanUint(x)) {
+		return false
+	}
+	return x.CanConvert(t)
+}
+
+// isJSONMarshaler returns true if the field value implements json.Marshaler,
+// possibly through a pointer receiver, unless it is a time.Time or a nil
+// pointer.
+func (f *StructField) isJSONMarshaler() bool {
+	_, ok := f.jsonMarshaler()
+	return ok
+}
+
+// jsonMarshaler returns the field value as a json.Marshaler, see isJSONMarshaler.
+func (f *StructField) jsonMarshaler() (json.Marshaler, bool) {
+	v := f.value
+	if !f.isReadable() || !v.CanInterface() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, false
+	}
+	if utils.CanTime(reflect.Indirect(v)) {
+		return nil, false
+	}
+	if m, ok := v.Interface().(json.Marshaler); ok {
+		return m, true
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		m, ok := v.Addr().Interface().(json.Marshaler)
+		return m, ok
+	}
+	return nil, false
+}
+
+// mapValue returns the value of the field as rendered in maps, e.g. by ToMap:
+// values implementing json.Marshaler are decoded from their JSON encoding, with
+// numbers as json.Number, other values are returned as is.
+func (f *StructField) mapValue() interface{} {
+	if m, ok := f.jsonMarshaler(); ok {
+		if b, err := m.MarshalJSON(); err == nil {
+			var x interface{}
+			d := json.NewDecoder(bytes.NewReader(b))
+			d.UseNumber()
+			if err := d.Decode(&x); err == nil {
+				return x
+			}
+		}
+	}
+	return f.Interface()
+}
+
+// textUnmarshaler returns the addressable value v as an encoding.TextUnmarshaler,
+// if its type implements it.
+func textUnmarshaler(v reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if v.Kind() == reflect.Ptr || !v.CanAddr() || !v.Addr().CanInterface() {
+		return nil, false
+	}
+	u, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	return u, ok
+}
+
+// canMarshalText returns true if the type of v implements encoding.TextMarshaler,
+// unless it is time.Time which getters deal with as is.
+func canMarshalText(v reflect.Value) bool {
+	if !v.IsValid() || !v.CanInterface() || utils.CanTime(v) {
+		return false
+	}
+	_, ok := v.Interface().(encoding.TextMarshaler)
+	return ok
+}
+
+// marshalText returns the text encoding of v, see canMarshalText, or v as is if
+// it cannot be encoded.
+func marshalText(v reflect.Value) interface{} {
+	text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return v.Interface()
+	}
+	return string(text)
+}
+
+// formatText returns the text encoding of v, if v, or its address, implements
+// encoding.TextMarshaler, see canMarshalText. It returns false otherwise, or if
+// the encoding failed.
+func formatText(v reflect.Value) (string, bool) {
+	if !canMarshalText(v) {
+		if !v.IsValid() || !v.CanInterface() {
+			return "", false
+		}
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		if !canMarshalText(p) {
+			return "", false
+		}
+		v = p
+	}
+	text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", false
+	}
+	return string(text), true
+}
+
+// nullType returns the index of the value field of t, if t is, or points to, a
+// nullable database type, such as sql.NullString, see IsNullable.
+func nullType(t reflect.Type) (int, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.NumField() != 2 || !reflect.PtrTo(t).Implements(scannerType) {
+		return 0, false
+	}
+	for i := 0; i < 2; i++ {
+		if sf := t.Field(i); sf.Name == "Valid" && sf.Type.Kind() == reflect.Bool {
+			return 1 - i, true
+		}
+	}
+	return 0, false
+}
+
+// nullFields returns the value field and the Valid field of v, if v is a
+// nullable database type, such as sql.NullString, see IsNullable.
+func nullFields(v reflect.Value) (value, valid reflect.Value, ok bool) {
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return value, valid, false
+	}
+	i, ok := nullType(v.Type())
+	if !ok {
+		return value, valid, false
+	}
+	return v.Field(i), v.Field(1 - i), true
+}
+
+// Reflect types of the interfaces implemented by scalar struct types.
+var (
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// elem returns a handle on element i of a slice or array field.
+func (f *StructField) elem(i int) (*StructField, error) {
+	v := reflect.Indirect(f.value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, errors.Wrapf(ErrNotSlice, "could not get element %d of field %s", i, f.FullName())
+	}
+	if i < 0 || i >= v.Len() {
+		return nil, errors.Wrapf(ErrOutOfRange, "could not get element %d of field %s", i, f.FullName())
+	}
+	return f.elemOf(v.Index(i), i), nil
+}
+
+// indexOf returns the field itself if it is equal to value v, else the first
+// equal field of its nested struct or element of its collection, if any.
+func (f *StructField) indexOf(v reflect.Value) *StructField {
+	if !f.isReadable() {
+		return nil
+	}
+	if f.equal(v, 0) != OutOfRange { // i.e. exact match
+		return f
+	}
+	if f.CanStruct() {
+		return f.Struct().indexOf(v)
+	}
+	x := reflect.Indirect(f.value)
+	switch x.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < x.Len(); i++ {
+			if e := f.elemOf(x.Index(i), i).indexOf(v); e != nil {
+				return e
+			}
+		}
+	case reflect.Map:
+		for _, k := range f.Keys() {
+			if e := f.elemOf(x.MapIndex(reflect.ValueOf(k)), k).indexOf(v); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// mapKey converts key to the key type of map v, following the same rules as Set.
+func (f *StructField) mapKey(v reflect.Value, key interface{}) (reflect.Value, error) {
+	k := f.elemOf(reflect.New(v.Type().Key()).Elem(), key)
+	if err := k.set(key); err != nil {
+		return reflect.Value{}, err
+	}
+	return k.value, nil
+}
+
+// elemOf returns a handle on value v, as element or entry key of the field.
+func (f *StructField) elemOf(v reflect.Value, key interface{}) *StructField {
+	sf := f.field
+	sf.Name = fmt.Sprintf("%s[%v]", f.Name(), key)
+	sf.Type = v.Type()
+	sf.Tag = ""
+	sf.Anonymous = false
+	return &StructField{
+		index:   f.index,
+		indexes: f.indexes,
+		value:   v,
+		field:   sf,
+		owner:   f,
+		key:     fmt.Sprint(key),
+		Parent:  f.Parent,
+	}
+}
+
+// nameTag returns the struct tag key naming the field in documents, i.e. "json",
+// unless the field only has a "bson" struct tag.
+func (f *StructField) nameTag() string {
+	if _, ok := f.Tag("json"); !ok {
+		if _, ok := f.Tag("bson"); ok {
+			return "bson"
+		}
+	}
+	return "json"
+}
+
+// isHiddenBy returns true if the struct tag key of the field is equal to "-", or
+// if it has the omitempty option and the field is empty.
+func (f *StructField) isHiddenBy(key string) bool {
+	if val, ok := f.Tag(key); ok {
+		if val == "-" {
+			return true
+		}
+		if f.TagHasOption(key, "omitempty") {
+			if f.IsEmpty() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseTags splits the tag string into its key/value pairs, following the
+// conventional format read by reflect.StructTag.Lookup.
+func parseTags(tag reflect.StructTag) map[string]string {
+	tags := make(map[string]string)
+	t := string(tag)
+	for t != "" {
+		t = strings.TrimLeft(t, " ")
+		i := 0
+		for i < len(t) && t[i] > ' ' && t[i] != ':' && t[i] != '"' && t[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(t) || t[i] != ':' || t[i+1] != '"' {
+			break
+		}
+		key := t[:i]
+		t = t[i+1:]
+		i = 1
+		for i < len(t) && t[i] != '"' {
+			if t[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(t) {
+			break
+		}
+		value, err := strconv.Unquote(t[:i+1])
+		if err != nil {
+			break
+		}
+		tags[key] = value
+		t = t[i+1:]
+	}
+	return tags
+}
+
+// isElemKind returns true if values of kind k hold elements compared one by one,
+// i.e. pointers, slices, arrays and maps.
+func isElemKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// canStructType returns true if the field type is a nested struct or a pointer
+// to a nested struct, unlike CanStruct, even when the pointer is nil.
+func (f *StructField) canStructType() bool {
+	t := f.value.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) && !isScalarStruct(t)
+}
+
+// isScalar returns true if the field is handled as a single value even though
+// it is a struct, see isScalarStruct.
+func (f *StructField) isScalar() bool {
+	return isScalarStruct(f.value.Type())
+}
+
+// isScalarStruct returns true if t is, or points to, a struct type handled as a
+// single value rather than a nested struct, i.e. a nullable database type, a
+// numeric type, see IsNullable and RegisterNumeric, or a type encoding itself
+// as text, such as netip.Addr.
+func isScalarStruct(t reflect.Type) bool {
+	if _, ok := nullType(t); ok || isNumericType(t) {
+		return true
+	}
+	t = indirectType(t)
+	return reflect.PtrTo(t).Implements(textMarshalerType) && reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// setStrings sets the field to the text values x, parsing them according to
+// the field kind. Slice fields, apart from slices of bytes, receive one element
+// per value in x. Other fields are set using the first value in x.
+func (f *StructField) setStrings(x []string) error {
+	return f.mutate(func() error {
+		if len(x) == 0 {
+			return nil
+		}
+		v := f.value
+		if !v.CanSet() {
+			return errors.Wrapf(ErrNotSettable, "could not set field %s", f.FullName())
+		}
+		if utils.CanPtr(v) {
+			v = utils.PresetIndirect(v)
+		}
+		if v.Kind() == reflect.Slice && !utils.CanBytes(v) {
+			elems := reflect.MakeSlice(v.Type(), len(x), len(x))
+			for i, txt := range x {
+				if err := f.parseString(elems.Index(i), txt); err != nil {
+					return errors.Wrapf(err, "could not set field %s[%d]", f.FullName(), i)
+				}
+			}
+			v.Set(elems)
+			return nil
+		}
+		if err := f.parseString(v, x[0]); err != nil {
+			return errors.Wrapf(err, "could not set field %s", f.FullName())
+		}
+		return nil
+	})
+}
+
+// parseText parses the text x set into the number value v, see parseString.
+// Leading and trailing spaces are ignored, while an empty text is an error
+// rather than a zero-value.
+func (f *StructField) parseText(v reflect.Value, x string) error {
+	x = strings.TrimSpace(x)
+	if x == "" {
+		return errors.Errorf("invalid empty value for %s", v.Type())
+	}
+	return f.parseString(v, x)
+}
+
+// parseString parses the text x according to the kind of the settable reflect
+// value v and sets v to the result. Big numbers and registered numeric types are
+// set the way Set does, see RegisterNumeric. Types implementing
+// encoding.TextUnmarshaler, apart from time.Time, unmarshal x themselves. An
+// empty text sets non-string values to their zero-value, and nullable database
+// types, such as sql.NullString, to their invalid, i.e. NULL, value.
+func (f *StructField) parseString(v reflect.Value, x string) error {
+	if utils.CanPtr(v) {
+		v = utils.PresetIndirect(v)
+	}
+	if value, valid, ok := nullFields(v); ok {
+		if x == "" {
+			v.Set(utils.Zero(v))
+			return nil
+		}
+		if err := f.parseString(value, x); err != nil {
+			return err
+		}
+		valid.SetBool(true)
+		return nil
+	}
+	if isNumericType(v.Type()) {
+		if x == "" {
+			v.Set(utils.Zero(v))
+			return nil
+		}
+		if ok, err := setNumeric(v, x); ok {
+			return err
+		}
+	}
+	if u, ok := textUnmarshaler(v); ok && !utils.CanTime(v) {
+		if x == "" && !utils.CanString(v) {
+			v.Set(utils.Zero(v))
+			return nil
+		}
+		return u.UnmarshalText([]byte(x))
+	}
+	switch {
+	case utils.CanString(v):
+		v.SetString(x)
+		return nil
+	case utils.CanBytes(v):
+		v.SetBytes([]byte(x))
+		return nil
+	case x == "":
+		v.Set(utils.Zero(v))
+		return nil
+	case utils.CanTime(v):
+		t, err := f.parseTime(x)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(normalizeTime(t)))
+		return nil
+	case utils.CanDuration(v):
+		d, err := f.parseDuration(x)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(d))
+		return nil
+	case utils.CanError(v):
+		v.Set(reflect.ValueOf(errors.New(x)))
+		return nil
+	case utils.CanBool(v):
+		b, err := ParseBool(x)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case utils.CanInt(v):
+		return f.parseInt(v, x)
+	case utils.CanUint(v):
+		return f.parseUint(v, x)
+	case utils.CanFloat(v):
+		return f.parseFloat(v, x)
+	case utils.CanComplex(v):
+		c, err := strconv.ParseComplex(x, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetComplex(c)
+		return nil
+	}
+	return errors.Errorf("could not parse string %q into %q", x, v.Type())
+}
+
+// formatString returns the text representation of reflect value v, which is the
+// counterpart of parseString. Big numbers are formatted in base 10, see
+// formatNumeric, and types implementing encoding.TextMarshaler, apart from
+// time.Time, as their text encoding. Nil pointers and invalid nullable database
+// types are formatted as zero-value string.
+func formatString(v reflect.Value) string {
+	if utils.CanPtr(v) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if value, valid, ok := nullFields(v); ok {
+		if !valid.Bool() {
+			return ""
+		}
+		return formatString(value)
+	}
+	if text, ok := formatNumeric(v); ok {
+		return text
+	}
+	if text, ok := formatText(v); ok {
+		return text
+	}
+	switch {
+	case utils.CanTime(v):
+		return utils.Time(v).Format(time.RFC3339)
+	case utils.CanDuration(v):
+		return utils.Duration(v).String()
+	case utils.CanError(v):
+		if err := utils.Error(v); err != nil {
+			return err.Error()
+		}
+		return ""
+	case utils.CanString(v):
+		return v.String()
+	case utils.CanBool(v):
+		return strconv.FormatBool(v.Bool())
+	case utils.CanInt(v):
+		return strconv.FormatInt(v.Int(), 10)
+	case utils.CanUint(v):
+		return strconv.FormatUint(v.Uint(), 10)
+	case utils.CanFloat(v):
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case utils.CanComplex(v):
+		return strconv.FormatComplex(v.Complex(), 'f', -1, v.Type().Bits())
+	case utils.CanBytes(v):
+		return string(v.Bytes())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// formatStrings returns the text representation of reflect value v, like
+// formatString, except for slices and arrays, apart from slices of bytes, whose
+// elements are formatted one by one and joined using the separator sep.
+func formatStrings(v reflect.Value, sep string) string {
+	if utils.CanSlice(v) && !utils.CanBytes(v) {
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = formatString(v.Index(i))
+		}
+		return strings.Join(values, sep)
+	}
+	return formatString(v)
+}
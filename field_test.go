@@ -1,6 +1,9 @@
 package structs
 
 import (
+	"database/sql"
+	"encoding/json"
+	"net/netip"
 	"reflect"
 	"testing"
 	"time"
@@ -164,3 +167,303 @@ func TestFieldInvalidArg(t *testing.T) {
 	assert.NotEqual(t, nil, err)
 	assert.Equal(t, "invalid argument type; want: \"string\" or \"int\", got: \"bool\"", err.Error())
 }
+
+func TestFieldTags(t *testing.T) {
+	type testStruct struct {
+		Name    string `json:"name,omitempty" db:"name" validate:"required,min=3"`
+		Quoted  string `note:"a \"quoted\" value" json:"-"`
+		Untaged string
+	}
+	s, err := New(&testStruct{})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, map[string]string{
+		"json":     "name,omitempty",
+		"db":       "name",
+		"validate": "required,min=3",
+	}, s.Field("Name").Tags())
+	assert.Equal(t, map[string]string{"note": `a "quoted" value`, "json": "-"}, s.Field("Quoted").Tags())
+	assert.Equal(t, map[string]string{"json": "broken"}, parseTags(reflect.StructTag(`json:"broken" db:name`)))
+	assert.Equal(t, map[string]string{}, s.Field("Untaged").Tags())
+}
+
+func TestFieldTagOptions(t *testing.T) {
+	type testStruct struct {
+		Name   string `json:"name,omitempty,string"`
+		Hidden string `json:"-"`
+		Inline string `bson:",inline"`
+		Empty  string `json:",omitempty"`
+	}
+	s, err := New(&testStruct{})
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Name")
+	assert.Equal(t, "name", f.TagName("json"))
+	assert.Equal(t, "Name", f.TagName("db"))
+	assert.Equal(t, true, f.TagHasOption("json", "omitempty"))
+	assert.Equal(t, true, f.TagHasOption("json", "string"))
+	assert.Equal(t, false, f.TagHasOption("json", "name"))
+	assert.Equal(t, false, f.TagHasOption("json", "omit"))
+	assert.Equal(t, false, f.TagHasOption("db", "omitempty"))
+
+	assert.Equal(t, "", s.Field("Hidden").TagName("json"))
+	assert.Equal(t, "Inline", s.Field("Inline").TagName("bson"))
+	assert.Equal(t, true, s.Field("Inline").TagHasOption("bson", "inline"))
+	assert.Equal(t, "Empty", s.Field("Empty").TagName("json"))
+	assert.Equal(t, true, s.Field("Empty").TagHasOption("json", "omitempty"))
+}
+
+func TestFieldIsEmpty(t *testing.T) {
+	type Program struct {
+		Name string `json:"name,omitempty"`
+	}
+	type Server struct {
+		Name    string    `json:"name,omitempty"`
+		Program Program   `json:"program,omitempty"`
+		Backup  *Program  `json:"backup,omitempty"`
+		Created time.Time `json:"created,omitempty"`
+	}
+
+	server := Server{}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, s.Field("Name").IsHidden())
+	assert.Equal(t, false, s.Field("Program").IsEmpty())
+	assert.Equal(t, false, s.Field("Program").IsHidden())
+	assert.Equal(t, true, s.Field("Backup").IsHidden())
+	assert.Equal(t, false, s.Field("Created").IsHidden())
+	assert.Equal(t, true, s.IsEmpty())
+
+	b, err := json.Marshal(server)
+	assert.Equal(t, nil, err)
+	var m map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(b, &m))
+	for _, f := range s.Fields() {
+		_, ok := m[f.NameJson()]
+		assert.Equal(t, !ok, f.IsHidden(), f.Name())
+	}
+
+	server.Program.Name = "Apache"
+	assert.Equal(t, false, s.IsEmpty())
+}
+
+func TestFieldNullable(t *testing.T) {
+	type testStruct struct {
+		Name    sql.NullString
+		Count   sql.NullInt64
+		Enabled sql.NullBool
+		Ratio   sql.NullFloat64
+		Created sql.NullTime
+		Label   *sql.NullString
+		Plain   string
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Name")
+	assert.Equal(t, true, f.IsNullable())
+	assert.Equal(t, false, f.CanStruct())
+	assert.Equal(t, true, f.IsNil())
+	assert.Equal(t, true, f.IsZero())
+	assert.Equal(t, nil, f.Get())
+	assert.Equal(t, "", f.String())
+	assert.Equal(t, nil, f.Set("Apache"))
+	assert.Equal(t, sql.NullString{String: "Apache", Valid: true}, ts.Name)
+	assert.Equal(t, false, f.IsNil())
+	assert.Equal(t, false, f.IsZero())
+	assert.Equal(t, "Apache", f.Get())
+	assert.Equal(t, "Apache", f.String())
+	assert.Equal(t, nil, f.Set(""))
+	assert.Equal(t, sql.NullString{Valid: true}, ts.Name)
+	assert.Equal(t, false, f.IsZero())
+	assert.Equal(t, nil, f.Set(nil))
+	assert.Equal(t, sql.NullString{}, ts.Name)
+	assert.Equal(t, nil, f.Set(sql.NullString{String: "Nginx", Valid: true}))
+	assert.Equal(t, "Nginx", ts.Name.String)
+
+	assert.Equal(t, nil, s.Field("Count").Set("42"))
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, ts.Count)
+	assert.Equal(t, int64(42), s.Field("Count").Int())
+	assert.Equal(t, nil, s.Field("Enabled").Set(true))
+	assert.Equal(t, true, s.Field("Enabled").Bool())
+	assert.Equal(t, nil, s.Field("Ratio").Set(0.5))
+	assert.Equal(t, 0.5, s.Field("Ratio").Float())
+	assert.Equal(t, nil, s.Field("Created").Set("2021-08-03"))
+	assert.Equal(t, time.Date(2021, time.August, 3, 0, 0, 0, 0, time.UTC), s.Field("Created").Time())
+	assert.Equal(t, true, ts.Created.Valid)
+	assert.NotEqual(t, nil, s.Field("Count").Set("many"))
+	assert.NotEqual(t, nil, s.Field("Count").Set(" "))
+	assert.Equal(t, int64(42), ts.Count.Int64)
+
+	f = s.Field("Label")
+	assert.Equal(t, true, f.IsNullable())
+	assert.Equal(t, true, f.IsNil())
+	assert.Equal(t, nil, f.Set("web"))
+	assert.Equal(t, &sql.NullString{String: "web", Valid: true}, ts.Label)
+	assert.Equal(t, "web", f.String())
+
+	assert.Equal(t, false, s.Field("Plain").IsNullable())
+}
+
+type testLevel int
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 0:
+		return []byte("info"), nil
+	case 1:
+		return []byte("debug"), nil
+	}
+	return nil, errors.Errorf("unknown level %d", l)
+}
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "info":
+		*l = 0
+	case "debug":
+		*l = 1
+	default:
+		return errors.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func TestFieldText(t *testing.T) {
+	type testStruct struct {
+		Level testLevel
+		Addr  netip.Addr
+		Ptr   *testLevel
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Level")
+	assert.Equal(t, nil, f.Set("debug"))
+	assert.Equal(t, testLevel(1), ts.Level)
+	assert.Equal(t, "debug", f.Get())
+	assert.Equal(t, nil, f.Set([]byte("info")))
+	assert.Equal(t, testLevel(0), ts.Level)
+	assert.NotEqual(t, nil, f.Set("trace"))
+	assert.Equal(t, nil, f.Set(testLevel(1)))
+	assert.Equal(t, testLevel(1), ts.Level)
+	assert.Equal(t, nil, f.Set(5))
+	assert.Equal(t, testLevel(5), f.Get())
+
+	f = s.Field("Addr")
+	assert.Equal(t, false, f.CanStruct())
+	assert.Equal(t, nil, f.Set("192.168.0.1"))
+	assert.Equal(t, netip.MustParseAddr("192.168.0.1"), ts.Addr)
+	assert.Equal(t, "192.168.0.1", f.Get())
+	assert.NotEqual(t, nil, f.Set("localhost"))
+
+	f = s.Field("Ptr")
+	assert.Equal(t, nil, f.Set("debug"))
+	assert.Equal(t, testLevel(1), *ts.Ptr)
+	assert.Equal(t, "debug", f.Get())
+}
+
+type testColorName int
+
+func (c *testColorName) String() string { return []string{"red", "green", "blue"}[*c] }
+
+func TestFieldStringOf(t *testing.T) {
+	type testStruct struct {
+		Name     string
+		Count    int
+		Timeout  time.Duration
+		Color    testColorName
+		Nickname *string
+		Score    sql.NullFloat64
+		Tags     []string
+		secret   string
+	}
+	ts := testStruct{
+		Name:    "Roninzo",
+		Count:   42,
+		Timeout: time.Minute,
+		Color:   2,
+		Score:   sql.NullFloat64{Float64: 1.5, Valid: true},
+		Tags:    []string{"a", "b"},
+		secret:  "s3cr3t",
+	}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "Roninzo", s.Field("Name").StringOf())
+	assert.Equal(t, "42", s.Field("Count").StringOf())
+	assert.Equal(t, "1m0s", s.Field("Timeout").StringOf())
+	assert.Equal(t, "blue", s.Field("Color").StringOf())
+	assert.Equal(t, "", s.Field("Nickname").StringOf())
+	assert.Equal(t, "1.5", s.Field("Score").StringOf())
+	assert.Equal(t, "[a b]", s.Field("Tags").StringOf())
+	assert.Equal(t, nil, s.Err())
+
+	assert.Equal(t, "", s.Field("secret").StringOf())
+	assert.Equal(t, ErrNotExported, errors.Cause(s.Err()))
+}
+
+func TestFieldSetStrict(t *testing.T) {
+	type Level int
+	type testNested struct {
+		Count int
+	}
+	type testStruct struct {
+		Name    string
+		Port    uint16
+		Ratio   float64
+		Level   Level
+		Timeout time.Duration
+		Enabled *bool
+		Tags    []string
+		Nested  testNested
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, s.Field("Port").SetStrict(8080))
+	assert.Equal(t, uint16(8080), ts.Port)
+	var overflow *OverflowError
+	assert.Equal(t, true, errors.As(s.Field("Port").SetStrict(-1), &overflow))
+	assert.Equal(t, nil, s.Field("Ratio").SetStrict(float32(0.5)))
+	assert.Equal(t, 0.5, ts.Ratio)
+	assert.Equal(t, nil, s.Field("Level").SetStrict(2))
+	assert.Equal(t, Level(2), ts.Level)
+	assert.Equal(t, nil, s.Field("Timeout").SetStrict(time.Second))
+	assert.Equal(t, time.Second, ts.Timeout)
+	assert.Equal(t, nil, s.Field("Enabled").SetStrict(true))
+	assert.Equal(t, true, *ts.Enabled)
+	assert.Equal(t, nil, s.Field("Enabled").SetStrict(nil))
+	assert.Equal(t, (*bool)(nil), ts.Enabled)
+
+	for name, x := range map[string]interface{}{
+		"Name":    65,
+		"Port":    "8080",
+		"Ratio":   "0.5",
+		"Timeout": "1s",
+		"Enabled": "yes",
+		"Tags":    "a,b",
+	} {
+		err := s.Field(name).SetStrict(x)
+		assert.Equal(t, ErrStrict, errors.Cause(err), name)
+	}
+	assert.Equal(t, "", ts.Name)
+	assert.Equal(t, (*bool)(nil), ts.Enabled)
+
+	assert.Equal(t, false, s.IsStrict())
+	assert.Equal(t, nil, s.Field("Port").Set("443"))
+	assert.Equal(t, true, s.Strict(true).IsStrict())
+	assert.Equal(t, ErrStrict, errors.Cause(s.Field("Port").Set("443")))
+	assert.Equal(t, uint16(443), ts.Port)
+	nested := s.Field("Nested").Struct()
+	assert.Equal(t, true, nested.IsStrict())
+	assert.Equal(t, ErrStrict, errors.Cause(nested.Field("Count").Set("3")))
+	assert.Equal(t, nil, nested.Field("Count").Set(int8(3)))
+	assert.Equal(t, 3, ts.Nested.Count)
+	s.Strict(false)
+	assert.Equal(t, nil, nested.Field("Count").Set("4"))
+	assert.Equal(t, 4, ts.Nested.Count)
+}
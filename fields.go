@@ -19,6 +19,16 @@ func (s *StructValue) Fields() StructFields {
 	return s.fieldsByIndex
 }
 
+// FieldsDeep returns all the leaf fields of the struct in a slice, recursively,
+// i.e. the fields of nested structs replace the fields holding them, so that
+// generic processors do not need to recurse on their own. Each field reports
+// its full path with Namespace, e.g. "Program.Name", and its owning struct with
+// Parent. Nil pointers to nested structs are neglected.
+func (s *StructValue) FieldsDeep() StructFields {
+	fields, _ := s.columns(&columnOptions{recursive: true}, "")
+	return fields
+}
+
 /*   I m p l e m e n t a t i o n   */
 
 // Names returns all the field names of the struct. This method is not
@@ -32,6 +42,19 @@ func (fields StructFields) Names() []string {
 	return names
 }
 
+// Pointers returns pointers to all the fields of the struct, in declared order,
+// so that a struct can be passed straight to sql.Rows.Scan(ptrs...) or similar.
+// Unexported struct fields will be neglected.
+func (fields StructFields) Pointers() []interface{} {
+	ptrs := make([]interface{}, 0, len(fields))
+	for _, f := range fields {
+		if x := f.Addr(); x != nil {
+			ptrs = append(ptrs, x)
+		}
+	}
+	return ptrs
+}
+
 // Parent returns the related StructValue object (which is a level above StructFields).
 func (fields StructFields) Parent() *StructValue {
 	return fields[0].Parent
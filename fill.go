@@ -0,0 +1,370 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// FillOption configures how structs are filled with random data, see Fill.
+type FillOption func(*filler)
+
+// fillSource is the source of the random numbers used to fill structs.
+type fillSource interface {
+	Uint64() uint64
+}
+
+// fuzzSource reads the numbers used to fill structs out of a byte stream, and
+// returns zeros once the stream is exhausted.
+type fuzzSource struct {
+	data []byte
+}
+
+// filler populates values with plausible random data.
+type filler struct {
+	src      fillSource
+	fuzz     *fuzzSource // Byte stream mapped raw onto values, if any, see FillFromBytes.
+	maxDepth int         // Depth of nested structs after which pointers, slices and maps are left empty.
+}
+
+// fillMaxDepth is the default depth of nested structs that are filled, which
+// stops recursive types from being filled forever.
+const fillMaxDepth = 5
+
+var (
+	fillFirstNames = []string{"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda", "Ada", "Alan"}
+	fillLastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Lovelace", "Turing"}
+	fillWords      = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet", "kilo", "lima"}
+	fillDomains    = []string{"example.com", "example.org", "example.net", "test.io"}
+	fillCountries  = []string{"France", "Germany", "Italy", "Japan", "Brazil", "Canada", "Kenya", "Norway"}
+)
+
+/*   F u n c t i o n s   */
+
+// WithSeed makes Fill deterministic, generating the same data for the same seed,
+// e.g. for reproducible fixtures. Without it, Fill is seeded with the time.
+func WithSeed(seed int64) FillOption {
+	return func(fl *filler) {
+		fl.src = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Fill populates every settable field of the struct, or slice of structs, dest
+// with plausible random data, respecting field kinds, e.g. to generate unit
+// tests and benchmarks fixtures instantly. Nested structs are filled
+// recursively, nil pointers are allocated, and slices and maps receive one to
+// three elements. String fields tagged `faker:"..."` receive data of that kind:
+// name, first_name, last_name, username, email, domain, url, phone, uuid, ipv4,
+// country, word or sentence. Fields tagged `faker:"-"` are left untouched.
+// Unexported struct fields will be neglected.
+func Fill(dest interface{}, opts ...FillOption) error {
+	fl := &filler{maxDepth: fillMaxDepth}
+	for _, opt := range opts {
+		opt(fl)
+	}
+	if fl.src == nil {
+		fl.src = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return fl.fillDest(dest, "could not fill struct")
+}
+
+// FillFromBytes deterministically populates every settable field of the struct,
+// or slice of structs, dest out of the byte stream data, e.g. provided by a
+// fuzzer, making it trivial to fuzz functions taking structs:
+//
+//	func FuzzHandler(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			var req Request
+//			if err := structs.FillFromBytes(&req, data); err != nil {
+//				t.Skip()
+//			}
+//			Handler(req)
+//		})
+//	}
+//
+// Contrary to Fill, data is mapped raw onto values, over their whole range:
+// numbers take as many bytes as their size, strings and byte slices are
+// prefixed with a length byte, and slices and maps receive zero to three
+// elements. Once data is exhausted, remaining values are set to zero.
+// Unexported struct fields will be neglected.
+func FillFromBytes(dest interface{}, data []byte) error {
+	fs := &fuzzSource{data: data}
+	fl := &filler{src: fs, fuzz: fs, maxDepth: fillMaxDepth}
+	return fl.fillDest(dest, "could not fill struct from bytes")
+}
+
+/*   U n e x p o r t e d   */
+
+// fillDest fills the struct, or slice of structs, dest. ctx is the context of
+// the errors returned.
+func (fl *filler) fillDest(dest interface{}, ctx string) error {
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	ctx = fmt.Sprintf("%s %s", ctx, s.Name())
+	if !s.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s.Name()), ctx)
+	}
+	if s.Multiple() {
+		return errors.Wrap(fl.fill(reflect.ValueOf(dest).Elem(), "", 0), ctx)
+	}
+	return errors.Wrap(fl.fillStruct(s, 0), ctx)
+}
+
+// fillStruct fills the settable exported fields of struct s, found at depth.
+func (fl *filler) fillStruct(s *StructValue, depth int) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not fill struct %s", s.FullName())
+	}
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		tag, _ := f.Tag("faker")
+		if tag == "-" {
+			continue
+		}
+		if err := f.mutate(func() error { return fl.fill(f.value, tag, depth) }); err != nil {
+			return errors.Wrapf(err, "could not fill field %s", f.FullName())
+		}
+	}
+	return nil
+}
+
+// fill sets the settable value v to random data, of the faker kind tag, if any.
+func (fl *filler) fill(v reflect.Value, tag string, depth int) error {
+	switch v.Type() {
+	case reflect.TypeOf(time.Time{}):
+		t := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		v.Set(reflect.ValueOf(t.Add(time.Duration(fl.intn(30*365*24*3600)) * time.Second)))
+		return nil
+	case reflect.TypeOf(time.Duration(0)):
+		v.SetInt(int64(fl.intn(3600)+1) * int64(time.Second))
+		return nil
+	}
+	if fl.fuzz != nil && fl.fuzz.fill(v) {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if depth >= fl.maxDepth {
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return fl.fill(v.Elem(), tag, depth)
+	case reflect.Struct:
+		s, err := New(v.Addr().Interface())
+		if err != nil {
+			return err
+		}
+		return fl.fillStruct(s, depth+1)
+	case reflect.Bool:
+		v.SetBool(fl.intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(fl.intn(fl.numberMax(v))))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(fl.intn(fl.numberMax(v))))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(fl.intn(100000)) / 100)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(fl.intn(1000)), float64(fl.intn(1000))))
+	case reflect.String:
+		v.SetString(fl.fake(tag))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, 8+fl.intn(9))
+			for i := range b {
+				b[i] = byte(fl.intn(256))
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		if depth >= fl.maxDepth {
+			return nil
+		}
+		n := fl.length()
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			if err := fl.fill(v.Index(i), tag, depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := fl.fill(v.Index(i), tag, depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if depth >= fl.maxDepth {
+			return nil
+		}
+		n := fl.length()
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := fl.fill(k, "", depth); err != nil {
+				return err
+			}
+			x := reflect.New(v.Type().Elem()).Elem()
+			if err := fl.fill(x, tag, depth); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, x)
+		}
+	}
+	// Interfaces, funcs, channels and unsafe pointers are left untouched.
+	return nil
+}
+
+// fake returns random text of the faker kind tag, a random word by default.
+func (fl *filler) fake(tag string) string {
+	first, last := fl.pick(fillFirstNames), fl.pick(fillLastNames)
+	switch strings.TrimSpace(strings.Split(tag, ",")[0]) {
+	case "name":
+		return first + " " + last
+	case "first_name":
+		return first
+	case "last_name":
+		return last
+	case "username":
+		return strings.ToLower(first) + fmt.Sprint(fl.intn(100))
+	case "email":
+		return strings.ToLower(first+"."+last) + "@" + fl.pick(fillDomains)
+	case "domain":
+		return fl.pick(fillWords) + "." + fl.pick(fillDomains)
+	case "url":
+		return "https://" + fl.pick(fillDomains) + "/" + fl.pick(fillWords)
+	case "phone":
+		return fmt.Sprintf("+1-%03d-%03d-%04d", 200+fl.intn(800), fl.intn(1000), fl.intn(10000))
+	case "uuid":
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(fl.intn(256))
+		}
+		b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", 1+fl.intn(254), fl.intn(256), fl.intn(256), 1+fl.intn(254))
+	case "country":
+		return fl.pick(fillCountries)
+	case "sentence":
+		words := make([]string, 4+fl.intn(5))
+		for i := range words {
+			words[i] = fl.pick(fillWords)
+		}
+		x := strings.Join(words, " ")
+		return strings.ToUpper(x[:1]) + x[1:] + "."
+	}
+	return fl.pick(fillWords)
+}
+
+// numberMax returns the exclusive upper bound of the random numbers filling
+// the number value v, small enough not to overflow it.
+func (fl *filler) numberMax(v reflect.Value) int {
+	if v.Type().Size() == 1 {
+		return 100
+	}
+	return 10000
+}
+
+// length returns the random length of a slice or map, from one to three, or
+// from zero to three elements when filling from bytes.
+func (fl *filler) length() int {
+	if fl.fuzz != nil {
+		return int(fl.fuzz.byte() % 4)
+	}
+	return 1 + fl.intn(3)
+}
+
+// pick returns a random element of x.
+func (fl *filler) pick(x []string) string {
+	return x[fl.intn(len(x))]
+}
+
+// intn returns a random number in [0,n).
+func (fl *filler) intn(n int) int {
+	return int(fl.src.Uint64() % uint64(n))
+}
+
+// fill sets the settable value v raw out of the byte stream, if v is a boolean,
+// a number, a string or a byte slice, and reports whether it did.
+func (fs *fuzzSource) fill(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(fs.byte()&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := 64 - 8*uint(v.Type().Size())
+		v.SetInt(int64(fs.uint(int(v.Type().Size()))<<shift) >> shift)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(fs.uint(int(v.Type().Size())))
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(uint32(fs.uint(4)))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(fs.uint(8)))
+	case reflect.Complex64:
+		re, im := math.Float32frombits(uint32(fs.uint(4))), math.Float32frombits(uint32(fs.uint(4)))
+		v.SetComplex(complex(float64(re), float64(im)))
+	case reflect.Complex128:
+		re, im := math.Float64frombits(fs.uint(8)), math.Float64frombits(fs.uint(8))
+		v.SetComplex(complex(re, im))
+	case reflect.String:
+		v.SetString(string(fs.bytes(int(fs.byte()))))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return false
+		}
+		v.SetBytes(fs.bytes(int(fs.byte())))
+	default:
+		return false
+	}
+	return true
+}
+
+// Uint64 returns the next 8 bytes as a big-endian number.
+func (fs *fuzzSource) Uint64() uint64 {
+	return fs.uint(8)
+}
+
+// uint returns the next size bytes as a big-endian number.
+func (fs *fuzzSource) uint(size int) uint64 {
+	var n uint64
+	for i := 0; i < size; i++ {
+		n = n<<8 | uint64(fs.byte())
+	}
+	return n
+}
+
+// bytes returns the next n bytes, or the remaining ones if fewer.
+func (fs *fuzzSource) bytes(n int) []byte {
+	if n > len(fs.data) {
+		n = len(fs.data)
+	}
+	b := append([]byte(nil), fs.data[:n]...)
+	fs.data = fs.data[n:]
+	return b
+}
+
+// byte returns the next byte, or zero if the stream is exhausted.
+func (fs *fuzzSource) byte() byte {
+	if len(fs.data) == 0 {
+		return 0
+	}
+	b := fs.data[0]
+	fs.data = fs.data[1:]
+	return b
+}
@@ -0,0 +1,129 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFillNode struct {
+	Value int
+	Next  *testFillNode
+}
+
+func TestFill(t *testing.T) {
+	type Program struct {
+		Name    string `faker:"word"`
+		Version *string
+	}
+	type Server struct {
+		Name     string `faker:"name"`
+		Email    string `faker:"email"`
+		ID       string `faker:"uuid"`
+		Skipped  string `faker:"-"`
+		Port     uint16
+		Level    int8
+		Ratio    float64
+		Enabled  bool
+		Created  time.Time
+		Timeout  time.Duration
+		Data     []byte
+		Program  *Program
+		Backups  []Program
+		Labels   map[string]int
+		Node     testFillNode
+		Any      interface{}
+		internal string
+	}
+
+	var s1, s2 Server
+	err := Fill(&s1, WithSeed(42))
+	assert.Equal(t, nil, err)
+	err = Fill(&s2, WithSeed(42))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, s1, s2)
+
+	assert.Equal(t, 1, strings.Count(s1.Name, " "))
+	assert.Equal(t, true, strings.Contains(s1.Email, "@"))
+	assert.Equal(t, 36, len(s1.ID))
+	assert.Equal(t, "", s1.Skipped)
+	assert.Equal(t, false, s1.Created.IsZero())
+	assert.NotEqual(t, time.Duration(0), s1.Timeout)
+	assert.NotEqual(t, 0, len(s1.Data))
+	assert.NotEqual(t, (*Program)(nil), s1.Program)
+	assert.NotEqual(t, "", s1.Program.Name)
+	assert.NotEqual(t, (*string)(nil), s1.Program.Version)
+	assert.NotEqual(t, 0, len(s1.Backups))
+	assert.NotEqual(t, 0, len(s1.Labels))
+	assert.Equal(t, nil, s1.Any)
+	assert.Equal(t, "", s1.internal)
+
+	depth := 0
+	for n := &s1.Node; n != nil; n = n.Next {
+		depth++
+	}
+	assert.Equal(t, fillMaxDepth, depth)
+
+	var s3 Server
+	err = Fill(&s3, WithSeed(7))
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, s1, s3)
+
+	var rows []Program
+	err = Fill(&rows, WithSeed(42))
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, 0, len(rows))
+
+	err = Fill(s1)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFillFromBytes(t *testing.T) {
+	type Program struct {
+		Name string
+	}
+	type Server struct {
+		Enabled bool
+		Port    int16
+		ID      uint32
+		Name    string
+		Data    []byte
+		Tags    []string
+		Program *Program
+		Ratio   float64
+	}
+
+	data := []byte{
+		0x01,       // Enabled
+		0xff, 0xfe, // Port
+		0x00, 0x00, 0x01, 0x00, // ID
+		0x03, 'a', 'b', 'c', // Name
+		0x02, 0x10, 0x20, // Data
+		0x05, 0x01, 'x', // Tags: 5%4 = 1 element
+		0x02, 'h', 'i', // Program.Name
+	}
+	var s1, s2 Server
+	err := FillFromBytes(&s1, data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Server{
+		Enabled: true,
+		Port:    -2,
+		ID:      256,
+		Name:    "abc",
+		Data:    []byte{0x10, 0x20},
+		Tags:    []string{"x"},
+		Program: &Program{Name: "hi"},
+		Ratio:   0,
+	}, s1)
+
+	err = FillFromBytes(&s2, data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, s1, s2)
+
+	var empty Server
+	err = FillFromBytes(&empty, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Server{Tags: []string{}, Program: &Program{}}, empty)
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"flag"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   S t r u c t   d e f i n i t i o n   */
+
+// flagValue is the flag.Value implementation binding a command-line flag to a
+// struct field.
+type flagValue struct {
+	field *StructField
+	set   bool // whether the flag was set, so that repeated flags replace default slices
+}
+
+/*   F u n c t i o n s   */
+
+// RegisterFlags defines one command-line flag in the flag set fs per exported
+// field of struct dest. The current field values become the flag default values
+// and parsed flags are stored back into the fields.
+//
+// Flag names are looked up in the flag struct tag and default to the field names
+// in lower case kebab-case style, e.g. "max-count". Flags of nested struct fields
+// are prefixed with the flag name of their nested struct, e.g. "server.port". Their
+// usage message is read from the usage struct tag. Repeated flags are collected
+// into slice fields, replacing their default value.
+//
+// NOTE: nil pointers to nested structs are allocated, so that their fields can
+// be bound to flags.
+func RegisterFlags(fs *flag.FlagSet, dest interface{}) error {
+	ctx := "could not register struct fields as flags"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if !s.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s.Name()), ctx)
+	}
+	if s.Multiple() {
+		return errors.Wrap(errors.Errorf("source is a slice of struct %s", s.Name()), ctx)
+	}
+	s.registerFlags(fs, "")
+	return nil
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// String returns the text representation of the field value.
+func (x *flagValue) String() string {
+	if x == nil || x.field == nil {
+		return ""
+	}
+	return formatStrings(x.field.value, ",")
+}
+
+// Set parses the text value of the flag and stores it into the field. Slice
+// fields are reset the first time the flag is set, then appended one element
+// per repeated flag.
+func (x *flagValue) Set(value string) error {
+	f := x.field
+	t := indirectType(f.value.Type())
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return f.setStrings([]string{value})
+	}
+	return f.mutate(func() error {
+		v := utils.PresetIndirect(f.value)
+		e := reflect.New(t.Elem()).Elem()
+		if err := f.parseString(e, value); err != nil {
+			return err
+		}
+		if !x.set {
+			v.Set(reflect.MakeSlice(t, 0, 1))
+		}
+		v.Set(reflect.Append(v, e))
+		x.set = true
+		return nil
+	})
+}
+
+// IsBoolFlag allows boolean flags to be set without value, e.g. "-enabled".
+func (x *flagValue) IsBoolFlag() bool {
+	return x.field != nil && utils.CanBool(x.field.Indirect())
+}
+
+/*   U n e x p o r t e d   */
+
+// registerFlags defines flags for the struct fields, named after them and
+// prepended with prefix.
+func (s *StructValue) registerFlags(fs *flag.FlagSet, prefix string) {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		name := f.flagName()
+		if name == "" {
+			continue
+		}
+		name = prefix + name
+		if f.canStructType() {
+			utils.PresetIndirect(f.value)
+			f.Struct().registerFlags(fs, name+".")
+			continue
+		}
+		usage, _ := f.Tag("usage")
+		fs.Var(&flagValue{field: f}, name, usage)
+	}
+}
+
+// flagName returns the name of the flag bound to the field, or zero-value string
+// if the field is tagged `flag:"-"`.
+func (f *StructField) flagName() string {
+	if tag, ok := f.Tag("flag"); ok && tag != "" {
+		if tag == "-" {
+			return ""
+		}
+		return tag
+	}
+	return strings.ReplaceAll(utils.CamelCaseToUnderscore(f.Name()), "_", "-")
+}
@@ -0,0 +1,132 @@
+package structs
+
+import (
+	"database/sql"
+	"flag"
+	"io/ioutil"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFlags(t *testing.T) {
+	type Server struct {
+		Host string `usage:"server host name"`
+		Port int    `flag:"port" usage:"server port"`
+	}
+
+	type Options struct {
+		MaxCount int
+		Verbose  bool
+		Timeout  time.Duration
+		Tags     []string `flag:"tag"`
+		Secret   string   `flag:"-"`
+		Server   *Server
+	}
+
+	opts := Options{MaxCount: 5, Timeout: time.Second, Tags: []string{"default"}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	err := RegisterFlags(fs, &opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "5", fs.Lookup("max-count").DefValue)
+	assert.Equal(t, "default", fs.Lookup("tag").DefValue)
+	assert.Equal(t, "server port", fs.Lookup("server.port").Usage)
+	assert.Equal(t, (*flag.Flag)(nil), fs.Lookup("secret"))
+
+	err = fs.Parse([]string{
+		"-max-count", "10",
+		"-verbose",
+		"-timeout", "1m",
+		"-tag", "a",
+		"-tag", "b",
+		"-server.host", "localhost",
+		"-server.port", "8080",
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 10, opts.MaxCount)
+	assert.Equal(t, true, opts.Verbose)
+	assert.Equal(t, time.Minute, opts.Timeout)
+	assert.Equal(t, []string{"a", "b"}, opts.Tags)
+	assert.Equal(t, "localhost", opts.Server.Host)
+	assert.Equal(t, 8080, opts.Server.Port)
+
+	err = fs.Parse([]string{"-max-count", "abc"})
+	assert.NotEqual(t, nil, err)
+
+	err = RegisterFlags(fs, opts)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestRegisterFlagsSlice(t *testing.T) {
+	type Options struct {
+		Tags []string `flag:"tag"`
+	}
+
+	opts := Options{Tags: []string{"default"}}
+	s, err := New(&opts)
+	assert.Equal(t, nil, err)
+	var changes []interface{}
+	s.Track().OnSet(func(f *StructField, old, new interface{}) {
+		changes = append(changes, new)
+	})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	s.registerFlags(fs, "")
+
+	err = fs.Parse([]string{"-tag", "a", "-tag", "b"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"a", "b"}, opts.Tags)
+	assert.Equal(t, []string{"Tags"}, s.Changed())
+	assert.Equal(t, []interface{}{[]string{"a"}, []string{"a", "b"}}, changes)
+
+	s.Freeze()
+	err = fs.Lookup("tag").Value.Set("c")
+	assert.Equal(t, ErrReadOnly, errors.Cause(err))
+	assert.Equal(t, []string{"a", "b"}, opts.Tags)
+}
+
+func TestRegisterFlagsNullable(t *testing.T) {
+	type Options struct {
+		Name  sql.NullString
+		Count sql.NullInt64
+	}
+
+	opts := Options{Count: sql.NullInt64{Int64: 5, Valid: true}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	err := RegisterFlags(fs, &opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", fs.Lookup("name").DefValue)
+	assert.Equal(t, "5", fs.Lookup("count").DefValue)
+
+	err = fs.Parse([]string{"-name", "Roninzo", "-count", "10"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sql.NullString{String: "Roninzo", Valid: true}, opts.Name)
+	assert.Equal(t, sql.NullInt64{Int64: 10, Valid: true}, opts.Count)
+}
+
+func TestRegisterFlagsText(t *testing.T) {
+	type Options struct {
+		Addr  netip.Addr
+		Level testLevel
+	}
+
+	opts := Options{Addr: netip.MustParseAddr("127.0.0.1")}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	err := RegisterFlags(fs, &opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "127.0.0.1", fs.Lookup("addr").DefValue)
+	assert.Equal(t, "info", fs.Lookup("level").DefValue)
+
+	err = fs.Parse([]string{"-addr", "192.168.0.1", "-level", "debug"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Options{Addr: netip.MustParseAddr("192.168.0.1"), Level: 1}, opts)
+
+	err = fs.Parse([]string{"-level", "trace"})
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+/*   F u n c t i o n s   */
+
+// CloneT returns a deep copy of the struct, or slice of structs, src, like
+// Clone, but typed, so that callers avoid the clone.(*Server) type assertion.
+// T may be a pointer itself, e.g. CloneT(&p) with p of type *Server.
+func CloneT[T any](src *T) (*T, error) {
+	if src == nil {
+		return nil, errors.Wrap(ErrNoStruct, "could not clone nil struct")
+	}
+	if v := reflect.ValueOf(*src); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, errors.Wrap(ErrNoStruct, "could not clone nil struct")
+	}
+	clone, err := cloneAny(src)
+	if err != nil {
+		return nil, err
+	}
+	switch c := clone.(type) {
+	case *T:
+		return c, nil
+	case T: // T is a pointer to a struct, or slice of structs.
+		return &c, nil
+	}
+	return nil, errors.Errorf("could not clone %T as %T", src, clone)
+}
+
+// CopyT deep copies the struct, or slice of structs, src into dst, like Copy,
+// but typed, so that copying between different types fails at compile-time.
+func CopyT[T any](dst, src *T) error {
+	ctx := "could not copy struct"
+	if dst == nil || src == nil {
+		return errors.Wrap(ErrNoStruct, ctx)
+	}
+	if _, err := New(src); err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	return errors.Wrap(Copy(dst, src), ctx)
+}
@@ -1,11 +1,15 @@
 module github.com/roninzo/structs
 
-go 1.16
+go 1.18
 
 require (
-	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/jinzhu/copier v0.3.4 // indirect
+	github.com/jinzhu/copier v0.3.4
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
@@ -7,7 +7,11 @@ package structs
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
+	"sync"
 
 	"github.com/jinzhu/copier"
 	"github.com/pkg/errors"
@@ -32,32 +36,18 @@ import (
 // 	return s
 // }
 
-// Sprint returns a MarshalIndent string.
-//
-// BUG(roninzo): Sprint uses json marshaling which does not support complex
-// types (complex64/complex128).
+// Sprint returns a MarshalIndent string. Values that json marshaling does not
+// support, such as complex numbers, channels, functions or NaN, do not fail
+// it: they are rendered as strings, with struct fields in declared order and
+// map keys sorted, so that the output stays deterministic.
 func Sprint(dest interface{}) string {
-	// s := fmt.Sprintf("%#v", t)
-	// m := make(map[string]interface{}) // convert dest to m first?
-	// http://choly.ca/post/go-json-marshalling/
-	// https://www.py4u.net/discuss/1206302
-	// https://play.golang.org/p/MuW6gwSAKi
-	// https://attilaolah.eu/2013/11/29/json-decoding-in-go/
-	// https://mariadesouza.com/2017/09/07/custom-unmarshal-json-in-golang/
-	j, err := json.MarshalIndent(dest, " ", "\t")
-	if err != nil {
-		return err.Error()
-	}
-	return string(j)
+	return sprint(dest, true)
 }
 
-// Sprint returns a Marshal one-line string (without indenting).
+// SprintCompact returns a Marshal one-line string (without indenting), see
+// Sprint.
 func SprintCompact(dest interface{}) string {
-	j, err := json.Marshal(dest)
-	if err != nil {
-		return err.Error()
-	}
-	return string(j)
+	return sprint(dest, false)
 }
 
 // Name returns the structs's type name within its package. It returns an
@@ -73,12 +63,23 @@ func Name(dest interface{}) (string, error) {
 
 // Names returns a slice of field names. For more info refer to StructValue
 // types Names() method. It returns an error if s's kind is not struct.
-func Names(dest interface{}) ([]string, error) {
+func Names(dest interface{}, opts ...ColumnOption) ([]string, error) {
 	s, err := New(dest)
 	if err != nil {
 		return nil, err
 	}
-	return s.Fields().Names(), nil
+	return s.Names(opts...), nil
+}
+
+// HasField returns true if struct dest has a field called name. For more info
+// refer to StructValue type Has() method.
+// HasField returns false if dest is not a struct.
+func HasField(dest interface{}, name string) bool {
+	s, err := New(dest)
+	if err != nil {
+		return false
+	}
+	return s.Has(name)
 }
 
 // Fields returns a slice of *StructField. For more info refer to StructValue
@@ -185,6 +186,24 @@ func Clone(src interface{}) (interface{}, error) {
 	return dest, nil
 }
 
+// cloneAny returns a deep copy of the struct or slice of structs src. Contrary
+// to Clone, a slice of structs is copied as a whole, as a pointer to a slice.
+func cloneAny(src interface{}) (interface{}, error) {
+	s, err := New(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not clone struct")
+	}
+	if !s.Multiple() {
+		return Clone(src)
+	}
+	dest := reflect.New(s.rows.Type()).Interface()
+	err = copier.CopyWithOption(dest, src, copier.Option{DeepCopy: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not clone structs")
+	}
+	return dest, nil
+}
+
 // Transpose loops through target fields and set value of its related
 // source field.
 func Transpose(dest, src interface{}) error {
@@ -305,15 +324,31 @@ func Diff(dest, src interface{}) (map[string]interface{}, error) {
 	return s2.Diff(s1)
 }
 
+// ToMap returns the fields of struct dest indexed by their names in documents,
+// honoring json tags, else bson tags. For more info refer to StructValue types
+// ToMap() method. It returns an error if dest is not a singular struct.
+func ToMap(dest interface{}) (map[string]interface{}, error) {
+	ctx := "could not convert struct to map"
+	s, err := New(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	if s.Multiple() {
+		return nil, errors.Wrap(errors.Errorf("source is a slice of struct %s", s.Name()), ctx)
+	}
+	return s.ToMap(), nil
+}
+
 // Replace returns a copy of the struct dest with the first n non-overlapping
-// instance of old replaced by new.
+// instance of old replaced by new. When dest is a slice of structs, every row
+// is walked, in order, and a pointer to a copy of the slice is returned.
 //
 // Counts how many replacing to do until n. if n = -1, then replace all.
 func Replace(dest, old, new interface{}, n int) (interface{}, error) {
 	// ctx will be the context error returned
 	// by this func if anything goes wrong
 	ctx := ErrNotReplaced.Error()
-	src, err := Clone(dest)
+	src, err := cloneAny(dest)
 	if err != nil {
 		return nil, errors.Wrap(err, ctx)
 	}
@@ -321,39 +356,138 @@ func Replace(dest, old, new interface{}, n int) (interface{}, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, ctx)
 	}
-	c := 0
 	v := reflect.ValueOf(old)
-	for {
-		if n != ReplaceAll {
-			if c >= n {
-				break
-			}
-		}
-		if i := s.contains(v); i != OutOfRange {
-			f := s.Field(i)
-			if f == nil {
-				return src, errors.New(ctx)
-			}
-			if err := f.Set(new); err != nil {
-				return src, errors.Wrap(err, ctx)
-			}
-		} else {
-			break
-		}
-		c++
+	err = replaceRows(s, n, func(s *StructValue, n int) (int, error) {
+		return s.replace(v, new, n)
+	})
+	if err != nil {
+		return src, errors.Wrap(err, ctx)
 	}
 	return src, nil
 }
 
-// MapFunc returns a copy of the StructValue s with all its fields modified
-// according to the mapping function handler.
+// ReplaceFunc returns a copy of the struct dest with the first n fields for
+// which match returns true set to new, e.g. to target fields by name, struct tag
+// or current value pattern rather than by exact value. Nested structs are
+// searched recursively, in declared order of fields. When dest is a slice of
+// structs, every row is walked, in order, and a pointer to a copy of the slice
+// is returned.
 //
-//    If mapping returns a negative value, the character is
-//    dropped from the byte slice with no replacement. The characters in s and the
-//    output are interpreted as UTF-8-encoded code points.
+// Counts how many replacing to do until n. if n = -1, then replace all.
+func ReplaceFunc(dest interface{}, match func(f *StructField) bool, new interface{}, n int) (interface{}, error) {
+	// ctx will be the context error returned
+	// by this func if anything goes wrong
+	ctx := ErrNotReplaced.Error()
+	src, err := cloneAny(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	s, err := New(src)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	err = replaceRows(s, n, func(s *StructValue, n int) (int, error) {
+		return s.replaceFunc(func(f *StructField) (interface{}, bool) {
+			return new, match(f)
+		}, n)
+	})
+	if err != nil {
+		return src, errors.Wrap(err, ctx)
+	}
+	return src, nil
+}
+
+// ReplaceRegexp returns a copy of the struct dest with matches of the regular
+// expression pattern replaced by replacement in the first n string fields it
+// matches, e.g. to scrub tokens or normalize formats. Nested structs are
+// searched recursively, in declared order of fields. When dest is a slice of
+// structs, every row is walked, in order, and a pointer to a copy of the slice
+// is returned. Inside replacement, $ signs are interpreted as in
+// regexp.Regexp.ReplaceAllString.
 //
-// BUG(roninzo): the MapFunc method argument dest is also changed. should
-// that be the case?
+// Counts how many fields to replace until n. if n = -1, then replace all.
+func ReplaceRegexp(dest interface{}, pattern, replacement string, n int) (interface{}, error) {
+	// ctx will be the context error returned
+	// by this func if anything goes wrong
+	ctx := "could not replace regexp in struct"
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	src, err := cloneAny(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	s, err := New(src)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	err = replaceRows(s, n, func(s *StructValue, n int) (int, error) {
+		return s.replaceFunc(func(f *StructField) (interface{}, bool) {
+			if f.value.Kind() != reflect.String || !re.MatchString(f.value.String()) {
+				return nil, false
+			}
+			return re.ReplaceAllString(f.value.String(), replacement), true
+		}, n)
+	})
+	if err != nil {
+		return src, errors.Wrap(err, ctx)
+	}
+	return src, nil
+}
+
+// replaceRows calls replace on the struct s, or on each of its rows in order if
+// s is a slice of structs, with the number of replacements left to do out of n.
+// replace returns how many replacements it did.
+func replaceRows(s *StructValue, n int, replace func(s *StructValue, n int) (int, error)) error {
+	if !s.Multiple() {
+		_, err := replace(s, n)
+		return err
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		return nil // nothing to replace in empty slice of structs
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if n != ReplaceAll && n <= 0 {
+			break
+		}
+		c, err := replace(&rows.StructValue, n)
+		if err != nil {
+			return err
+		}
+		if n != ReplaceAll {
+			n -= c
+		}
+	}
+	return rows.Err()
+}
+
+// GroupBy returns the indexes of the structs of the slice dest grouped by the
+// value of their field name, e.g. GroupBy(&servers, "Enabled"). For more info
+// refer to StructRows type GroupBy() method.
+// GroupBy returns an error if dest is not a slice of structs.
+func GroupBy(dest interface{}, name string) (map[interface{}][]int, error) {
+	s, err := New(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not group structs")
+	}
+	rows, err := s.Rows()
+	if err != nil {
+		if err == ErrNoRows {
+			return map[interface{}][]int{}, nil
+		}
+		return nil, errors.Wrap(err, "could not group structs")
+	}
+	defer rows.Close()
+	return rows.GroupBy(name)
+}
+
+// MapFunc returns a deep copy of the struct dest with all its fields modified
+// according to the mapping function handler, leaving dest untouched, including
+// the structs, slices and maps it points to. See MapFuncInPlace for mapping dest
+// itself, without the cost of the copy.
 func MapFunc(dest interface{}, handler func(reflect.Value) error) (interface{}, error) {
 	// ctx will be the context error returned
 	// by this func if anything goes wrong
@@ -372,44 +506,206 @@ func MapFunc(dest interface{}, handler func(reflect.Value) error) (interface{},
 	return clone, nil
 }
 
+// MapFuncInPlace modifies all the fields of the struct dest according to the
+// mapping function handler. Contrary to MapFunc, no copy is made, so dest must be
+// a pointer to a struct.
+func MapFuncInPlace(dest interface{}, handler func(reflect.Value) error) error {
+	ctx := "could not map struct with func in place"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if !s.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s.Name()), ctx)
+	}
+	if _, err := s.MapFunc(handler); err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	return nil
+}
+
+// MapFuncParallel maps every struct of the slice of structs dest with func
+// handler, like the MapFunc method, on n goroutines, e.g. for large-scale
+// normalization or anonymization. Each row is mapped by its own StructValue,
+// so that no state is shared between goroutines; handler itself must be safe
+// for concurrent use. Contrary to MapFunc, dest is mapped in place and must
+// therefore be a slice of structs, or a pointer to one. If handler fails on
+// several rows, the error of the first one is returned.
+func MapFuncParallel(dest interface{}, n int, handler func(reflect.Value) error) error {
+	ctx := "could not map structs with func in parallel"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if !s.Multiple() {
+		return errors.Wrap(ErrNoStructs, ctx)
+	}
+	if n < 1 {
+		n = 1
+	}
+	rows := s.rows
+	errs := make([]error, rows.Len())
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				row := IndirectStruct(rows.Index(i))
+				if _, err := row.MapFunc(handler); err != nil {
+					errs[i] = err
+				}
+			}
+		}()
+	}
+	for i := 0; i < rows.Len(); i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "%s: row %d", ctx, i)
+		}
+	}
+	return nil
+}
+
 // ScanFromMap trusted source maps of string to interface{} row into Go struct dest.
 //
 // Optionally, a mapping argument can be provided if the column names are different between
 // dest and row. That argument is a key-value pair of strings where key is the column name
 // in dest and value the column name in row.
+//
+// Columns are scanned in increasing order of their names in dest. Scanning stops
+// at the first column that cannot be scanned, whose error is returned, so that
+// the following columns are left untouched.
 func ScanFromMap(dest interface{}, row map[string]interface{}, mapping map[string]string) error {
 	s, err := New(dest)
 	if err != nil {
 		return err
 	}
+	if errs := scanFromMap(s, row, mapping, true); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ScanFromMaps is the slice counterpart of ScanFromMap: it replaces the content
+// of the slice of structs pointed to by dest with one newly allocated struct
+// per map of rows, e.g. ScanFromMaps(&servers, rows, nil). For more info about
+// the mapping argument, refer to ScanFromMap.
+// Scanning goes on when a column cannot be scanned, in which case ScanFromMaps
+// returns RowErrors, listing one RowError per row and column in error.
+func ScanFromMaps(dest interface{}, rows []map[string]interface{}, mapping map[string]string) error {
+	d := reflect.ValueOf(dest)
+	if d.Kind() != reflect.Ptr || d.IsNil() || d.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("could not scan maps into %T: want a pointer to a slice of structs", dest)
+	}
+	t := d.Elem().Type().Elem()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return errors.Wrapf(ErrNoStructs, "could not scan maps into %T", dest)
+	}
+	values := reflect.MakeSlice(d.Elem().Type(), len(rows), len(rows))
+	errs := make(RowErrors, 0)
+	for i, row := range rows {
+		v := reflect.New(t)
+		s, err := New(v.Interface())
+		if err != nil {
+			return errors.Wrapf(err, "could not scan map into row %d", i)
+		}
+		for _, err := range scanFromMap(s, row, mapping, false) {
+			errs = append(errs, &RowError{Row: i, Err: err})
+		}
+		if isPtr {
+			values.Index(i).Set(v)
+		} else {
+			values.Index(i).Set(v.Elem())
+		}
+	}
+	d.Elem().Set(values)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ScanFromValues scans url values, such as HTTP form values or query string
+// parameters, into Go struct dest. Texts are parsed according to the kind of
+// the fields they are assigned to, and slice fields are set from all the values
+// of repeated keys.
+//
+// Keys are looked up in the struct tag key tag, e.g. "form", and default to the
+// field names. Nested struct fields are scanned from dot separated keys, such as
+// "program.name".
+func ScanFromValues(dest interface{}, values url.Values, tag string) error {
+	s, err := New(dest)
+	if err != nil {
+		return err
+	}
+	if !s.CanSet() {
+		return errors.Errorf("cannot edit struct %s", s.Name())
+	}
+	return s.scanFromValues(values, tag, "")
+}
+
+// scanFromMap scans row into s, as ScanFromMap does, and returns the errors of
+// all the columns that could not be scanned, ordered by column name, or only
+// the first one if stop is true, in which case scanning stops there.
+func scanFromMap(s *StructValue, row map[string]interface{}, mapping map[string]string, stop bool) []error {
+	errs := make([]error, 0)
 	if mapping != nil {
-		for destCol, srcCol := range mapping {
+		for _, destCol := range sortedKeys(mapping) {
+			if stop && len(errs) > 0 {
+				break
+			}
+			srcCol := mapping[destCol]
 			srcValue, ok := row[srcCol]
 			if !ok {
-				return errors.Errorf("could not find column %q in trusted source instance", srcCol)
+				errs = append(errs, errors.Errorf("could not find column %q in trusted source instance", srcCol))
+				continue
 			}
 			f := s.Field(destCol)
-			if err = s.Err(); err != nil {
-				return errors.Wrapf(err, "could not find column %q in %s", destCol, s.Name())
+			if err := s.Err(); err != nil {
+				errs = append(errs, errors.Wrapf(err, "could not find column %q in %s", destCol, s.Name()))
+				continue
 			}
-			err = f.Set(srcValue)
-			if err != nil {
-				return errors.Wrapf(err, "could not set column %q in %s", destCol, s.Name())
+			if err := f.Set(srcValue); err != nil {
+				errs = append(errs, errors.Wrapf(err, "could not set column %q in %s", destCol, s.Name()))
 			}
 		}
-	} else {
-		for srcCol, srcValue := range row {
-			f := s.Field(srcCol)
-			if err = s.Err(); err != nil {
-				return errors.Wrapf(err, "could not find column %q in %s", srcCol, s.Name())
-			}
-			err = f.Set(srcValue)
-			if err != nil {
-				return errors.Wrapf(err, "could not set column %q in %s to %v", srcCol, s.Name(), srcValue)
-			}
+		return errs
+	}
+	for _, srcCol := range sortedKeys(row) {
+		if stop && len(errs) > 0 {
+			break
+		}
+		srcValue := row[srcCol]
+		f := s.Field(srcCol)
+		if err := s.Err(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not find column %q in %s", srcCol, s.Name()))
+			continue
+		}
+		if err := f.Set(srcValue); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not set column %q in %s to %v", srcCol, s.Name(), srcValue))
 		}
 	}
-	return nil
+	return errs
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Unmarshal parses the Go struct and stores the result
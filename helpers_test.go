@@ -1,9 +1,15 @@
 package structs
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +131,53 @@ func TestHelperClone(t *testing.T) {
 	}
 }
 
+func TestHelperCloneT(t *testing.T) {
+	type testNested struct {
+		Tags []string
+	}
+	type testStruct struct {
+		Username string
+		Nested   *testNested
+	}
+
+	ts := testStruct{Username: "Roninzo", Nested: &testNested{Tags: []string{"a"}}}
+	clone, err := CloneT(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ts, *clone)
+	clone.Nested.Tags[0] = "b"
+	assert.Equal(t, "a", ts.Nested.Tags[0])
+
+	rows := []testStruct{ts}
+	clones, err := CloneT(&rows)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rows, *clones)
+
+	p := &ts
+	pclone, err := CloneT(&p)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ts, **pclone)
+	assert.Equal(t, false, p == *pclone)
+
+	var np *testStruct
+	_, err = CloneT(&np)
+	assert.NotEqual(t, nil, err)
+
+	_, err = CloneT[testStruct](nil)
+	assert.NotEqual(t, nil, err)
+
+	var dst testStruct
+	err = CopyT(&dst, &ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ts, dst)
+	assert.Equal(t, false, ts.Nested == dst.Nested)
+
+	err = CopyT(nil, &ts)
+	assert.NotEqual(t, nil, err)
+	n := 1
+	err = CopyT(&n, &n)
+	assert.NotEqual(t, nil, err)
+}
+
 func TestHelperForward(t *testing.T) {
 	type T1 struct {
 		A string
@@ -253,6 +306,104 @@ func TestHelperCompare(t *testing.T) {
 	assert.Equal(t, true, Compare(testStructA, testStructB))
 }
 
+func TestHelperToMap(t *testing.T) {
+	type testBase struct {
+		Created string `bson:"created_at"`
+	}
+	type testNested struct {
+		Name string `bson:"name"`
+	}
+	type testStruct struct {
+		ID       string     `bson:"_id,omitempty"`
+		Username string     `json:"username" bson:"user"`
+		Password string     `bson:"-"`
+		Count    int        `bson:"count,omitempty"`
+		Base     testBase   `bson:",inline"`
+		Nested   testNested `bson:"nested"`
+		Flags    int        `bson:",omitempty"`
+		Size     int        `bson:"size,minsize"`
+		Note     string
+	}
+
+	ts := testStruct{
+		ID:       "60d5ec",
+		Username: "Roninzo",
+		Password: "s3cr3t",
+		Base:     testBase{Created: "2021-06-25"},
+		Nested:   testNested{Name: "Apache"},
+		Flags:    3,
+		Size:     7,
+		Note:     "kept",
+	}
+
+	m, err := ToMap(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"_id":        "60d5ec",
+		"username":   "Roninzo",
+		"created_at": "2021-06-25",
+		"nested":     map[string]interface{}{"name": "Apache"},
+		"Flags":      3,
+		"size":       7,
+		"Note":       "kept",
+	}, m)
+
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "_id", s.Field("ID").NameJson())
+	assert.Equal(t, "username", s.Field("Username").NameJson())
+	assert.Equal(t, true, s.Field("Password").IsHidden())
+	assert.Equal(t, true, s.Field("Count").IsHidden())
+
+	_, err = ToMap([]testStruct{ts})
+	assert.NotEqual(t, nil, err)
+}
+
+type testPrice struct {
+	Cents    int64
+	Currency string
+}
+
+func (m testPrice) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"amount":%d.%02d,"currency":%q}`, m.Cents/100, m.Cents%100, m.Currency)), nil
+}
+
+type testColor int
+
+func (c *testColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{"red", "green", "blue"}[*c])
+}
+
+func TestHelperToMapMarshalJSON(t *testing.T) {
+	type testStruct struct {
+		Price   testPrice  `json:"price" bson:"price"`
+		Color   testColor  `json:"color" bson:"color"`
+		Refund  *testPrice `json:"refund" bson:"refund"`
+		Created time.Time  `json:"created" bson:"created"`
+	}
+
+	created := time.Date(2021, 6, 25, 0, 0, 0, 0, time.UTC)
+	ts := testStruct{
+		Price:   testPrice{Cents: 1250, Currency: "EUR"},
+		Color:   2,
+		Created: created,
+	}
+	want := map[string]interface{}{
+		"price":   map[string]interface{}{"amount": json.Number("12.50"), "currency": "EUR"},
+		"color":   "blue",
+		"refund":  (*testPrice)(nil),
+		"created": created,
+	}
+
+	m, err := ToMap(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, m)
+
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, s.ToMap())
+}
+
 func TestHelperReplace(t *testing.T) {
 	type testStruct struct {
 		TestInt     int
@@ -317,6 +468,106 @@ func TestHelperReplace(t *testing.T) {
 	assert.Equal(t, float32(42.444), value.(*testStruct).TestFloat32)
 }
 
+func TestHelperReplaceRows(t *testing.T) {
+	type testStruct struct {
+		Username string
+		Status   string
+		Backup   string
+	}
+	ts := []testStruct{
+		{Username: "Roninzo", Status: "draft", Backup: "draft"},
+		{Username: "Apache", Status: "published", Backup: "draft"},
+		{Username: "Nginx", Status: "draft", Backup: "none"},
+	}
+
+	res, err := Replace(ts, "draft", "pending", ReplaceAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Status: "pending", Backup: "pending"},
+		{Username: "Apache", Status: "published", Backup: "pending"},
+		{Username: "Nginx", Status: "pending", Backup: "none"},
+	}, *res.(*[]testStruct))
+	assert.Equal(t, "draft", ts[0].Status)
+
+	res, err = Replace(&ts, "draft", "pending", 3)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Status: "pending", Backup: "pending"},
+		{Username: "Apache", Status: "published", Backup: "pending"},
+		{Username: "Nginx", Status: "draft", Backup: "none"},
+	}, *res.(*[]testStruct))
+}
+
+func TestHelperReplaceFunc(t *testing.T) {
+	type testNested struct {
+		Token string `scrub:"true"`
+		Title string
+	}
+	type testStruct struct {
+		Username string
+		Password string `scrub:"true"`
+		Nested   testNested
+		Count    int
+	}
+	ts := testStruct{
+		Username: "Roninzo",
+		Password: "abcdefg",
+		Nested:   testNested{Token: "xyz", Title: "Test title"},
+		Count:    5,
+	}
+	scrub := func(f *StructField) bool {
+		tag, _ := f.Tag("scrub")
+		return tag == "true"
+	}
+
+	res, err := ReplaceFunc(&ts, scrub, "***", ReplaceAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "***", res.(*testStruct).Password)
+	assert.Equal(t, "***", res.(*testStruct).Nested.Token)
+	assert.Equal(t, "abcdefg", ts.Password)
+
+	res, err = ReplaceFunc(&ts, scrub, "***", 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "***", res.(*testStruct).Password)
+	assert.Equal(t, "xyz", res.(*testStruct).Nested.Token)
+
+	res, err = ReplaceFunc(&ts, func(f *StructField) bool { return f.Name() == "Count" }, "many", ReplaceAll)
+	assert.Contains(t, err.Error(), ErrNotReplaced.Error())
+}
+
+func TestHelperReplaceFuncRows(t *testing.T) {
+	type testStruct struct {
+		Username string
+		Password string `scrub:"true"`
+	}
+	ts := []testStruct{
+		{Username: "Roninzo", Password: "abc"},
+		{Username: "Apache", Password: "def"},
+		{Username: "Nginx", Password: "ghi"},
+	}
+	scrub := func(f *StructField) bool {
+		tag, _ := f.Tag("scrub")
+		return tag == "true"
+	}
+
+	res, err := ReplaceFunc(ts, scrub, "***", ReplaceAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Password: "***"},
+		{Username: "Apache", Password: "***"},
+		{Username: "Nginx", Password: "***"},
+	}, *res.(*[]testStruct))
+	assert.Equal(t, "abc", ts[0].Password)
+
+	res, err = ReplaceFunc(&ts, scrub, "***", 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Password: "***"},
+		{Username: "Apache", Password: "***"},
+		{Username: "Nginx", Password: "ghi"},
+	}, *res.(*[]testStruct))
+}
+
 func TestHelperMapFunc(t *testing.T) {
 	type testStruct struct {
 		Username string
@@ -345,6 +596,261 @@ func TestHelperMapFunc(t *testing.T) {
 	assert.Contains(t, err.Error(), testErr.Error())
 }
 
+func TestHelperReplaceRegexp(t *testing.T) {
+	type testNested struct {
+		Token string
+	}
+	type testStruct struct {
+		Username string
+		Phone    string
+		Count    int
+		Nested   testNested
+	}
+	ts := testStruct{
+		Username: "Roninzo",
+		Phone:    "555-123-4567",
+		Count:    555,
+		Nested:   testNested{Token: "token=555abc"},
+	}
+
+	res, err := ReplaceRegexp(&ts, `\d`, "*", ReplaceAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testStruct{
+		Username: "Roninzo",
+		Phone:    "***-***-****",
+		Count:    555,
+		Nested:   testNested{Token: "token=***abc"},
+	}, *res.(*testStruct))
+	assert.Equal(t, "555-123-4567", ts.Phone)
+
+	res, err = ReplaceRegexp(&ts, `(\d{3})-(\d{3})-(\d{4})`, "($1) $2-$3", 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "(555) 123-4567", res.(*testStruct).Phone)
+	assert.Equal(t, "token=555abc", res.(*testStruct).Nested.Token)
+
+	_, err = ReplaceRegexp(&ts, `(`, "", ReplaceAll)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestHelperReplaceRegexpRows(t *testing.T) {
+	type testStruct struct {
+		Username string
+		Phone    string
+	}
+	ts := []testStruct{
+		{Username: "Roninzo", Phone: "555-1234"},
+		{Username: "Apache", Phone: "none"},
+		{Username: "Nginx", Phone: "555-5678"},
+	}
+
+	res, err := ReplaceRegexp(ts, `\d`, "*", ReplaceAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Phone: "***-****"},
+		{Username: "Apache", Phone: "none"},
+		{Username: "Nginx", Phone: "***-****"},
+	}, *res.(*[]testStruct))
+	assert.Equal(t, "555-1234", ts[0].Phone)
+
+	res, err = ReplaceRegexp(&ts, `^555-`, "", 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{
+		{Username: "Roninzo", Phone: "1234"},
+		{Username: "Apache", Phone: "none"},
+		{Username: "Nginx", Phone: "555-5678"},
+	}, *res.(*[]testStruct))
+}
+
+func TestHelperMapFuncInPlace(t *testing.T) {
+	type testNested struct {
+		Title string
+	}
+	type testStruct struct {
+		Username string
+		Tags     []string
+		Nested   *testNested
+		Labels   map[string]string
+	}
+	ts := testStruct{
+		Username: "Roninzo",
+		Tags:     []string{"Test"},
+		Nested:   &testNested{Title: "Test title"},
+		Labels:   map[string]string{"env": "Prod"},
+	}
+	lower := func(v reflect.Value) error {
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(strings.ToLower(v.String()))
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				v.Index(i).SetString(strings.ToLower(v.Index(i).String()))
+			}
+		case reflect.Map:
+			for _, k := range v.MapKeys() {
+				v.SetMapIndex(k, reflect.ValueOf(strings.ToLower(v.MapIndex(k).String())))
+			}
+		}
+		return nil
+	}
+
+	res, err := MapFunc(&ts, lower)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testStruct{
+		Username: "roninzo",
+		Tags:     []string{"test"},
+		Nested:   &testNested{Title: "test title"},
+		Labels:   map[string]string{"env": "prod"},
+	}, *res.(*testStruct))
+	assert.Equal(t, testStruct{
+		Username: "Roninzo",
+		Tags:     []string{"Test"},
+		Nested:   &testNested{Title: "Test title"},
+		Labels:   map[string]string{"env": "Prod"},
+	}, ts)
+
+	err = MapFuncInPlace(&ts, lower)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, *res.(*testStruct), ts)
+
+	err = MapFuncInPlace(ts, lower)
+	assert.EqualError(t, err, "could not map struct with func in place: cannot edit struct testStruct")
+}
+
+func TestHelperMapFuncParallel(t *testing.T) {
+	type testStruct struct {
+		ID       int
+		Username string
+	}
+	ts := make([]*testStruct, 100)
+	for i := range ts {
+		ts[i] = &testStruct{ID: i, Username: fmt.Sprintf("User%d", i)}
+	}
+
+	err := MapFuncParallel(ts, 4, func(v reflect.Value) error {
+		if v.Type().Kind() == reflect.String {
+			v.SetString(strings.ToLower(v.String()))
+		}
+		return nil
+	})
+	assert.Equal(t, nil, err)
+	for i, row := range ts {
+		assert.Equal(t, fmt.Sprintf("user%d", i), row.Username)
+	}
+
+	err = MapFuncParallel(&ts, 3, func(v reflect.Value) error {
+		if v.Kind() == reflect.Int && v.Int() >= 42 {
+			return errors.New("Test")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "could not map structs with func in parallel: row 42: Test")
+
+	err = MapFuncParallel(ts[0], 2, func(v reflect.Value) error { return nil })
+	assert.EqualError(t, err, "could not map structs with func in parallel: structs not found")
+}
+
+func TestHelperScanFromValues(t *testing.T) {
+	type Program struct {
+		Name string `form:"name"`
+	}
+
+	type Server struct {
+		Name    string        `form:"name"`
+		ID      uint          `form:"id"`
+		Enabled bool          `form:"enabled"`
+		Ratio   float64       `form:"ratio"`
+		Timeout time.Duration `form:"timeout"`
+		Tags    []string      `form:"tags"`
+		Ports   []int         `form:"ports"`
+		Secret  string        `form:"-"`
+		Program *Program      `form:"program"`
+	}
+
+	values := url.Values{
+		"name":         {"Roninzo"},
+		"id":           {"123456"},
+		"enabled":      {"true"},
+		"ratio":        {"0.5"},
+		"timeout":      {"5s"},
+		"tags":         {"a", "b"},
+		"ports":        {"80", "443"},
+		"Secret":       {"abcdefg"},
+		"program.name": {"Apache"},
+	}
+
+	var server Server
+	err := ScanFromValues(&server, values, "form")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Roninzo", server.Name)
+	assert.Equal(t, uint(123456), server.ID)
+	assert.Equal(t, true, server.Enabled)
+	assert.Equal(t, 0.5, server.Ratio)
+	assert.Equal(t, 5*time.Second, server.Timeout)
+	assert.Equal(t, []string{"a", "b"}, server.Tags)
+	assert.Equal(t, []int{80, 443}, server.Ports)
+	assert.Equal(t, "", server.Secret)
+	assert.Equal(t, "Apache", server.Program.Name)
+
+	err = ScanFromValues(&server, url.Values{"id": {"abc"}}, "form")
+	assert.NotEqual(t, nil, err)
+
+	err = ScanFromValues(server, values, "form")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestHelperScanFromValuesNullable(t *testing.T) {
+	type Row struct {
+		Name  sql.NullString  `form:"name"`
+		Count sql.NullInt64   `form:"count"`
+		Ratio sql.NullFloat64 `form:"ratio"`
+	}
+
+	var row Row
+	err := ScanFromValues(&row, url.Values{"name": {"Roninzo"}, "count": {"42"}}, "form")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sql.NullString{String: "Roninzo", Valid: true}, row.Name)
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, row.Count)
+	assert.Equal(t, sql.NullFloat64{}, row.Ratio)
+
+	s, err := New(&row)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, url.Values{"name": {"Roninzo"}, "count": {"42"}}, s.ToValues("form"))
+	assert.Equal(t, map[string]string{"Name": "Roninzo", "Count": "42", "Ratio": ""}, s.ToStringMap())
+
+	err = ScanFromValues(&row, url.Values{"count": {""}}, "form")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sql.NullInt64{}, row.Count)
+}
+
+func TestHelperScanFromValuesText(t *testing.T) {
+	type Host struct {
+		Addr  netip.Addr   `form:"addr"`
+		Level testLevel    `form:"level"`
+		Peers []netip.Addr `form:"peers"`
+	}
+
+	values := url.Values{
+		"addr":  {"192.168.0.1"},
+		"level": {"debug"},
+		"peers": {"10.0.0.1", "10.0.0.2"},
+	}
+
+	var host Host
+	err := ScanFromValues(&host, values, "form")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, netip.MustParseAddr("192.168.0.1"), host.Addr)
+	assert.Equal(t, testLevel(1), host.Level)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}, host.Peers)
+
+	s, err := New(&host)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, values, s.ToValues("form"))
+	assert.Equal(t, "192.168.0.1", s.ToStringMap()["Addr"])
+
+	err = ScanFromValues(&host, url.Values{"addr": {"localhost"}}, "form")
+	assert.NotEqual(t, nil, err)
+}
+
 /*   B e n c h m a r k s   */
 
 func BenchmarkCompareEqual(b *testing.B) {
@@ -449,3 +955,134 @@ func BenchmarkMapFunc(b *testing.B) {
 		})
 	}
 }
+
+func TestHelperGroupBy(t *testing.T) {
+	type testStruct struct {
+		Name    string
+		Enabled bool
+		Zone    *string
+		Tags    []string
+	}
+	eu := "eu"
+	ts := []*testStruct{
+		{Name: "Apache", Enabled: true, Zone: &eu},
+		{Name: "Nginx"},
+		{Name: "Caddy", Enabled: true},
+	}
+
+	groups, err := GroupBy(&ts, "Enabled")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[interface{}][]int{true: {0, 2}, false: {1}}, groups)
+
+	groups, err = GroupBy(ts, "Zone")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[interface{}][]int{"eu": {0}, nil: {1, 2}}, groups)
+
+	_, err = GroupBy(&ts, "Tags")
+	assert.NotEqual(t, nil, err)
+
+	_, err = GroupBy(&ts, "Missing")
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+
+	_, err = GroupBy(ts[0], "Enabled")
+	assert.Equal(t, ErrNoStructs, errors.Cause(err))
+
+	groups, err = GroupBy(&[]testStruct{}, "Enabled")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(groups))
+
+	type testRegion struct {
+		Name   string
+		region string
+	}
+	regions := []testRegion{{Name: "a", region: "eu"}, {Name: "b", region: "us"}}
+	_, err = GroupBy(&regions, "region")
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+
+	AllowUnexported(UnexportedRead)
+	defer AllowUnexported(UnexportedNone)
+	groups, err = GroupBy(&regions, "region")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[interface{}][]int{"eu": {0}, "us": {1}}, groups)
+}
+
+func TestHelperScanFromMap(t *testing.T) {
+	type testStruct struct {
+		Count   int
+		Enabled bool
+		Name    string
+	}
+
+	ts := testStruct{Name: "Apache"}
+	err := ScanFromMap(&ts, map[string]interface{}{"Count": 5, "Enabled": "maybe", "Name": "Nginx"}, nil)
+	assert.Contains(t, err.Error(), "could not set column \"Enabled\"")
+	assert.Equal(t, testStruct{Count: 5, Name: "Apache"}, ts)
+
+	ts = testStruct{Name: "Apache"}
+	err = ScanFromMap(&ts, map[string]interface{}{"count": "many", "name": "Nginx"}, map[string]string{"Count": "count", "Name": "name"})
+	assert.Contains(t, err.Error(), "could not set column \"Count\"")
+	assert.Equal(t, testStruct{Name: "Apache"}, ts)
+
+	err = ScanFromMap(&ts, map[string]interface{}{"Count": 7, "Name": "Nginx"}, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testStruct{Count: 7, Name: "Nginx"}, ts)
+}
+
+func TestHelperScanFromMaps(t *testing.T) {
+	type testStruct struct {
+		Name    string
+		Count   int
+		Enabled bool
+	}
+	rows := []map[string]interface{}{
+		{"Name": "Apache", "Count": 5},
+		{"Name": "Nginx", "Count": "many", "Missing": true},
+		{"Name": "Caddy", "Enabled": true},
+	}
+
+	var ts []testStruct
+	err := ScanFromMaps(&ts, rows, nil)
+	errs, ok := err.(RowErrors)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, []int{1}, errs.Rows())
+	assert.Contains(t, errs[1].Error(), "row 1: could not find column \"Missing\"")
+	assert.Equal(t, []testStruct{
+		{Name: "Apache", Count: 5},
+		{Name: "Nginx"},
+		{Name: "Caddy", Enabled: true},
+	}, ts)
+
+	var tp []*testStruct
+	err = ScanFromMaps(&tp, rows[:1], map[string]string{"Name": "Name"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []*testStruct{{Name: "Apache"}}, tp)
+
+	err = ScanFromMaps(&tp, rows[:1], map[string]string{"Name": "name"})
+	assert.Equal(t, "row 0: could not find column \"name\" in trusted source instance", err.Error())
+
+	err = ScanFromMaps(tp, rows, nil)
+	assert.NotEqual(t, nil, err)
+	err = ScanFromMaps(&[]int{}, rows, nil)
+	assert.Equal(t, ErrNoStructs, errors.Cause(err))
+}
+
+func TestHelperHasField(t *testing.T) {
+	type testStruct struct {
+		Name     string
+		internal int
+	}
+	ts := testStruct{}
+
+	assert.Equal(t, true, HasField(ts, "Name"))
+	assert.Equal(t, true, HasField(&ts, "internal"))
+	assert.Equal(t, false, HasField(&ts, "Missing"))
+	assert.Equal(t, false, HasField(nil, "Name"))
+	assert.Equal(t, false, HasField(42, "Name"))
+
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, s.Has("Name"))
+	assert.Equal(t, false, s.Has("Missing"))
+	assert.Equal(t, nil, s.Err())
+}
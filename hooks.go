@@ -0,0 +1,26 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+/*   T y p e   d e f i n i t i o n   */
+
+// SetHook is a callback fired after a struct field was successfully set, with
+// the field and its old and new values, recorded the same way as Change values.
+type SetHook func(f *StructField, old, new interface{})
+
+/*   I m p l e m e n t a t i o n   */
+
+// OnSet registers the callback fn, fired after each successful call to one of
+// the setter methods of the struct fields, including the ones of its nested
+// structs, e.g. to attach validation, logging or cache invalidation without
+// wrapping every call site. Callbacks are fired in order of registration.
+// OnSet is carried out on the top level struct, even if s is a nested struct.
+func (s *StructValue) OnSet(fn SetHook) *StructValue {
+	if fn != nil {
+		r := s.root()
+		r.hooks = append(r.hooks, fn)
+	}
+	return s
+}
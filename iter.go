@@ -0,0 +1,41 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package structs
+
+import (
+	"iter"
+)
+
+/*   I m p l e m e n t a t i o n   */
+
+// All returns an iterator over the fields of the struct along with their index,
+// for use in range loops, e.g. for i, f := range s.All(). Like Fields, All is not
+// recursive.
+func (s *StructValue) All() iter.Seq2[int, *StructField] {
+	return func(yield func(int, *StructField) bool) {
+		for i, f := range s.Fields() {
+			if !yield(i, f) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the rows, for use in range loops, e.g. for row :=
+// range rows.All(). The StructValue yielded is the current row, which is only
+// valid until the next iteration. The rows are closed once the loop is over,
+// including when it exits early, and Err reports any error encountered.
+func (r *StructRows) All() iter.Seq[*StructValue] {
+	return func(yield func(*StructValue) bool) {
+		defer r.Close()
+		for r.Next() {
+			if !yield(&r.StructValue) {
+				return
+			}
+		}
+	}
+}
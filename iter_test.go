@@ -0,0 +1,64 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructAll(t *testing.T) {
+	type testStruct struct {
+		Name    string
+		Enabled bool
+	}
+	s, err := New(&testStruct{Name: "Apache"})
+	assert.Equal(t, nil, err)
+
+	names := make([]string, 0)
+	for i, f := range s.All() {
+		assert.Equal(t, len(names), i)
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{"Name", "Enabled"}, names)
+
+	for i := range s.All() {
+		assert.Equal(t, 0, i)
+		break
+	}
+}
+
+func TestRowsAll(t *testing.T) {
+	type testStruct struct {
+		Name string
+	}
+	ts := []testStruct{{Name: "Apache"}, {Name: "Nginx"}, {Name: "Caddy"}}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	names := make([]string, 0)
+	for row := range rows.All() {
+		names = append(names, row.Field("Name").String())
+		assert.Equal(t, nil, row.Field("Name").Set("x"+row.Field("Name").String()))
+	}
+	assert.Equal(t, []string{"Apache", "Nginx", "Caddy"}, names)
+	assert.Equal(t, []testStruct{{Name: "xApache"}, {Name: "xNginx"}, {Name: "xCaddy"}}, ts)
+	assert.Equal(t, nil, rows.Err())
+
+	rows, err = s.Rows()
+	assert.Equal(t, nil, err)
+	for row := range rows.All() {
+		assert.Equal(t, "xApache", row.Field("Name").String())
+		break
+	}
+	assert.Equal(t, OutOfRange, rows.Index())
+	_, err = rows.Columns()
+	assert.Equal(t, ErrRowsClosed, err)
+}
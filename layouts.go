@@ -0,0 +1,151 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Time layouts accepted when parsing text into time.Time fields, on top of the
+// standard library layouts, such as time.RFC3339.
+const (
+	LayoutDateTime  = "2006-01-02 15:04:05" // MySQL datetime.
+	LayoutDate      = "2006-01-02"          // Date only.
+	LayoutUnix      = "unix"                // Seconds since January 1, 1970 UTC.
+	LayoutUnixMilli = "unixmilli"           // Milliseconds since January 1, 1970 UTC.
+)
+
+// TimeParser parses text x into a time.Time value of field f, see SetTimeParser.
+type TimeParser func(f *StructField, x string) (time.Time, error)
+
+var (
+	timeParser  TimeParser
+	timeLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		LayoutDateTime,
+		LayoutDate,
+	}
+	timeLayoutsMu sync.RWMutex
+)
+
+/*   F u n c t i o n s   */
+
+// SetTimeLayouts replaces the time layouts accepted globally when text is set
+// into time.Time fields, e.g. by Set, ScanFromEnv or ApplyDefaults. Layouts are
+// attempted in order. Besides the time package layouts, LayoutUnix and
+// LayoutUnixMilli parse integer timestamps. Calling SetTimeLayouts without
+// layouts restores the defaults: time.RFC3339Nano, time.RFC3339,
+// LayoutDateTime and LayoutDate.
+func SetTimeLayouts(layouts ...string) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339Nano, time.RFC3339, LayoutDateTime, LayoutDate}
+	}
+	timeLayouts = append([]string(nil), layouts...)
+}
+
+// TimeLayouts returns the time layouts accepted globally.
+func TimeLayouts() []string {
+	timeLayoutsMu.RLock()
+	defer timeLayoutsMu.RUnlock()
+	return append([]string(nil), timeLayouts...)
+}
+
+// SetTimeParser overrides how text is parsed when set into time.Time fields,
+// e.g. by Set, ScanFromMap or ApplyDefaults, for instance to handle locales or
+// to detect epoch timestamps. Parser is given the field being set, so that it
+// can read its struct tags, and can delegate to ParseTime. Calling
+// SetTimeParser(nil) restores the default parsing, i.e. ParseTime with the time
+// layouts of the field struct.
+func SetTimeParser(parser TimeParser) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+	timeParser = parser
+}
+
+// ParseTime parses text x using layouts, in order, or using the time layouts
+// accepted globally if none are given, see SetTimeLayouts.
+// On failure, the error lists the attempted layouts.
+func ParseTime(x string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = TimeLayouts()
+	}
+	for _, layout := range layouts {
+		if t, err := parseTimeLayout(layout, x); err == nil {
+			return t, nil
+		}
+	}
+	attempted := make([]string, len(layouts))
+	for i, layout := range layouts {
+		attempted[i] = fmt.Sprintf("%q", layout)
+	}
+	return time.Time{}, errors.Errorf("could not parse time %q with layouts %s", x, strings.Join(attempted, ", "))
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// SetTimeLayouts replaces the time layouts accepted by struct s, and its nested
+// structs, in place of the global ones. Calling it without layouts reverts to
+// the global time layouts. See the package-level SetTimeLayouts.
+func (s *StructValue) SetTimeLayouts(layouts ...string) *StructValue {
+	s.layouts = append([]string(nil), layouts...)
+	return s
+}
+
+// TimeLayouts returns the time layouts accepted by struct s, i.e. the ones set
+// on itself or its closest parent struct, else the global ones.
+func (s *StructValue) TimeLayouts() []string {
+	for p := s; p != nil; p = p.Parent {
+		if len(p.layouts) > 0 {
+			return append([]string(nil), p.layouts...)
+		}
+	}
+	return TimeLayouts()
+}
+
+/*   U n e x p o r t e d   */
+
+// parseTime parses text x using the time parser set globally, if any, else the
+// time layouts accepted by the field struct, in order.
+func (f *StructField) parseTime(x string) (time.Time, error) {
+	timeLayoutsMu.RLock()
+	parser := timeParser
+	timeLayoutsMu.RUnlock()
+	if parser != nil {
+		return parser(f, x)
+	}
+	if f.Parent != nil {
+		return ParseTime(x, f.Parent.TimeLayouts()...)
+	}
+	return ParseTime(x)
+}
+
+// parseTimeLayout parses text x using layout, including the pseudo layouts
+// LayoutUnix and LayoutUnixMilli.
+func parseTimeLayout(layout, x string) (time.Time, error) {
+	switch layout {
+	case LayoutUnix:
+		n, err := strconv.ParseInt(x, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(n, 0).UTC(), nil
+	case LayoutUnixMilli:
+		n, err := strconv.ParseInt(x, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(n/1e3, (n%1e3)*int64(time.Millisecond)).UTC(), nil
+	}
+	return time.Parse(layout, x)
+}
@@ -0,0 +1,85 @@
+package structs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeLayouts(t *testing.T) {
+	type Program struct {
+		Released time.Time
+	}
+	type Server struct {
+		Started time.Time
+		Program *Program
+	}
+
+	server := Server{Program: &Program{}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	err = s.Field("Started").Set("2021-08-03 13:59:35")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Date(2021, time.August, 3, 13, 59, 35, 0, time.UTC), server.Started)
+
+	err = s.Field("Started").Set("1628000000")
+	assert.EqualError(t, err, `invalid value for field Server.Started: could not parse time "1628000000" with layouts "2006-01-02T15:04:05.999999999Z07:00", "2006-01-02T15:04:05Z07:00", "2006-01-02 15:04:05", "2006-01-02"`)
+
+	s.SetTimeLayouts(LayoutUnix, LayoutUnixMilli)
+	err = s.Field("Started").Set("1628000000")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Unix(1628000000, 0).UTC(), server.Started)
+
+	p := s.Field("Program").Struct()
+	assert.Equal(t, []string{LayoutUnix, LayoutUnixMilli}, p.TimeLayouts())
+	err = p.Field("Released").Set("unknown")
+	assert.EqualError(t, err, `invalid value for field Server.Program.Released: could not parse time "unknown" with layouts "unix", "unixmilli"`)
+
+	SetTimeLayouts(LayoutUnixMilli)
+	defer SetTimeLayouts()
+	s.SetTimeLayouts()
+	err = s.Field("Started").Set("1628000000123")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Unix(1628000000, 123000000).UTC(), server.Started)
+}
+
+func TestTimeParser(t *testing.T) {
+	type Server struct {
+		Started time.Time `time:"02/01/2006"`
+		Stopped time.Time
+	}
+	defer SetTimeParser(nil)
+	SetTimeParser(func(f *StructField, x string) (time.Time, error) {
+		if layout, ok := f.Tag("time"); ok {
+			return ParseTime(x, layout)
+		}
+		if n, err := strconv.ParseInt(x, 10, 64); err == nil {
+			return time.Unix(n, 0).UTC(), nil
+		}
+		return ParseTime(x)
+	})
+
+	server := Server{}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	err = s.Field("Started").Set("03/08/2021")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Date(2021, time.August, 3, 0, 0, 0, 0, time.UTC), server.Started)
+	err = s.Field("Started").Set("2021-08-03")
+	assert.EqualError(t, err, `invalid value for field Server.Started: could not parse time "2021-08-03" with layouts "02/01/2006"`)
+
+	err = s.Field("Stopped").Set("1628000000")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Unix(1628000000, 0).UTC(), server.Stopped)
+	err = s.Field("Stopped").Set("2021-08-03")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.Date(2021, time.August, 3, 0, 0, 0, 0, time.UTC), server.Stopped)
+
+	SetTimeParser(nil)
+	err = s.Field("Stopped").Set("1628000000")
+	assert.NotEqual(t, nil, err)
+}
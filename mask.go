@@ -0,0 +1,169 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// MaskFunc transforms the value of a string field, e.g. for a struct tag
+// declared as `mask:"email"`, the MaskFunc registered as "email" is called with
+// the value of the field, which is set to the result.
+type MaskFunc func(x string) string
+
+// TagMask is the struct tag key naming the MaskFunc applied to a field by Mask,
+// e.g. `mask:"last4"`.
+const TagMask = "mask"
+
+var (
+	maskFuncs = map[string]MaskFunc{
+		"email": maskEmail,
+		"last4": maskLast4,
+		"hash":  maskHash,
+	}
+	maskFuncsMu sync.RWMutex
+)
+
+/*   F u n c t i o n s   */
+
+// RegisterMask registers the masking strategy fn under name, so that fields
+// tagged `mask:"name"` are transformed by Mask. Registering a nil strategy
+// removes it. The following are registered out of the box:
+//
+//	email  keeps the first character and the domain, e.g. "r******@example.com".
+//	last4  keeps the last four characters, e.g. "************1234".
+//	hash   replaces the value with its hex encoded SHA-256 hash.
+func RegisterMask(name string, fn MaskFunc) {
+	maskFuncsMu.Lock()
+	defer maskFuncsMu.Unlock()
+	if fn == nil {
+		delete(maskFuncs, name)
+		return
+	}
+	maskFuncs[name] = fn
+}
+
+// Mask returns a pointer to a deep clone of struct dest whose fields tagged with
+// a mask struct tag are masked, leaving dest untouched. See StructValue.Mask.
+func Mask(dest interface{}) (interface{}, error) {
+	c, err := Clone(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not mask struct")
+	}
+	s, err := New(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not mask struct")
+	}
+	if err := s.mask(true); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Mask transforms in place the string fields, or pointers to strings, tagged
+// with a mask struct tag, e.g. `mask:"email"`, using the MaskFunc registered
+// under the tag value, so that personally identifiable information can be
+// logged or displayed safely. Empty strings and nil pointers are left as is.
+// Nested structs are masked recursively, including the ones held by slices,
+// arrays, maps and interfaces. See RegisterMask for the available strategies.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Mask() error {
+	return s.mask(false)
+}
+
+/*   U n e x p o r t e d   */
+
+// mask masks the tagged fields of the struct in place, see Mask. If clone is
+// true, the struct is a deep clone, see walkStructs.
+func (s *StructValue) mask(clone bool) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		if _, ok := f.Tag(TagMask); ok {
+			if err := f.mask(); err != nil {
+				return errors.Wrapf(err, "could not mask struct %s", s.FullName())
+			}
+			continue
+		}
+		if f.CanStruct() {
+			if err := f.Struct().mask(clone); err != nil { // Recursivity
+				return err
+			}
+			continue
+		}
+		err := walkStructs(f.value, map[uintptr]bool{}, clone, func(s *StructValue) error {
+			return s.mask(clone) // Recursivity
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mask transforms the value of the field with the MaskFunc named by its mask
+// struct tag.
+func (f *StructField) mask() error {
+	name, _ := f.Tag(TagMask)
+	maskFuncsMu.RLock()
+	fn, ok := maskFuncs[name]
+	maskFuncsMu.RUnlock()
+	if !ok {
+		return errors.Errorf("unknown mask %q for field %s", name, f.FullName())
+	}
+	if f.IndirectType().Kind() != reflect.String {
+		return errors.Wrapf(ErrNotString, "could not mask field %s", f.FullName())
+	}
+	v := f.Indirect()
+	if !v.IsValid() || v.String() == "" {
+		return nil
+	}
+	return f.Set(fn(v.String()))
+}
+
+// maskEmail is the MaskFunc keeping the first character of the local part of
+// email address x, along with its domain.
+func maskEmail(x string) string {
+	i := strings.LastIndex(x, "@")
+	if i < 0 {
+		return maskRunes(x, 0, 0)
+	}
+	return maskRunes(x[:i], 1, 0) + x[i:]
+}
+
+// maskLast4 is the MaskFunc keeping the last four characters of x.
+func maskLast4(x string) string {
+	return maskRunes(x, 0, 4)
+}
+
+// maskHash is the MaskFunc replacing x with its hex encoded SHA-256 hash.
+func maskHash(x string) string {
+	h := sha256.Sum256([]byte(x))
+	return hex.EncodeToString(h[:])
+}
+
+// maskRunes replaces the characters of x with asterisks, but the first head and
+// last tail ones. If x is not longer than head and tail, it is masked entirely.
+func maskRunes(x string, head, tail int) string {
+	r := []rune(x)
+	if len(r) <= head+tail {
+		head, tail = 0, 0
+	}
+	for i := head; i < len(r)-tail; i++ {
+		r[i] = '*'
+	}
+	return string(r)
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/pointers"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCard struct {
+	Number string  `mask:"last4"`
+	Holder *string `mask:"upper"`
+}
+
+type testContact struct {
+	Email    string  `mask:"email"`
+	Backup   *string `mask:"email"`
+	Password string  `mask:"hash"`
+	Name     string
+	Card     testCard
+	Count    int `mask:"last4"`
+}
+
+func TestMask(t *testing.T) {
+	RegisterMask("upper", strings.ToUpper)
+	defer RegisterMask("upper", nil)
+
+	ts := testContact{
+		Email:    "roninzo@example.com",
+		Password: "abc",
+		Name:     "Roninzo",
+		Card:     testCard{Number: "4111111111111234", Holder: pointers.String("roninzo")},
+	}
+
+	x, err := Mask(&ts)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, nil, x)
+	assert.Equal(t, ErrNotString, errors.Cause(err))
+
+	type T struct {
+		Email string `mask:"unknown"`
+	}
+	s, err := New(&T{Email: "roninzo@example.com"})
+	assert.Equal(t, nil, err)
+	assert.EqualError(t, s.Mask(), `could not mask struct T: unknown mask "unknown" for field T.Email`)
+
+	type Contact struct {
+		Email    string  `mask:"email"`
+		Backup   *string `mask:"email"`
+		Password string  `mask:"hash"`
+		Name     string
+		Card     testCard
+	}
+	c := Contact{
+		Email:    "roninzo@example.com",
+		Password: "abc",
+		Name:     "Roninzo",
+		Card:     testCard{Number: "4111111111111234", Holder: pointers.String("roninzo")},
+	}
+	x, err = Mask(&c)
+	assert.Equal(t, nil, err)
+	m := x.(*Contact)
+	assert.Equal(t, "r******@example.com", m.Email)
+	assert.Equal(t, (*string)(nil), m.Backup)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", m.Password)
+	assert.Equal(t, "Roninzo", m.Name)
+	assert.Equal(t, "************1234", m.Card.Number)
+	assert.Equal(t, "RONINZO", *m.Card.Holder)
+	assert.Equal(t, "roninzo@example.com", c.Email)
+	assert.Equal(t, "roninzo", *c.Card.Holder)
+
+	s, err = New(&c)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Mask())
+	assert.Equal(t, "r******@example.com", c.Email)
+	assert.Equal(t, "RONINZO", *c.Card.Holder)
+}
+
+func TestMaskCollections(t *testing.T) {
+	RegisterMask("upper", strings.ToUpper)
+	defer RegisterMask("upper", nil)
+
+	type Holder struct {
+		Cards []testCard
+		Arr   [1]testCard
+		ByID  map[string]*testCard
+		ByKey map[string]testCard
+		Any   interface{}
+		Value interface{}
+	}
+
+	ts := Holder{
+		Cards: []testCard{{Number: "1111222233334444"}},
+		Arr:   [1]testCard{{Number: "5555666677778888"}},
+		ByID:  map[string]*testCard{"x": {Number: "0000000000001234"}},
+		ByKey: map[string]testCard{"y": {Number: "0000000000005678"}},
+		Any:   &testCard{Number: "0000000000009012"},
+		Value: testCard{Number: "0000000000003456"},
+	}
+
+	x, err := Mask(&ts)
+	assert.Equal(t, nil, err)
+	m := x.(*Holder)
+	assert.Equal(t, "************4444", m.Cards[0].Number)
+	assert.Equal(t, "************8888", m.Arr[0].Number)
+	assert.Equal(t, "************1234", m.ByID["x"].Number)
+	assert.Equal(t, "************5678", m.ByKey["y"].Number)
+	assert.Equal(t, "************9012", m.Any.(*testCard).Number)
+	assert.Equal(t, "************3456", m.Value.(testCard).Number)
+
+	// original left untouched
+	assert.Equal(t, "1111222233334444", ts.Cards[0].Number)
+	assert.Equal(t, "5555666677778888", ts.Arr[0].Number)
+	assert.Equal(t, "0000000000001234", ts.ByID["x"].Number)
+	assert.Equal(t, "0000000000005678", ts.ByKey["y"].Number)
+	assert.Equal(t, "0000000000009012", ts.Any.(*testCard).Number)
+	assert.Equal(t, "0000000000003456", ts.Value.(testCard).Number)
+
+	// in place
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Mask())
+	assert.Equal(t, "************4444", ts.Cards[0].Number)
+	assert.Equal(t, "************8888", ts.Arr[0].Number)
+	assert.Equal(t, "************1234", ts.ByID["x"].Number)
+	assert.Equal(t, "************5678", ts.ByKey["y"].Number)
+	assert.Equal(t, "************9012", ts.Any.(*testCard).Number)
+	assert.Equal(t, "************3456", ts.Value.(testCard).Number)
+}
+
+func TestMaskStrategies(t *testing.T) {
+	assert.Equal(t, "*@example.com", maskEmail("r@example.com"))
+	assert.Equal(t, "*****", maskEmail("notan"))
+	assert.Equal(t, "****", maskLast4("1234"))
+	assert.Equal(t, "***1234", maskLast4("ééé1234"))
+	assert.Equal(t, "é**", maskRunes("éé€", 1, 0))
+}
@@ -0,0 +1,620 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// msgpackEntry represents a decoded MessagePack map entry. Decoded maps are
+// kept as slices of entries, since their keys may not be comparable.
+type msgpackEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+// msgpackDecoder reads MessagePack values out of a byte slice.
+type msgpackDecoder struct {
+	b []byte
+	i int
+}
+
+// msgpackTimestamp is the MessagePack extension type of timestamps.
+const msgpackTimestamp = -1
+
+/*   F u n c t i o n s   */
+
+// ToMsgpack returns the MessagePack encoding of the struct, or slice of structs,
+// dest. Structs are encoded as maps keyed by the name part of their msgpack
+// struct tags, else by field names. Fields are hidden the same way as by
+// IsHidden, i.e. when tagged "-", or when tagged with the omitempty option and
+// empty. Times are encoded as timestamp extensions, durations as integers.
+// Unexported struct fields will be neglected.
+func ToMsgpack(dest interface{}) ([]byte, error) {
+	ctx := "could not encode struct to msgpack"
+	if _, err := New(dest); err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	var buf bytes.Buffer
+	if err := encodeMsgpack(&buf, reflect.ValueOf(dest)); err != nil {
+		return nil, errors.Wrap(err, ctx)
+	}
+	return buf.Bytes(), nil
+}
+
+// FromMsgpack decodes the MessagePack data b into the struct, or slice of
+// structs, dest, which must be a pointer. Map keys are matched against the same
+// names as ToMsgpack. Numbers are converted to the kind of their field, as long
+// as they do not overflow it. Maps and arrays decoded into interface{} values
+// become map[string]interface{} and []interface{}, like encoding/json. Keys
+// without matching field are neglected.
+func FromMsgpack(dest interface{}, b []byte) error {
+	ctx := "could not decode msgpack into struct"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	ctx = fmt.Sprintf("could not decode msgpack into struct %s", s.Name())
+	if !s.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s.Name()), ctx)
+	}
+	d := &msgpackDecoder{b: b}
+	x, err := d.decode()
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if d.i != len(b) {
+		return errors.Wrap(errors.Errorf("%d trailing bytes", len(b)-d.i), ctx)
+	}
+	return errors.Wrap(assignMsgpack(reflect.ValueOf(dest).Elem(), x), ctx)
+}
+
+/*   U n e x p o r t e d   */
+
+// encodeMsgpack writes the MessagePack encoding of v to buf.
+func encodeMsgpack(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteByte(0xc0)
+		return nil
+	}
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		t := v.Interface().(time.Time)
+		buf.Write([]byte{0xc7, 12, byte(msgpackTimestamp & 0xff)})
+		writeBigEndian(buf, uint64(t.Nanosecond()), 4)
+		writeBigEndian(buf, uint64(t.Unix()), 8)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return encodeMsgpack(buf, v.Elem())
+	case reflect.Struct:
+		return encodeMsgpackStruct(buf, v)
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeMsgpackInt(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeMsgpackUint(buf, v.Uint())
+	case reflect.Float32:
+		buf.WriteByte(0xca)
+		writeBigEndian(buf, uint64(math.Float32bits(float32(v.Float()))), 4)
+	case reflect.Float64:
+		buf.WriteByte(0xcb)
+		writeBigEndian(buf, math.Float64bits(v.Float()), 8)
+	case reflect.String:
+		writeMsgpackHeader(buf, v.Len(), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		buf.WriteString(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeMsgpackHeader(buf, v.Len(), 0, 0, 0xc4, 0xc5, 0xc6)
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			buf.Write(b)
+			return nil
+		}
+		writeMsgpackHeader(buf, v.Len(), 0x90, 16, 0, 0xdc, 0xdd)
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeMsgpack(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		writeMsgpackHeader(buf, len(keys), 0x80, 16, 0, 0xde, 0xdf)
+		for _, k := range keys {
+			if err := encodeMsgpack(buf, k); err != nil {
+				return err
+			}
+			if err := encodeMsgpack(buf, v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
+
+// encodeMsgpackStruct writes the struct v to buf as a map of its visible fields.
+func encodeMsgpackStruct(buf *bytes.Buffer, v reflect.Value) error {
+	s, err := New(v.Interface())
+	if err != nil {
+		return err
+	}
+	fields := make(StructFields, 0)
+	for _, f := range s.Fields() {
+		if f.IsExported() && !f.isHiddenBy("msgpack") {
+			fields = append(fields, f)
+		}
+	}
+	writeMsgpackHeader(buf, len(fields), 0x80, 16, 0, 0xde, 0xdf)
+	for _, f := range fields {
+		name := f.TagName("msgpack")
+		writeMsgpackHeader(buf, len(name), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		buf.WriteString(name)
+		if err := encodeMsgpack(buf, f.value); err != nil {
+			return errors.Wrapf(err, "could not encode field %s", f.FullName())
+		}
+	}
+	return nil
+}
+
+// writeMsgpackHeader writes the header of a string, binary, array or map of
+// length n, using the fix format when n is lower than fixMax, and the 8, 16 or
+// 32 bit formats otherwise. Formats set to zero are not available.
+func writeMsgpackHeader(buf *bytes.Buffer, n int, fix byte, fixMax int, f8, f16, f32 byte) {
+	switch {
+	case n < fixMax:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint8 && f8 != 0:
+		buf.Write([]byte{f8, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(f16)
+		writeBigEndian(buf, uint64(n), 2)
+	default:
+		buf.WriteByte(f32)
+		writeBigEndian(buf, uint64(n), 4)
+	}
+}
+
+// writeMsgpackInt writes the signed integer n to buf, in its shortest format.
+func writeMsgpackInt(buf *bytes.Buffer, n int64) {
+	switch {
+	case n >= 0:
+		writeMsgpackUint(buf, uint64(n))
+	case n >= -32:
+		buf.WriteByte(byte(n))
+	case n >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(n)})
+	case n >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		writeBigEndian(buf, uint64(n), 2)
+	case n >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		writeBigEndian(buf, uint64(n), 4)
+	default:
+		buf.WriteByte(0xd3)
+		writeBigEndian(buf, uint64(n), 8)
+	}
+}
+
+// writeMsgpackUint writes the unsigned integer n to buf, in its shortest format.
+func writeMsgpackUint(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n <= 0x7f:
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		writeBigEndian(buf, n, 2)
+	case n <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		writeBigEndian(buf, n, 4)
+	default:
+		buf.WriteByte(0xcf)
+		writeBigEndian(buf, n, 8)
+	}
+}
+
+// writeBigEndian writes the size lowest bytes of n to buf, in big-endian order.
+func writeBigEndian(buf *bytes.Buffer, n uint64, size int) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	buf.Write(b[8-size:])
+}
+
+// decode reads the next value: nil, bool, int64, uint64, float64, string,
+// []byte, time.Time, []interface{} or []msgpackEntry.
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	c, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	b := c[0]
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return d.decodeMap(int(b & 0x0f))
+	case b&0xf0 == 0x90:
+		return d.decodeArray(int(b & 0x0f))
+	case b&0xe0 == 0xa0:
+		return d.decodeString(int(b & 0x1f))
+	}
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.uint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		x, err := d.read(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), x...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.uint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(int(n))
+	case 0xca:
+		n, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := d.uint(8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return d.uint(1 << (b - 0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		n, err := d.uint(size)
+		shift := uint(64 - 8*size)
+		return int64(n<<shift) >> shift, err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExt(1 << (b - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.uint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+	}
+	return nil, errors.Errorf("invalid msgpack format 0x%02x at offset %d", b, d.i-1)
+}
+
+// decodeString reads a string of n bytes.
+func (d *msgpackDecoder) decodeString(n int) (interface{}, error) {
+	x, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(x), nil
+}
+
+// decodeArray reads n values.
+func (d *msgpackDecoder) decodeArray(n int) (interface{}, error) {
+	if n < 0 || n > len(d.b)-d.i { // Each value takes at least a byte.
+		return nil, errors.New("unexpected end of msgpack data")
+	}
+	a := make([]interface{}, n)
+	for i := range a {
+		x, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		a[i] = x
+	}
+	return a, nil
+}
+
+// decodeMap reads n key/value pairs.
+func (d *msgpackDecoder) decodeMap(n int) (interface{}, error) {
+	if n < 0 || n > (len(d.b)-d.i)/2 { // Each pair takes at least two bytes.
+		return nil, errors.New("unexpected end of msgpack data")
+	}
+	m := make([]msgpackEntry, n)
+	for i := range m {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		m[i] = msgpackEntry{key: k, value: v}
+	}
+	return m, nil
+}
+
+// decodeExt reads an extension of n bytes. Only timestamps are supported.
+func (d *msgpackDecoder) decodeExt(n int) (interface{}, error) {
+	c, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	x, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(c[0]) != msgpackTimestamp {
+		return nil, errors.Errorf("unsupported msgpack extension type %d", int8(c[0]))
+	}
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(x)), 0), nil
+	case 8:
+		n := binary.BigEndian.Uint64(x)
+		return time.Unix(int64(n&0x3ffffffff), int64(n>>34)), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(x[4:])), int64(binary.BigEndian.Uint32(x))), nil
+	}
+	return nil, errors.Errorf("invalid msgpack timestamp length %d", n)
+}
+
+// uint reads a big-endian unsigned integer of size bytes.
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	x, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, b := range x {
+		n = n<<8 | uint64(b)
+	}
+	return n, nil
+}
+
+// read returns the next n bytes.
+func (d *msgpackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || d.i+n > len(d.b) {
+		return nil, errors.New("unexpected end of msgpack data")
+	}
+	x := d.b[d.i : d.i+n]
+	d.i += n
+	return x, nil
+}
+
+// assignMsgpack sets the settable value v to the decoded value x.
+func assignMsgpack(v reflect.Value, x interface{}) error {
+	if x == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return assignMsgpack(v.Elem(), x)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(plainMsgpack(x)))
+			return nil
+		}
+	case reflect.Struct:
+		if t, ok := x.(time.Time); ok && v.Type() == reflect.TypeOf(t) {
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+		if m, ok := x.([]msgpackEntry); ok {
+			return assignMsgpackStruct(v, m)
+		}
+	case reflect.Bool:
+		if b, ok := x.(bool); ok {
+			v.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch y := x.(type) {
+		case int64:
+			n = y
+		case uint64:
+			if y > math.MaxInt64 {
+				return errors.Errorf("value %d overflows %s", y, v.Type())
+			}
+			n = int64(y)
+		default:
+			return errors.Errorf("cannot decode %T into %s", x, v.Type())
+		}
+		if v.OverflowInt(n) {
+			return errors.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		switch y := x.(type) {
+		case uint64:
+			n = y
+		case int64:
+			if y < 0 {
+				return errors.Errorf("value %d overflows %s", y, v.Type())
+			}
+			n = uint64(y)
+		default:
+			return errors.Errorf("cannot decode %T into %s", x, v.Type())
+		}
+		if v.OverflowUint(n) {
+			return errors.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch y := x.(type) {
+		case float64:
+			v.SetFloat(y)
+		case int64:
+			v.SetFloat(float64(y))
+		case uint64:
+			v.SetFloat(float64(y))
+		default:
+			return errors.Errorf("cannot decode %T into %s", x, v.Type())
+		}
+		return nil
+	case reflect.String:
+		switch y := x.(type) {
+		case string:
+			v.SetString(y)
+			return nil
+		case []byte:
+			v.SetString(string(y))
+			return nil
+		}
+	case reflect.Slice, reflect.Array:
+		if b, ok := x.([]byte); ok && v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Kind() == reflect.Slice {
+				v.SetBytes(b)
+				return nil
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		a, ok := x.([]interface{})
+		if !ok {
+			break
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(a), len(a)))
+		} else if len(a) > v.Len() {
+			return errors.Errorf("%d values overflow %s", len(a), v.Type())
+		}
+		for i, y := range a {
+			if err := assignMsgpack(v.Index(i), y); err != nil {
+				return errors.Wrapf(err, "element %d", i)
+			}
+		}
+		return nil
+	case reflect.Map:
+		m, ok := x.([]msgpackEntry)
+		if !ok {
+			break
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(m)))
+		for _, e := range m {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := assignMsgpack(k, e.key); err != nil {
+				return errors.Wrapf(err, "key %v", e.key)
+			}
+			y := reflect.New(v.Type().Elem()).Elem()
+			if err := assignMsgpack(y, e.value); err != nil {
+				return errors.Wrapf(err, "value of key %v", e.key)
+			}
+			v.SetMapIndex(k, y)
+		}
+		return nil
+	}
+	return errors.Errorf("cannot decode %T into %s", x, v.Type())
+}
+
+// plainMsgpack returns the decoded value x with its maps converted to
+// map[string]interface{}, keys being formatted with fmt.Sprint, and its arrays
+// to []interface{}, recursively, for interface{} targets.
+func plainMsgpack(x interface{}) interface{} {
+	switch y := x.(type) {
+	case []msgpackEntry:
+		m := make(map[string]interface{}, len(y))
+		for _, e := range y {
+			k, ok := e.key.(string)
+			if !ok {
+				k = fmt.Sprint(e.key)
+			}
+			m[k] = plainMsgpack(e.value)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(y))
+		for i, z := range y {
+			a[i] = plainMsgpack(z)
+		}
+		return a
+	}
+	return x
+}
+
+// assignMsgpackStruct sets the fields of the settable struct v to the values of
+// the entries of m with matching names.
+func assignMsgpackStruct(v reflect.Value, m []msgpackEntry) error {
+	s, err := New(v.Addr().Interface())
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(m))
+	for _, e := range m {
+		if k, ok := e.key.(string); ok {
+			values[k] = e.value
+		}
+	}
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		name := f.TagName("msgpack")
+		if name == "" {
+			continue
+		}
+		x, ok := values[name]
+		if !ok {
+			continue
+		}
+		err := f.mutate(func() error { return assignMsgpack(f.value, x) })
+		if err != nil {
+			return errors.Wrapf(err, "could not decode field %s", f.FullName())
+		}
+	}
+	return nil
+}
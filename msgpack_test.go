@@ -0,0 +1,122 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgpack(t *testing.T) {
+	type Program struct {
+		Name    string `msgpack:"name"`
+		Version string `msgpack:"version,omitempty"`
+	}
+	type Server struct {
+		Name     string           `msgpack:"name"`
+		ID       uint             `msgpack:"id"`
+		Port     int16            `msgpack:"port"`
+		Offset   int64            `msgpack:"offset"`
+		Ratio    float64          `msgpack:"ratio"`
+		Enabled  bool             `msgpack:"enabled"`
+		Secret   string           `msgpack:"-"`
+		Data     []byte           `msgpack:"data"`
+		Timeout  time.Duration    `msgpack:"timeout"`
+		Created  time.Time        `msgpack:"created"`
+		Program  *Program         `msgpack:"program"`
+		Backups  []Program        `msgpack:"backups"`
+		Labels   map[string]int   `msgpack:"labels"`
+		Optional *string          `msgpack:"optional,omitempty"`
+		Any      interface{}      `msgpack:"any"`
+		Extra    map[int][]string `msgpack:"extra"`
+	}
+
+	created := time.Date(2021, 6, 1, 12, 30, 15, 123, time.UTC)
+	server := Server{
+		Name:    "Roninzo",
+		ID:      123456,
+		Port:    -8080,
+		Offset:  -1 << 40,
+		Ratio:   0.75,
+		Enabled: true,
+		Secret:  "s3cr3t",
+		Data:    []byte{1, 2, 3},
+		Timeout: 5 * time.Second,
+		Created: created,
+		Program: &Program{Name: "Apache"},
+		Backups: []Program{{Name: "Nginx", Version: "1.21"}},
+		Labels:  map[string]int{"a": 1, "b": -2},
+		Any:     "text",
+		Extra:   map[int][]string{1: {"x", "y"}},
+	}
+
+	b, err := ToMsgpack(&server)
+	assert.Equal(t, nil, err)
+
+	got := Server{Secret: "kept"}
+	err = FromMsgpack(&got, b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "kept", got.Secret)
+	got.Secret = server.Secret
+	assert.Equal(t, true, got.Created.Equal(created))
+	got.Created = created
+	assert.Equal(t, server, got)
+
+	// Fixed values of the MessagePack specification.
+	type Small struct {
+		A int    `msgpack:"a"`
+		B string `msgpack:"b,omitempty"`
+	}
+	b, err = ToMsgpack(Small{A: 1})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0x81, 0xa1, 'a', 0x01}, b)
+
+	var small Small
+	err = FromMsgpack(&small, []byte{0x82, 0xa1, 'a', 0xcd, 0x01, 0x00, 0xa1, 'b', 0xa2, 'h', 'i'})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Small{A: 256, B: "hi"}, small)
+
+	type Tiny struct {
+		A int8 `msgpack:"a"`
+	}
+	var tiny Tiny
+	err = FromMsgpack(&tiny, []byte{0x81, 0xa1, 'a', 0xcd, 0x01, 0x00})
+	assert.NotEqual(t, nil, err)
+	err = FromMsgpack(&tiny, []byte{0x81, 0xa1})
+	assert.NotEqual(t, nil, err)
+	err = FromMsgpack(tiny, []byte{0x80})
+	assert.NotEqual(t, nil, err)
+
+	// Untrusted lengths.
+	err = FromMsgpack(&small, []byte{0xdd, 0xff, 0xff, 0xff, 0xff})
+	assert.NotEqual(t, nil, err)
+	err = FromMsgpack(&small, []byte{0xdf, 0xff, 0xff, 0xff, 0xff, 0xa1, 'a'})
+	assert.NotEqual(t, nil, err)
+
+	// Maps and arrays decoded into interfaces.
+	type Loose struct {
+		Any  interface{}            `msgpack:"any"`
+		Meta map[string]interface{} `msgpack:"meta"`
+	}
+	loose := Loose{
+		Any:  map[string]interface{}{"a": []interface{}{"x", map[int]string{1: "y"}}},
+		Meta: map[string]interface{}{"b": map[string]bool{"c": true}},
+	}
+	b, err = ToMsgpack(&loose)
+	assert.Equal(t, nil, err)
+	var gotLoose Loose
+	err = FromMsgpack(&gotLoose, b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Loose{
+		Any:  map[string]interface{}{"a": []interface{}{"x", map[string]interface{}{"1": "y"}}},
+		Meta: map[string]interface{}{"b": map[string]interface{}{"c": true}},
+	}, gotLoose)
+
+	rows := []Small{{A: 1}, {A: 2, B: "x"}}
+	b, err = ToMsgpack(rows)
+	assert.Equal(t, nil, err)
+	var back []Small
+	err = FromMsgpack(&back, b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rows, back)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// NamespaceOption configures how namespaces are rendered, see Namespace.
+type NamespaceOption func(*namespaceOptions)
+
+// namespaceOptions holds the configuration of namespaces.
+type namespaceOptions struct {
+	sep string // Separator of path segments.
+	tag string // Struct tag key naming path segments, if any.
+}
+
+/*   F u n c t i o n s   */
+
+// WithSeparator joins namespace segments with sep, instead of ".", e.g. "/" to
+// render JSON pointers or "_" to render SQL column prefixes.
+func WithSeparator(sep string) NamespaceOption {
+	return func(o *namespaceOptions) {
+		o.sep = sep
+	}
+}
+
+// WithTagNames names namespace segments after the name part of struct tag key,
+// e.g. "json" or "db", instead of Go field names. Fields without such a tag, or
+// tagged with "-", fall back to their Go field name.
+func WithTagNames(key string) NamespaceOption {
+	return func(o *namespaceOptions) {
+		o.tag = key
+	}
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Namespace returns the path of the nested struct from the top level struct, i.e.
+// the names of the fields leading to it, e.g. "Program" for a struct found in the
+// Program field of the top level struct. Contrary to FullName, segments are named
+// after fields, not struct types, and the top level struct is omitted; its own
+// Namespace is empty. Nested structs not obtained from StructField.Struct are
+// named after their struct type. Rows of a slice of structs being iterated are
+// named after their index, e.g. "[2]", and so are elements of slice or map
+// fields, e.g. "Programs[2]".
+func (s *StructValue) Namespace(opts ...NamespaceOption) string {
+	o := newNamespaceOptions(opts)
+	return strings.Join(s.namespace(o), o.sep)
+}
+
+// Namespace returns the path of the field from the top level struct, e.g.
+// "Program.Name", or "program/name" when using options WithTagNames("json") and
+// WithSeparator("/"). See StructValue.Namespace.
+func (f *StructField) Namespace(opts ...NamespaceOption) string {
+	o := newNamespaceOptions(opts)
+	return strings.Join(f.namespace(o), o.sep)
+}
+
+/*   U n e x p o r t e d   */
+
+// newNamespaceOptions returns the namespace configuration set by opts.
+func newNamespaceOptions(opts []NamespaceOption) *namespaceOptions {
+	o := &namespaceOptions{sep: "."}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// namespace returns the path segments of the struct.
+func (s *StructValue) namespace(o *namespaceOptions) []string {
+	switch {
+	case s.parentField != nil:
+		return s.parentField.namespace(o)
+	case s.Parent != nil:
+		return append(s.Parent.namespace(o), s.Name())
+	case s.rownum != OutOfRange:
+		return []string{fmt.Sprintf("[%d]", s.rownum)}
+	}
+	return nil
+}
+
+// namespace returns the path segments of the field.
+func (f *StructField) namespace(o *namespaceOptions) []string {
+	var segments []string
+	if f.Parent != nil {
+		segments = f.Parent.namespace(o)
+	}
+	return append(segments, f.segment(o))
+}
+
+// segment returns the name of the field in a namespace.
+func (f *StructField) segment(o *namespaceOptions) string {
+	if f.owner != nil {
+		return fmt.Sprintf("%s[%s]", f.owner.segment(o), f.key)
+	}
+	if o.tag != "" {
+		if name := f.TagName(o.tag); name != "" {
+			return name
+		}
+	}
+	return f.Name()
+}
@@ -0,0 +1,69 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	type Program struct {
+		Name string `json:"name" db:"program_name"`
+	}
+	type Server struct {
+		Name   string   `json:"name"`
+		Backup *Program `json:"backup,omitempty"`
+		Secret string   `json:"-"`
+	}
+
+	server := Server{Backup: &Program{}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	b := s.Field("Backup").Struct()
+	f := b.Field("Name")
+	assert.Equal(t, "", s.Namespace())
+	assert.Equal(t, "Backup", b.Namespace())
+	assert.Equal(t, "Name", s.Field("Name").Namespace())
+	assert.Equal(t, "Backup.Name", f.Namespace())
+	assert.Equal(t, "Server.Program.Name", f.FullName())
+	assert.Equal(t, "backup/name", f.Namespace(WithTagNames("json"), WithSeparator("/")))
+	assert.Equal(t, "Backup_program_name", f.Namespace(WithTagNames("db"), WithSeparator("_")))
+	assert.Equal(t, "Secret", s.Field("Secret").Namespace(WithTagNames("json")))
+	assert.Equal(t, "Backup.Name", s.FindStruct("Program").Field("Name").Namespace())
+}
+
+func TestNamespaceIndexes(t *testing.T) {
+	type Program struct {
+		Name string `json:"name"`
+	}
+	type Server struct {
+		Name     string    `json:"name"`
+		Programs []Program `json:"programs"`
+	}
+
+	servers := []Server{
+		{Name: "Apache"},
+		{Name: "Nginx", Programs: []Program{{Name: "PHP"}, {Name: "Perl"}}},
+	}
+	s, err := New(&servers)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Server.Name", s.Field("Name").FullName())
+
+	rows, err := s.Rows()
+	assert.Equal(t, nil, err)
+	defer rows.Close()
+	fullnames := make([]string, 0)
+	namespaces := make([]string, 0)
+	for rows.Next() {
+		f := rows.Field("Programs")
+		fullnames = append(fullnames, rows.Field("Name").FullName())
+		for i := 0; i < f.Len(); i++ {
+			p := f.At(i).Struct().Field("Name")
+			fullnames = append(fullnames, p.FullName())
+			namespaces = append(namespaces, p.Namespace(WithTagNames("json")))
+		}
+	}
+	assert.Equal(t, []string{"Server[0].Name", "Server[1].Name", "Server[1].Program[0].Name", "Server[1].Program[1].Name"}, fullnames)
+	assert.Equal(t, []string{"[1].programs[0].name", "[1].programs[1].name"}, namespaces)
+}
@@ -0,0 +1,265 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// NumericConverter converts x, e.g. a string, an int, a float64 or a value of
+// the registered type itself, into a value of the numeric type it was registered
+// for, see RegisterNumeric.
+type NumericConverter func(x interface{}) (interface{}, error)
+
+var (
+	numerics   = map[reflect.Type]NumericConverter{}
+	numericsMu sync.RWMutex
+
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+/*   F u n c t i o n s   */
+
+// RegisterNumeric registers the numeric type of sample, e.g. decimal.Decimal of
+// package github.com/shopspring/decimal, so that fields of that type, or
+// pointers to it, are set through convert and handled as single values rather
+// than nested structs:
+//
+//	structs.RegisterNumeric(decimal.Zero, func(x interface{}) (interface{}, error) {
+//		switch x := x.(type) {
+//		case string:
+//			return decimal.NewFromString(x)
+//		case float64:
+//			return decimal.NewFromFloat(x), nil
+//		case int:
+//			return decimal.NewFromInt(int64(x)), nil
+//		}
+//		return nil, fmt.Errorf("cannot convert %T to decimal", x)
+//	})
+//
+// Registering a nil convert unregisters the type.
+func RegisterNumeric(sample interface{}, convert NumericConverter) {
+	t := reflect.TypeOf(sample)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	numericsMu.Lock()
+	defer numericsMu.Unlock()
+	if convert == nil {
+		delete(numerics, t)
+		return
+	}
+	numerics[t] = convert
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// CanBigInt returns true if the field is a big.Int, or a pointer to one.
+func (f *StructField) CanBigInt() bool { return indirectType(f.value.Type()) == bigIntType }
+
+// CanBigFloat returns true if the field is a big.Float, or a pointer to one.
+func (f *StructField) CanBigFloat() bool { return indirectType(f.value.Type()) == bigFloatType }
+
+// BigInt returns a copy of the value of a big.Int field, or of the big.Int it
+// points to. Like other typed getters, nil pointers return zero. Fields of other
+// integer kinds are converted.
+// BigInt returns nil if the field is not an integer.
+func (f *StructField) BigInt() *big.Int {
+	v := f.elemValue()
+	switch {
+	case v.Type() == bigIntType:
+		x := v.Interface().(big.Int)
+		return new(big.Int).Set(&x)
+	case v.CanInt():
+		return big.NewInt(v.Int())
+	case v.CanUint():
+		return new(big.Int).SetUint64(v.Uint())
+	}
+	return nil
+}
+
+// BigFloat returns a copy of the value of a big.Float field, or of the big.Float
+// it points to. Like other typed getters, nil pointers return zero. Fields of
+// other numeric kinds, including big.Int, are converted.
+// BigFloat returns nil if the field is not a number.
+func (f *StructField) BigFloat() *big.Float {
+	v := f.elemValue()
+	switch {
+	case v.Type() == bigFloatType:
+		x := v.Interface().(big.Float)
+		return new(big.Float).Copy(&x)
+	case v.Type() == bigIntType:
+		x := v.Interface().(big.Int)
+		return new(big.Float).SetInt(&x)
+	case v.CanInt():
+		return new(big.Float).SetInt64(v.Int())
+	case v.CanUint():
+		return new(big.Float).SetUint64(v.Uint())
+	case v.CanFloat():
+		return big.NewFloat(v.Float())
+	}
+	return nil
+}
+
+/*   U n e x p o r t e d   */
+
+// isNumericType returns true if t is a numeric struct type, i.e. big.Int,
+// big.Float or a type registered with RegisterNumeric, or a pointer to one.
+func isNumericType(t reflect.Type) bool {
+	t = indirectType(t)
+	if t == bigIntType || t == bigFloatType {
+		return true
+	}
+	_, ok := numericConverter(t)
+	return ok
+}
+
+// indirectType returns the type t points to, if it is a pointer, else t.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// numericConverter returns the converter registered for type t, if any.
+func numericConverter(t reflect.Type) (NumericConverter, bool) {
+	numericsMu.RLock()
+	defer numericsMu.RUnlock()
+	convert, ok := numerics[t]
+	return convert, ok
+}
+
+// setNumeric sets the addressable numeric struct value v to x, see
+// isNumericType. It returns false if v is not of a numeric struct type.
+func setNumeric(v reflect.Value, x interface{}) (bool, error) {
+	switch v.Type() {
+	case bigIntType:
+		return true, setBigInt(v.Addr().Interface().(*big.Int), x)
+	case bigFloatType:
+		return true, setBigFloat(v.Addr().Interface().(*big.Float), x)
+	}
+	convert, ok := numericConverter(v.Type())
+	if !ok {
+		return false, nil
+	}
+	y, err := convert(x)
+	if err != nil {
+		return true, err
+	}
+	w := reflect.ValueOf(y)
+	if w.Kind() == reflect.Ptr && w.Type().Elem() == v.Type() && !w.IsNil() {
+		w = w.Elem()
+	}
+	if !w.IsValid() || w.Type() != v.Type() {
+		return true, errors.Errorf("invalid converted value of type %T; want: %s", y, v.Type())
+	}
+	v.Set(w)
+	return true, nil
+}
+
+// formatNumeric returns the text representation of v, if v is a big number: in
+// base 10, with the fewest digits needed to be parsed back exactly for big.Float.
+// It returns false if v is not a big number.
+func formatNumeric(v reflect.Value) (string, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return "", false
+	}
+	switch v.Type() {
+	case bigIntType:
+		x := v.Interface().(big.Int)
+		return x.String(), true
+	case bigFloatType:
+		x := v.Interface().(big.Float)
+		return x.Text('g', -1), true
+	}
+	return "", false
+}
+
+// setBigInt sets z to x, which can be a big number, an integer, a float whose
+// decimal part is lost, or a base 10 string.
+func setBigInt(z *big.Int, x interface{}) error {
+	switch y := x.(type) {
+	case big.Int:
+		z.Set(&y)
+		return nil
+	case *big.Int:
+		z.Set(y)
+		return nil
+	case big.Float:
+		y.Int(z)
+		return nil
+	case *big.Float:
+		y.Int(z)
+		return nil
+	case string:
+		if _, ok := z.SetString(y, 10); !ok {
+			return errors.Errorf("invalid big.Int value %q", y)
+		}
+		return nil
+	}
+	v := reflect.ValueOf(x)
+	switch {
+	case v.CanInt():
+		z.SetInt64(v.Int())
+	case v.CanUint():
+		z.SetUint64(v.Uint())
+	case v.CanFloat():
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return errors.Errorf("invalid big.Int value %v", v.Float())
+		}
+		big.NewFloat(v.Float()).Int(z)
+	default:
+		return errors.Errorf("cannot set big.Int to %T", x)
+	}
+	return nil
+}
+
+// setBigFloat sets z to x, which can be a big number, an integer, a float or a
+// string.
+func setBigFloat(z *big.Float, x interface{}) error {
+	switch y := x.(type) {
+	case big.Float:
+		z.Set(&y)
+		return nil
+	case *big.Float:
+		z.Set(y)
+		return nil
+	case big.Int:
+		z.SetInt(&y)
+		return nil
+	case *big.Int:
+		z.SetInt(y)
+		return nil
+	case string:
+		if _, ok := z.SetString(y); !ok {
+			return errors.Errorf("invalid big.Float value %q", y)
+		}
+		return nil
+	}
+	v := reflect.ValueOf(x)
+	switch {
+	case v.CanInt():
+		z.SetInt64(v.Int())
+	case v.CanUint():
+		z.SetUint64(v.Uint())
+	case v.CanFloat():
+		if math.IsNaN(v.Float()) {
+			return errors.New("invalid big.Float value NaN")
+		}
+		z.SetFloat64(v.Float())
+	default:
+		return errors.Errorf("cannot set big.Float to %T", x)
+	}
+	return nil
+}
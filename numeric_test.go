@@ -0,0 +1,152 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"math/big"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMoney struct {
+	cents int64
+}
+
+func TestNumericBig(t *testing.T) {
+	type testStruct struct {
+		Amount  *big.Int
+		Balance big.Int
+		Rate    *big.Float
+	}
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Amount")
+	assert.Equal(t, true, f.CanBigInt())
+	assert.Equal(t, false, f.CanBigFloat())
+	assert.Equal(t, false, f.CanStruct())
+	assert.Equal(t, big.NewInt(0), f.BigInt())
+	assert.Equal(t, nil, f.Set("123456789012345678901234567890"))
+	assert.Equal(t, "123456789012345678901234567890", ts.Amount.String())
+	assert.Equal(t, "123456789012345678901234567890", f.Get().(*big.Int).String())
+	assert.Equal(t, nil, f.Set(42))
+	assert.Equal(t, int64(42), ts.Amount.Int64())
+	assert.Equal(t, nil, f.Set(big.NewInt(7)))
+	assert.Equal(t, int64(7), ts.Amount.Int64())
+	assert.NotEqual(t, nil, f.Set("12.5"))
+
+	f = s.Field("Balance")
+	assert.Equal(t, true, f.CanBigInt())
+	assert.Equal(t, nil, f.Set(uint(10)))
+	assert.Equal(t, int64(10), ts.Balance.Int64())
+	assert.Equal(t, nil, f.Set(12.9))
+	assert.Equal(t, int64(12), f.BigInt().Int64())
+
+	f = s.Field("Rate")
+	assert.Equal(t, true, f.CanBigFloat())
+	assert.Equal(t, nil, f.Set("0.125"))
+	assert.Equal(t, "0.125", ts.Rate.String())
+	assert.Equal(t, nil, f.Set(big.NewInt(3)))
+	assert.Equal(t, "3", f.BigFloat().String())
+	assert.Equal(t, nil, f.Set(0.5))
+	assert.Equal(t, "0.5", f.Get().(*big.Float).String())
+	assert.NotEqual(t, nil, f.Set(true))
+
+	assert.Equal(t, []string{"Amount", "Balance", "Rate"}, s.Names(Recursive()))
+}
+
+func TestNumericRegistered(t *testing.T) {
+	type testStruct struct {
+		Price testMoney
+		Count int
+	}
+	RegisterNumeric(testMoney{}, func(x interface{}) (interface{}, error) {
+		switch x := x.(type) {
+		case int:
+			return testMoney{cents: int64(x) * 100}, nil
+		case float64:
+			return &testMoney{cents: int64(x * 100)}, nil
+		}
+		return "invalid", nil
+	})
+	defer RegisterNumeric(testMoney{}, nil)
+
+	ts := testStruct{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	f := s.Field("Price")
+	assert.Equal(t, false, f.CanStruct())
+	assert.Equal(t, nil, f.Set(3))
+	assert.Equal(t, testMoney{cents: 300}, ts.Price)
+	assert.Equal(t, nil, f.Set(1.25))
+	assert.Equal(t, testMoney{cents: 125}, ts.Price)
+	assert.NotEqual(t, nil, f.Set("oops"))
+	assert.Equal(t, testMoney{cents: 125}, f.Get())
+	assert.Equal(t, nil, f.Set(testMoney{cents: 1}))
+	assert.Equal(t, testMoney{cents: 1}, ts.Price)
+
+	RegisterNumeric(testMoney{}, nil)
+	assert.Equal(t, true, f.CanStruct())
+	err = f.Set(3)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, true, errors.Cause(err) != nil)
+}
+
+func TestNumericBigText(t *testing.T) {
+	type testStruct struct {
+		Amount  *big.Int   `form:"amount" env:"AMOUNT"`
+		Balance big.Int    `form:"balance" env:"BALANCE" default:"100"`
+		Rate    *big.Float `form:"rate" env:"RATE"`
+		Ratio   big.Rat    `form:"ratio" env:"RATIO"`
+	}
+
+	values := url.Values{
+		"amount":  {"123456789012345678901234567890"},
+		"balance": {"-42"},
+		"rate":    {"0.125"},
+		"ratio":   {"1/3"},
+	}
+
+	ts := testStruct{}
+	err := ScanFromValues(&ts, values, "form")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "123456789012345678901234567890", ts.Amount.String())
+	assert.Equal(t, int64(-42), ts.Balance.Int64())
+	assert.Equal(t, "0.125", ts.Rate.String())
+	assert.Equal(t, "1/3", ts.Ratio.String())
+
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, values, s.ToValues("form"))
+	assert.Equal(t, map[string]string{
+		"Amount":  "123456789012345678901234567890",
+		"Balance": "-42",
+		"Rate":    "0.125",
+		"Ratio":   "1/3",
+	}, s.ToStringMap())
+
+	env, err := ToEnv(&ts, "APP")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "123456789012345678901234567890", env["APP_AMOUNT"])
+	assert.Equal(t, "1/3", env["APP_RATIO"])
+
+	s.Freeze()
+	assert.Equal(t, "-42", s.ToStringMap()["Balance"])
+	assert.Equal(t, "1/3", s.ToStringMap()["Ratio"])
+
+	ts = testStruct{}
+	s, err = New(&ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.ApplyDefaults())
+	assert.Equal(t, int64(100), ts.Balance.Int64())
+
+	err = ScanFromValues(&ts, url.Values{"amount": {"12.5"}}, "form")
+	assert.NotEqual(t, nil, err)
+}
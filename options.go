@@ -0,0 +1,194 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// UnexportedAccess defines how the values of unexported fields can be accessed.
+type UnexportedAccess int
+
+// Access modes to unexported fields, see AllowUnexported.
+const (
+	UnexportedNone      UnexportedAccess = iota // Unexported fields are neither readable nor settable (default).
+	UnexportedRead                              // Unexported fields are readable.
+	UnexportedReadWrite                         // Unexported fields are readable and settable.
+)
+
+// EmbeddedMode defines how the fields of anonymous embedded structs are exposed.
+type EmbeddedMode int
+
+// Exposure modes of embedded struct fields, see SetEmbeddedMode.
+const (
+	EmbeddedPromote EmbeddedMode = iota // Embedded struct fields are promoted to the embedding struct (default).
+	EmbeddedPrefix                      // Same as EmbeddedPromote, with fields named after their embedded struct, e.g. "Base.ID".
+	EmbeddedKeep                        // Embedded structs are kept as single struct fields.
+)
+
+// Package-wide options, applying to StructValue objects when their fields are
+// first loaded.
+var (
+	skipUnexported bool
+	unexported     UnexportedAccess
+	embeddedMode   EmbeddedMode
+	timePrecision  time.Duration
+	floatEpsilon   float64
+	timeLocation   *time.Location
+	saturating     bool
+	optionsMu      sync.RWMutex
+)
+
+/*   F u n c t i o n s   */
+
+// SkipUnexported sets whether unexported fields are excluded from all structs,
+// i.e. from NumField, Fields, Names, Field, etc., matching encoding/json
+// semantics. By default, unexported fields are listed, even though getting or
+// setting their values fails.
+func SkipUnexported(skip bool) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	skipUnexported = skip
+}
+
+// AllowUnexported sets how unexported fields can be accessed, e.g. by debugging
+// and serialization tools. With UnexportedRead, getters such as Get, Value, As or
+// JSON work on unexported fields as they do on exported ones. UnexportedReadWrite
+// also allows setters. Access relies on package unsafe and only applies to fields
+// of addressable structs, i.e. structs passed by pointer to New.
+//
+// WARNING: Writing unexported fields bypasses the invariants their package may
+// maintain. Use with care.
+func AllowUnexported(access UnexportedAccess) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	unexported = access
+}
+
+// SetEmbeddedMode sets how the fields of anonymous embedded structs are exposed.
+// By default, like in Go, they are promoted to the embedding struct, as if they
+// were declared there. Since flattening is not always the desired view, mode
+// EmbeddedPrefix names promoted fields after their embedded struct, e.g. field
+// ID of embedded struct Base is named "Base.ID", while mode EmbeddedKeep keeps
+// embedded structs as single nested struct fields, e.g. field "Base".
+func SetEmbeddedMode(mode EmbeddedMode) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	embeddedMode = mode
+}
+
+// SetTimePrecision sets the precision to which time.Time values are truncated
+// before being compared, e.g. by Field.Equal, Diff or CompareDetailed, so that
+// timestamps round-tripped through a database storing microseconds or seconds
+// still match. Times are always compared with time.Time.Equal, i.e. regardless
+// of their locations and monotonic clock readings. A zero or negative precision,
+// the default, compares times to the nanosecond.
+func SetTimePrecision(d time.Duration) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	timePrecision = d
+}
+
+// SetFloatEpsilon sets the tolerance within which float32 and float64 values are
+// considered equal, e.g. by Field.Equal, Diff or CompareDetailed, so that values
+// round-tripped through text serialization or float32 conversions do not report
+// false differences. The tolerance is absolute: values a and b are equal when
+// |a-b| <= eps. A zero or negative epsilon, the default, requires exact equality.
+// Lookups, such as Contains, IndexOf or Replace, always match floats exactly.
+func SetFloatEpsilon(eps float64) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	floatEpsilon = eps
+}
+
+// SetTimeLocation sets the location to which time.Time values are converted
+// when set into fields, e.g. by Set, SetTime or ScanFromMap, so that structs
+// populated from mixed sources end up with consistent time zones, e.g. time.UTC.
+// Times parsed from text are converted too. A nil location, the default, keeps
+// times in their own location. See also StructField.SetTimeIn.
+func SetTimeLocation(loc *time.Location) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	timeLocation = loc
+}
+
+// SetSaturating sets whether numbers out of the range of the numeric field they
+// are set into, e.g. 300 into an int8 field or -1 into a uint field, are clamped
+// to the closest value of the field type, i.e. 127 and 0, instead of returning
+// an OverflowError, the default. Likewise, floats set into integer fields have
+// their fractional part truncated, e.g. 3.7 into 3, instead of returning
+// ErrFractional. NaN is never clamped into integers.
+func SetSaturating(saturate bool) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	saturating = saturate
+}
+
+/*   U n e x p o r t e d   */
+
+// isSkipUnexported returns true if unexported fields are to be excluded.
+func isSkipUnexported() bool {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return skipUnexported
+}
+
+// unexportedAccess returns how unexported fields can be accessed.
+func unexportedAccess() UnexportedAccess {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return unexported
+}
+
+// unlock returns a copy of value v of an unexported field, which can be read
+// and set, if access to unexported fields is allowed. Otherwise, or if v is not
+// addressable, v is returned as is.
+func unlock(v reflect.Value, sf reflect.StructField) reflect.Value {
+	if sf.PkgPath == "" || !v.CanAddr() || unexportedAccess() == UnexportedNone {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// getEmbeddedMode returns how the fields of embedded structs are exposed.
+func getEmbeddedMode() EmbeddedMode {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return embeddedMode
+}
+
+// getTimePrecision returns the precision to which times are compared.
+func getTimePrecision() time.Duration {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return timePrecision
+}
+
+// getFloatEpsilon returns the tolerance within which floats are equal.
+func getFloatEpsilon() float64 {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return floatEpsilon
+}
+
+// normalizeTime returns t in the location set by SetTimeLocation, if any.
+func normalizeTime(t time.Time) time.Time {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	if timeLocation == nil {
+		return t
+	}
+	return t.In(timeLocation)
+}
+
+// isSaturating returns true if out of range numbers are clamped.
+func isSaturating() bool {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return saturating
+}
@@ -0,0 +1,176 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipUnexported(t *testing.T) {
+	type Base struct {
+		ID     int
+		secret string
+	}
+	type Server struct {
+		Base
+		Name   string
+		hidden bool
+		Port   int
+	}
+
+	server := Server{Base: Base{ID: 1, secret: "pwd"}, Name: "Roninzo", Port: 80}
+
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, s.NumField())
+	assert.Equal(t, []string{"ID", "secret", "Name", "hidden", "Port"}, s.Fields().Names())
+
+	SkipUnexported(true)
+	defer SkipUnexported(false)
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, s.NumField())
+	assert.Equal(t, []string{"ID", "Name", "Port"}, s.Fields().Names())
+	assert.Equal(t, []int{0, 1, 2}, []int{s.Field("ID").Index(), s.Field("Name").Index(), s.Field("Port").Index()})
+	assert.Equal(t, (*StructField)(nil), s.Field("hidden"))
+}
+
+func TestAllowUnexported(t *testing.T) {
+	type Server struct {
+		Name   string
+		secret string
+		port   int
+	}
+
+	server := Server{Name: "Roninzo", secret: "pwd", port: 80}
+
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Field("secret").Get())
+	assert.EqualError(t, s.Field("secret").Set("abc"), "could not set field Server.secret: struct field is not settable")
+
+	AllowUnexported(UnexportedRead)
+	defer AllowUnexported(UnexportedNone)
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "pwd", s.Field("secret").Get())
+	assert.Equal(t, int64(80), s.Field("port").Int())
+	assert.Equal(t, false, s.Field("secret").CanSet())
+	assert.EqualError(t, s.Field("secret").Set("abc"), "could not set field Server.secret: struct field is not settable")
+	data, err := s.Field("port").JSON()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "80", string(data))
+
+	AllowUnexported(UnexportedReadWrite)
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, s.Field("secret").CanSet())
+	assert.Equal(t, nil, s.Field("secret").Set("abc"))
+	assert.Equal(t, nil, s.Field("port").Set("8080"))
+	assert.Equal(t, "abc", server.secret)
+	assert.Equal(t, 8080, server.port)
+}
+
+func TestSetTimePrecision(t *testing.T) {
+	type Event struct {
+		At time.Time
+	}
+
+	now := time.Now() // carries a monotonic clock reading
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		paris = time.FixedZone("CET", 3600)
+	}
+	a := Event{At: now}
+	b := Event{At: now.Round(0).In(paris)}
+
+	s1, err := New(&a)
+	assert.Equal(t, nil, err)
+	s2, err := New(&b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, s1.Field("At").Equal(s2.Field("At")))
+
+	b.At = now.Add(500 * time.Microsecond)
+	assert.Equal(t, false, s1.Field("At").Equal(s2.Field("At")))
+
+	SetTimePrecision(time.Second)
+	defer SetTimePrecision(0)
+	a.At = time.Date(2021, 6, 1, 12, 0, 0, 100, time.UTC)
+	b.At = time.Date(2021, 6, 1, 12, 0, 0, 900000, time.UTC)
+	assert.Equal(t, true, s1.Field("At").Equal(s2.Field("At")))
+	b.At = time.Date(2021, 6, 1, 12, 0, 1, 0, time.UTC)
+	assert.Equal(t, false, s1.Field("At").Equal(s2.Field("At")))
+}
+
+func TestSetFloatEpsilon(t *testing.T) {
+	type Measure struct {
+		Value float64
+		Ratio float32
+	}
+
+	x, y := 0.1, 0.2
+	a := Measure{Value: x + y, Ratio: 0.3}
+	b := Measure{Value: 0.3, Ratio: float32(0.30000001)}
+
+	s1, err := New(&a)
+	assert.Equal(t, nil, err)
+	s2, err := New(&b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, s1.Field("Value").Equal(s2.Field("Value")))
+	diffs, err := s1.Diff(s2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(diffs))
+
+	SetFloatEpsilon(1e-9)
+	defer SetFloatEpsilon(0)
+	assert.Equal(t, true, s1.Field("Value").Equal(s2.Field("Value")))
+	assert.Equal(t, true, s1.Field("Ratio").Equal(s2.Field("Ratio")))
+	diffs, err = s1.Diff(s2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(diffs))
+	assert.Equal(t, OutOfRange, s1.Contains(0.3))
+	assert.Equal(t, false, s1.ContainsDeep(0.3))
+	r, err := Replace(&a, 0.3, 0.5, -1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, x+y, r.(*Measure).Value)
+	assert.Equal(t, 0, s2.Contains(0.3))
+
+	b.Value = 0.3001
+	assert.Equal(t, false, s1.Field("Value").Equal(s2.Field("Value")))
+}
+
+func TestSetTimeLocation(t *testing.T) {
+	type Event struct {
+		At      time.Time
+		Updated *time.Time
+		Local   time.Time
+	}
+
+	cet := time.FixedZone("CET", 3600)
+	at := time.Date(2021, 6, 1, 12, 0, 0, 0, cet)
+	e := Event{}
+	s, err := New(&e)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, s.Field("At").Set(at))
+	assert.Equal(t, cet, e.At.Location())
+
+	SetTimeLocation(time.UTC)
+	defer SetTimeLocation(nil)
+	assert.Equal(t, nil, s.Field("At").Set(at))
+	assert.Equal(t, time.UTC, e.At.Location())
+	assert.Equal(t, true, at.Equal(e.At))
+	assert.Equal(t, nil, s.Field("Updated").Set(at))
+	assert.Equal(t, time.UTC, e.Updated.Location())
+	assert.Equal(t, nil, s.Field("At").Set("2021-06-01T14:00:00+02:00"))
+	assert.Equal(t, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC), e.At)
+
+	e.At = time.Time{}
+	s.Field("At").SetTime(at)
+	assert.Equal(t, time.UTC, e.At.Location())
+
+	s.Field("Local").SetTimeIn(at, cet)
+	assert.Equal(t, cet, e.Local.Location())
+	assert.Equal(t, true, at.Equal(e.Local))
+}
@@ -0,0 +1,261 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   S t r u c t   d e f i n i t i o n   */
+
+// OverflowError reports a number that cannot be represented by the numeric type
+// of the field it is set into, e.g. 300 into an int8 field, -1 into a uint field
+// or 1e20 into an int64 field. See SetSaturating to clamp such numbers instead.
+type OverflowError struct {
+	Field string       // Path of the field, such as "Program.Port", see Namespace.
+	Value interface{}  // Number, or text, that overflowed.
+	Type  reflect.Type // Type of the field.
+}
+
+/*   F u n c t i o n s   */
+
+// Error implements the error interface. Since setters wrap it with the name of
+// the field, the message only mentions the value and type.
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %v overflows %s", e.Value, e.Type)
+}
+
+/*   U n e x p o r t e d   */
+
+// overflow returns an OverflowError for number, or text, x set into value v of
+// field f.
+func (f *StructField) overflow(v reflect.Value, x interface{}) error {
+	return &OverflowError{Field: f.Namespace(), Value: x, Type: v.Type()}
+}
+
+// setInt sets the int value v to the number x, i.e. an int, a uint or a float
+// without fractional part. Out of range numbers are clamped, and fractional
+// parts truncated, when saturating, except NaN.
+func (f *StructField) setInt(v, x reflect.Value) error {
+	n, ok := intOf(x, v.Type().Bits())
+	if !ok && (!isSaturating() || isNaN(x)) {
+		return f.overflow(v, x.Interface())
+	}
+	if err := fractional(v, x); err != nil {
+		return err
+	}
+	v.SetInt(n)
+	return nil
+}
+
+// setUint sets the uint value v to the number x, see setInt.
+func (f *StructField) setUint(v, x reflect.Value) error {
+	n, ok := uintOf(x, v.Type().Bits())
+	if !ok && (!isSaturating() || isNaN(x)) {
+		return f.overflow(v, x.Interface())
+	}
+	if err := fractional(v, x); err != nil {
+		return err
+	}
+	v.SetUint(n)
+	return nil
+}
+
+// setFloat sets the float value v to the number x, see setInt. Infinities and
+// NaN are not out of range.
+func (f *StructField) setFloat(v, x reflect.Value) error {
+	var n float64
+	switch {
+	case utils.CanInt(x):
+		n = float64(x.Int())
+	case utils.CanUint(x):
+		n = float64(x.Uint())
+	default:
+		n = x.Float()
+	}
+	n, ok := floatOf(n, v.Type().Bits())
+	if !ok && !isSaturating() {
+		return f.overflow(v, x.Interface())
+	}
+	v.SetFloat(n)
+	return nil
+}
+
+// setComplex sets the complex value v to the complex number x, see setFloat.
+func (f *StructField) setComplex(v, x reflect.Value) error {
+	c := x.Complex()
+	bits := v.Type().Bits() / 2
+	re, okRe := floatOf(real(c), bits)
+	im, okIm := floatOf(imag(c), bits)
+	if !(okRe && okIm) && !isSaturating() {
+		return f.overflow(v, c)
+	}
+	v.SetComplex(complex(re, im))
+	return nil
+}
+
+// parseInt parses text x into the int value v, see setInt.
+func (f *StructField) parseInt(v reflect.Value, x string) error {
+	n, err := strconv.ParseInt(x, 10, v.Type().Bits())
+	if errors.Is(err, strconv.ErrRange) {
+		if !isSaturating() {
+			return f.overflow(v, x)
+		}
+		err = nil // n is clamped
+	}
+	if err != nil {
+		return err
+	}
+	v.SetInt(n)
+	return nil
+}
+
+// parseUint parses text x into the uint value v, see setUint. Negative numbers
+// are out of range.
+func (f *StructField) parseUint(v reflect.Value, x string) error {
+	n, err := strconv.ParseUint(x, 10, v.Type().Bits())
+	if errors.Is(err, strconv.ErrSyntax) && len(x) > 1 && x[0] == '-' {
+		if _, e := strconv.ParseUint(x[1:], 10, 64); e == nil || errors.Is(e, strconv.ErrRange) {
+			n, err = 0, strconv.ErrRange
+		}
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		if !isSaturating() {
+			return f.overflow(v, x)
+		}
+		err = nil // n is clamped
+	}
+	if err != nil {
+		return err
+	}
+	v.SetUint(n)
+	return nil
+}
+
+// parseFloat parses text x into the float value v, see setFloat.
+func (f *StructField) parseFloat(v reflect.Value, x string) error {
+	n, err := strconv.ParseFloat(x, v.Type().Bits())
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(n, 0) {
+		if !isSaturating() {
+			return f.overflow(v, x)
+		}
+		n, err = math.Copysign(maxFloat(v.Type().Bits()), n), nil
+	}
+	if err != nil && !errors.Is(err, strconv.ErrRange) { // i.e. underflows to zero
+		return err
+	}
+	v.SetFloat(n)
+	return nil
+}
+
+// intOf returns the number x as a signed integer of size bits, or the closest
+// one and false if it is out of range.
+func intOf(x reflect.Value, bits int) (int64, bool) {
+	lo, hi := int64(-1)<<(bits-1), int64(1)<<(bits-1)-1
+	switch {
+	case utils.CanInt(x):
+		n := x.Int()
+		if n < lo {
+			return lo, false
+		}
+		if n > hi {
+			return hi, false
+		}
+		return n, true
+	case utils.CanUint(x):
+		n := x.Uint()
+		if n > uint64(hi) {
+			return hi, false
+		}
+		return int64(n), true
+	}
+	n := math.Trunc(x.Float())
+	switch {
+	case math.IsNaN(n):
+		return 0, false
+	case n < float64(lo):
+		return lo, false
+	case n >= -float64(lo): // i.e. hi+1, exactly representable
+		return hi, false
+	}
+	return int64(n), true
+}
+
+// uintOf returns the number x as an unsigned integer of size bits, or the
+// closest one and false if it is out of range.
+func uintOf(x reflect.Value, bits int) (uint64, bool) {
+	hi := uint64(math.MaxUint64) >> (64 - bits)
+	switch {
+	case utils.CanInt(x):
+		n := x.Int()
+		if n < 0 {
+			return 0, false
+		}
+		if uint64(n) > hi {
+			return hi, false
+		}
+		return uint64(n), true
+	case utils.CanUint(x):
+		n := x.Uint()
+		if n > hi {
+			return hi, false
+		}
+		return n, true
+	}
+	n := math.Trunc(x.Float())
+	switch {
+	case math.IsNaN(n), n < 0:
+		return 0, false
+	case n >= math.Ldexp(1, bits): // i.e. hi+1, exactly representable
+		return hi, false
+	}
+	return uint64(n), true
+}
+
+// fractional returns ErrFractional if the number x is a float with a fractional
+// part, which would be lost into the integer value v, unless saturating.
+func fractional(v, x reflect.Value) error {
+	if !utils.CanFloat(x) || isSaturating() {
+		return nil
+	}
+	if n := x.Float(); n != math.Trunc(n) {
+		return errors.Wrapf(ErrFractional, "value %v into %s", n, v.Type())
+	}
+	return nil
+}
+
+// floatOf returns the number n as a float of size bits, or the closest one and
+// false if it is out of range.
+func floatOf(n float64, bits int) (float64, bool) {
+	hi := maxFloat(bits)
+	switch {
+	case math.IsInf(n, 0), math.IsNaN(n):
+		return n, true
+	case n > hi:
+		return hi, false
+	case n < -hi:
+		return -hi, false
+	}
+	return n, true
+}
+
+// maxFloat returns the largest finite float of size bits.
+func maxFloat(bits int) float64 {
+	if bits == 32 {
+		return math.MaxFloat32
+	}
+	return math.MaxFloat64
+}
+
+// isNaN returns true if reflect value x is a float NaN.
+func isNaN(x reflect.Value) bool {
+	return utils.CanFloat(x) && math.IsNaN(x.Float())
+}
@@ -0,0 +1,135 @@
+package structs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNumbers struct {
+	Int8    int8
+	Int64   int64
+	Uint8   uint8
+	Uint64  uint64
+	Float32 float32
+	Complex complex64
+	Ptr     *int16
+}
+
+func TestOverflow(t *testing.T) {
+	ts := testNumbers{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		name string
+		x    interface{}
+	}{
+		{"Int8", 128},
+		{"Int8", -129},
+		{"Int8", uint(200)},
+		{"Int8", 127.9 + 1},
+		{"Int8", math.NaN()},
+		{"Int8", "300"},
+		{"Int64", uint64(math.MaxUint64)},
+		{"Int64", 1e20},
+		{"Int64", math.Inf(-1)},
+		{"Uint8", -1},
+		{"Uint8", 256},
+		{"Uint8", -0.5 - 1},
+		{"Uint8", "-1"},
+		{"Uint8", "256"},
+		{"Uint64", 1.8446744073709552e19},
+		{"Float32", 1e39},
+		{"Float32", "1e39"},
+		{"Complex", complex(1e39, 0)},
+		{"Ptr", 40000},
+	}
+	for _, tt := range tests {
+		err := s.Field(tt.name).Set(tt.x)
+		var overflow *OverflowError
+		if assert.Equal(t, true, errors.As(err, &overflow), "%s <- %v", tt.name, tt.x) {
+			assert.Equal(t, tt.name, overflow.Field)
+		}
+	}
+	assert.Equal(t, int16(0), *ts.Ptr)
+	ts.Ptr = nil
+	assert.Equal(t, testNumbers{}, ts)
+
+	assert.Equal(t, nil, s.Field("Int8").Set(uint(127)))
+	assert.Equal(t, int8(127), ts.Int8)
+	assert.Equal(t, nil, s.Field("Int8").Set(-128.0))
+	assert.Equal(t, int8(-128), ts.Int8)
+	assert.Equal(t, ErrFractional, errors.Cause(s.Field("Int8").Set(3.7)))
+	assert.Equal(t, ErrFractional, errors.Cause(s.Field("Uint8").Set(float32(0.5))))
+	assert.Equal(t, int8(-128), ts.Int8)
+	assert.Equal(t, uint8(0), ts.Uint8)
+	assert.Equal(t, nil, s.Field("Int64").Set(uint64(math.MaxInt64)))
+	assert.Equal(t, int64(math.MaxInt64), ts.Int64)
+	assert.Equal(t, nil, s.Field("Uint8").Set(int64(255)))
+	assert.Equal(t, uint8(255), ts.Uint8)
+	assert.Equal(t, nil, s.Field("Uint64").Set(1.8446744073709550e19))
+	assert.Equal(t, nil, s.Field("Float32").Set(math.Inf(1)))
+	assert.Equal(t, true, math.IsInf(float64(ts.Float32), 1))
+	assert.Equal(t, nil, s.Field("Float32").Set("1e-50"))
+	assert.Equal(t, float32(0), ts.Float32)
+
+	s.Field("Int8").SetInt(1000)
+	assert.Equal(t, int8(-128), ts.Int8)
+	err = s.Err()
+	assert.Equal(t, reflect.TypeOf(int8(0)), errors.Cause(err).(*OverflowError).Type)
+	assert.Equal(t, "invalid value for field testNumbers.Int8: value 1000 overflows int8", err.Error())
+
+	type Pair struct {
+		Left  testNumbers
+		Right testNumbers
+	}
+	s, err = New(&Pair{})
+	assert.Equal(t, nil, err)
+	err = s.Field("Right").Struct().Field("Int8").Set(1000)
+	var overflow *OverflowError
+	if assert.Equal(t, true, errors.As(err, &overflow)) {
+		assert.Equal(t, "Right.Int8", overflow.Field)
+	}
+}
+
+func TestSaturating(t *testing.T) {
+	SetSaturating(true)
+	defer SetSaturating(false)
+
+	ts := testNumbers{}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, s.Field("Int8").Set(1000))
+	assert.Equal(t, int8(127), ts.Int8)
+	assert.Equal(t, nil, s.Field("Int8").Set(-128.7))
+	assert.Equal(t, int8(-128), ts.Int8)
+	assert.Equal(t, nil, s.Field("Uint8").Set(3.7))
+	assert.Equal(t, uint8(3), ts.Uint8)
+	assert.Equal(t, nil, s.Field("Int8").Set("-1000"))
+	assert.Equal(t, int8(-128), ts.Int8)
+	assert.Equal(t, nil, s.Field("Int64").Set(math.Inf(1)))
+	assert.Equal(t, int64(math.MaxInt64), ts.Int64)
+	assert.Equal(t, nil, s.Field("Uint8").Set(-5))
+	assert.Equal(t, uint8(0), ts.Uint8)
+	assert.Equal(t, nil, s.Field("Uint8").Set("-5"))
+	assert.Equal(t, uint8(0), ts.Uint8)
+	assert.Equal(t, nil, s.Field("Uint64").Set(1e30))
+	assert.Equal(t, uint64(math.MaxUint64), ts.Uint64)
+	assert.Equal(t, nil, s.Field("Float32").Set(-1e39))
+	assert.Equal(t, float32(-math.MaxFloat32), ts.Float32)
+	assert.Equal(t, nil, s.Field("Float32").Set("1e39"))
+	assert.Equal(t, float32(math.MaxFloat32), ts.Float32)
+	assert.Equal(t, nil, s.Field("Ptr").Set(uint64(1<<40)))
+	assert.Equal(t, int16(math.MaxInt16), *ts.Ptr)
+	s.Field("Uint8").SetUint(300)
+	assert.Equal(t, uint8(255), ts.Uint8)
+	assert.Equal(t, nil, s.Err())
+
+	var overflow *OverflowError
+	assert.Equal(t, true, errors.As(s.Field("Int8").Set(math.NaN()), &overflow))
+}
@@ -0,0 +1,253 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   S t r u c t   d e f i n i t i o n   */
+
+// pathSegment is a field name of a path, along with the element indexes or map
+// keys following it, e.g. "Items" and ["2"] for "Items[2]".
+type pathSegment struct {
+	name string
+	keys []string
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Get returns the value of the field found at path, such as "Program.Name",
+// "Programs[2].Name" or "Labels[env]", i.e. the names of the nested struct
+// fields leading to it, separated by dots, with element indexes of slice and
+// array fields, or keys of map fields, in brackets. The value is returned as by
+// StructField.Get and nil pointers return nil.
+// Contrary to Field, Get never saves errors in StructValue and does not alter
+// the struct, so that it can be used as a single lookup call.
+// Unexported struct fields will return an error.
+func (s *StructValue) Get(path string) (interface{}, error) {
+	f, err := s.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	if !f.isReadable() {
+		return nil, errors.Wrapf(ErrNotExported, "could not get value of field %s", path)
+	}
+	if utils.CanPtr(f.value) && f.value.IsNil() {
+		return nil, nil
+	}
+	return f.Get(), nil
+}
+
+// SetPath sets the field found at path, such as "Program.Name", "Items[2].Name"
+// or "Labels[env]", see Get, to dest, following the same rules as Set. Nil
+// pointers to nested structs and nil maps met along the way are allocated, and
+// map entries are created if missing, while slice indexes must be in range.
+// Unsettable struct fields will return an error.
+func (s *StructValue) SetPath(path string, dest interface{}) error {
+	ctx := fmt.Sprintf("could not set field %s", path)
+	if s.IsFrozen() {
+		return errors.Wrap(ErrReadOnly, ctx)
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	f, ok := s.fieldByName(segments[0].name)
+	if !ok {
+		return errors.Wrapf(ErrNoField, "could not get field %s", segments[0].name)
+	}
+	return f.setPath(segments[0].keys, segments[1:], dest)
+}
+
+/*   U n e x p o r t e d   */
+
+// lookup returns the field found at path, see Get, without saving errors in
+// StructValue.
+func (s *StructValue) lookup(path string) (*StructField, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	var f *StructField
+	for i, segment := range segments {
+		if i > 0 {
+			if !isStructValue(f.value) {
+				return nil, errors.Errorf("could not get field %s of %s: not a struct", segment.name, f.Namespace())
+			}
+			if isNilPath(f.value) {
+				return nil, errors.Wrapf(ErrNoStruct, "could not get field %s of nil %s", segment.name, f.Namespace())
+			}
+			s = f.nested()
+		}
+		next, ok := s.fieldByName(segment.name)
+		if !ok {
+			return nil, errors.Wrapf(ErrNoField, "could not get field %s", joinPath(segments[:i+1]))
+		}
+		f = next
+		for _, key := range segment.keys {
+			if f, err = f.lookupKey(key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f, nil
+}
+
+// lookupKey returns a handle on the element of the slice or array field at index
+// key, or on the entry key of the map field, parsed as text of the map key type.
+func (f *StructField) lookupKey(key string) (*StructField, error) {
+	v := reflect.Indirect(f.value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.Errorf("invalid index %q of field %s", key, f.Namespace())
+		}
+		return f.elem(i)
+	case reflect.Map:
+		k := reflect.New(v.Type().Key()).Elem()
+		if err := f.parseString(k, key); err != nil {
+			return nil, errors.Wrapf(err, "invalid key %q of field %s", key, f.Namespace())
+		}
+		x := v.MapIndex(k)
+		if !x.IsValid() {
+			return nil, errors.Wrapf(ErrNoField, "could not get key %s of field %s", key, f.Namespace())
+		}
+		return f.elemOf(x, key), nil
+	}
+	return nil, errors.Errorf("could not get element %s of field %s: not a slice, array or map", key, f.Namespace())
+}
+
+// setPath sets the value found at the element indexes or map keys of the field,
+// followed by the nested fields of segments, to dest, see SetPath.
+func (f *StructField) setPath(keys []string, segments []pathSegment, dest interface{}) error {
+	switch {
+	case len(keys) > 0:
+		v := f.value
+		if utils.CanPtr(v) {
+			if v.IsNil() && !v.CanSet() {
+				return errors.Wrapf(ErrNotSettable, "could not set field %s", f.FullName())
+			}
+			v = utils.Preset(v)
+		}
+		if v.Kind() != reflect.Map {
+			e, err := f.lookupKey(keys[0])
+			if err != nil {
+				return err
+			}
+			return e.setPath(keys[1:], segments, dest)
+		}
+		k := reflect.New(v.Type().Key()).Elem()
+		if err := f.parseString(k, keys[0]); err != nil {
+			return errors.Wrapf(err, "invalid key %q of field %s", keys[0], f.Namespace())
+		}
+		// Map entries are not addressable: set a copy, then store it.
+		e := f.elemOf(reflect.New(v.Type().Elem()).Elem(), keys[0])
+		if x := v.MapIndex(k); x.IsValid() {
+			e.value.Set(x)
+		}
+		if err := e.setPath(keys[1:], segments, dest); err != nil {
+			return err
+		}
+		if !v.CanSet() {
+			return errors.Wrapf(ErrNotSettable, "could not set field %s", f.FullName())
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(k, e.value)
+		return nil
+	case len(segments) > 0:
+		if !isStructValue(f.value) {
+			return errors.Errorf("could not get field %s of %s: not a struct", segments[0].name, f.Namespace())
+		}
+		if isNilPath(f.value) {
+			if !f.value.CanSet() {
+				return errors.Wrapf(ErrNotSettable, "could not set field %s", f.FullName())
+			}
+			utils.Preset(f.value)
+		}
+		next, ok := f.nested().fieldByName(segments[0].name)
+		if !ok {
+			return errors.Wrapf(ErrNoField, "could not get field %s of %s", segments[0].name, f.Namespace())
+		}
+		return next.setPath(segments[0].keys, segments[1:], dest)
+	}
+	return f.Set(dest)
+}
+
+// nested returns the nested struct of the field, like Struct, without saving
+// errors in StructValue.
+func (f *StructField) nested() *StructValue {
+	s := IndirectStruct(f.value)
+	s.Parent = f.Parent
+	s.parentField = f
+	return s
+}
+
+// parsePath splits path into its segments, e.g. "Items[2].Tags[env]" into
+// {"Items", ["2"]} and {"Tags", ["env"]}. Map keys may contain dots.
+func parsePath(path string) ([]pathSegment, error) {
+	segments := make([]pathSegment, 0)
+	rest := path
+	for {
+		i := strings.IndexAny(rest, ".[")
+		if i < 0 {
+			i = len(rest)
+		}
+		segment := pathSegment{name: rest[:i]}
+		if segment.name == "" {
+			return nil, errors.Errorf("invalid path %q", path)
+		}
+		rest = rest[i:]
+		for strings.HasPrefix(rest, "[") {
+			j := strings.IndexByte(rest, ']')
+			if j < 0 {
+				return nil, errors.Errorf("invalid path %q: missing ]", path)
+			}
+			segment.keys = append(segment.keys, rest[1:j])
+			rest = rest[j+1:]
+		}
+		segments = append(segments, segment)
+		if rest == "" {
+			return segments, nil
+		}
+		if rest[0] != '.' {
+			return nil, errors.Errorf("invalid path %q", path)
+		}
+		rest = rest[1:]
+	}
+}
+
+// joinPath renders segments back into a path.
+func joinPath(segments []pathSegment) string {
+	names := make([]string, len(segments))
+	for i, segment := range segments {
+		names[i] = segment.name
+		for _, key := range segment.keys {
+			names[i] += "[" + key + "]"
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// isStructValue returns true if v is a nested struct, or a pointer to one, i.e.
+// not a single value such as time.Time, see isScalarStruct.
+func isStructValue(v reflect.Value) bool {
+	t := indirectType(v.Type())
+	return t.Kind() == reflect.Struct && !isScalarStruct(t)
+}
+
+// isNilPath returns true if v is a nil pointer.
+func isNilPath(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
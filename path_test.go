@@ -0,0 +1,173 @@
+package structs
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPathProgram struct {
+	Name    string
+	Version *string
+}
+
+type testPathServer struct {
+	Name     string
+	Program  *testPathProgram
+	Programs []testPathProgram
+	Labels   map[string]string
+	Ports    map[int]*testPathProgram
+	Matrix   [2][]int
+	secret   string
+}
+
+func TestGet(t *testing.T) {
+	version := "2.4"
+	server := testPathServer{
+		Name:     "web",
+		Program:  &testPathProgram{Name: "Apache", Version: &version},
+		Programs: []testPathProgram{{Name: "nginx"}, {Name: "redis"}},
+		Labels:   map[string]string{"env": "prod", "app.kubernetes.io/name": "web"},
+		Ports:    map[int]*testPathProgram{80: {Name: "http"}},
+		Matrix:   [2][]int{{1, 2}, {3}},
+		secret:   "s3cr3t",
+	}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"Name", "web"},
+		{"Program.Name", "Apache"},
+		{"Program.Version", "2.4"},
+		{"Programs[1].Name", "redis"},
+		{"Programs[0].Version", nil},
+		{"Labels[env]", "prod"},
+		{"Labels[app.kubernetes.io/name]", "web"},
+		{"Ports[80].Name", "http"},
+		{"Matrix[1][0]", int64(3)},
+	}
+	for _, tt := range tests {
+		got, err := s.Get(tt.path)
+		assert.Equal(t, nil, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+
+	for path, cause := range map[string]error{
+		"Nope":              ErrNoField,
+		"Program.Nope":      ErrNoField,
+		"Programs[2].Name":  ErrOutOfRange,
+		"Labels[region]":    ErrNoField,
+		"secret":            ErrNotExported,
+		"Ports[443].Name":   ErrNoField,
+		"Name.Length":       nil,
+		"Programs[x]":       nil,
+		"Programs[0":        nil,
+		"Program..Name":     nil,
+		"Ports[http]":       nil,
+		"Name[0]":           nil,
+		"":                  nil,
+		"Matrix[1][0].Name": nil,
+	} {
+		_, err := s.Get(path)
+		if assert.NotEqual(t, nil, err, path) && cause != nil {
+			assert.Equal(t, cause, errors.Cause(err), path)
+		}
+	}
+
+	server.Program = nil
+	_, err = s.Get("Program.Name")
+	assert.Equal(t, ErrNoStruct, errors.Cause(err))
+	got, err := s.Get("Program")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, got)
+
+	assert.Equal(t, nil, s.Err())
+	assert.Equal(t, "web", server.Name)
+}
+
+func TestSetPath(t *testing.T) {
+	type testPathItem struct {
+		Name  string
+		Count int
+	}
+	type testPathOrder struct {
+		ID       int
+		Server   *testPathServer
+		Items    []testPathItem
+		Extras   *[]testPathItem
+		ByName   map[string]testPathItem
+		Counts   map[int]int
+		Shipping struct {
+			Address *struct {
+				City string
+			}
+		}
+	}
+
+	o := testPathOrder{Items: make([]testPathItem, 3)}
+	s, err := New(&o)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, s.SetPath("ID", "42"))
+	assert.Equal(t, 42, o.ID)
+	assert.Equal(t, nil, s.SetPath("Server.Program.Name", "Apache"))
+	assert.Equal(t, "Apache", o.Server.Program.Name)
+	assert.Equal(t, nil, s.SetPath("Server.Program.Version", "2.4"))
+	assert.Equal(t, "2.4", *o.Server.Program.Version)
+	assert.Equal(t, nil, s.SetPath("Items[2].Name", "x"))
+	assert.Equal(t, "x", o.Items[2].Name)
+	assert.Equal(t, nil, s.SetPath("ByName[x].Count", 5))
+	assert.Equal(t, nil, s.SetPath("ByName[x].Name", "x"))
+	assert.Equal(t, testPathItem{Name: "x", Count: 5}, o.ByName["x"])
+	assert.Equal(t, nil, s.SetPath("Counts[7]", "3"))
+	assert.Equal(t, map[int]int{7: 3}, o.Counts)
+	assert.Equal(t, nil, s.SetPath("Server.Ports[80].Name", "http"))
+	assert.Equal(t, "http", o.Server.Ports[80].Name)
+	assert.Equal(t, nil, s.SetPath("Server.Labels[app.kubernetes.io/name]", "web"))
+	assert.Equal(t, "web", o.Server.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, nil, s.SetPath("Shipping.Address.City", "Paris"))
+	assert.Equal(t, "Paris", o.Shipping.Address.City)
+
+	got, err := s.Get("Server.Ports[80].Name")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "http", got)
+
+	assert.Equal(t, ErrOutOfRange, errors.Cause(s.SetPath("Items[3].Name", "y")))
+	assert.Equal(t, ErrOutOfRange, errors.Cause(s.SetPath("Extras[0].Name", "y")))
+	assert.Equal(t, ErrNoField, errors.Cause(s.SetPath("Items[0].Nope", "y")))
+	assert.Equal(t, ErrNoField, errors.Cause(s.SetPath("Nope", "y")))
+	assert.Equal(t, ErrNotSettable, errors.Cause(s.SetPath("Server.secret", "y")))
+	assert.NotEqual(t, nil, s.SetPath("Counts[x]", 1))
+	assert.NotEqual(t, nil, s.SetPath("ID.Value", 1))
+	assert.NotEqual(t, nil, s.SetPath("Items[0].Count", "many"))
+	assert.NotEqual(t, nil, s.SetPath("Items[", 1))
+
+	s.Freeze()
+	assert.Equal(t, ErrReadOnly, errors.Cause(s.SetPath("ID", 1)))
+	assert.Equal(t, 42, o.ID)
+}
+
+func TestSetPathTextKeys(t *testing.T) {
+	type testPathHosts struct {
+		Names map[netip.Addr]string
+	}
+
+	h := testPathHosts{}
+	s, err := New(&h)
+	assert.Equal(t, nil, err)
+
+	addr := netip.MustParseAddr("192.168.0.1")
+	assert.Equal(t, nil, s.SetPath("Names[192.168.0.1]", "web"))
+	assert.Equal(t, map[netip.Addr]string{addr: "web"}, h.Names)
+
+	got, err := s.Get("Names[192.168.0.1]")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "web", got)
+
+	assert.NotEqual(t, nil, s.SetPath("Names[localhost]", "db"))
+}
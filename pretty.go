@@ -0,0 +1,174 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// PrettyOption configures how structs are rendered by Pretty.
+type PrettyOption func(*prettyOptions)
+
+// prettyOptions holds the configuration of Pretty.
+type prettyOptions struct {
+	color  bool   // Whether tokens are highlighted with ANSI escape codes.
+	indent string // Indentation of each nesting level.
+}
+
+// prettyPrinter renders a stream of json tokens into buf.
+type prettyPrinter struct {
+	buf  bytes.Buffer
+	dec  *json.Decoder
+	opts *prettyOptions
+}
+
+// ANSI escape codes highlighting Pretty tokens.
+const (
+	colorReset  = "\x1b[0m"
+	colorKey    = "\x1b[34m" // Blue.
+	colorString = "\x1b[32m" // Green.
+	colorNumber = "\x1b[36m" // Cyan.
+	colorBool   = "\x1b[33m" // Yellow.
+	colorNil    = "\x1b[90m" // Gray.
+)
+
+/*   F u n c t i o n s   */
+
+// WithColor highlights keys, strings, numbers, booleans and nils in different
+// colors, using ANSI escape codes, for reading in a terminal.
+func WithColor() PrettyOption {
+	return func(o *prettyOptions) {
+		o.color = true
+	}
+}
+
+// WithIndent indents each nesting level with indent instead of a tab.
+func WithIndent(indent string) PrettyOption {
+	return func(o *prettyOptions) {
+		o.indent = indent
+	}
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Pretty writes an indented json rendering of the struct to w, for interactive
+// debugging sessions. Like Sprint, it keeps fields in declared order and
+// renders values json does not support as strings, see WithColor for syntax
+// highlighting. Unexported struct fields will be neglected.
+func (s *StructValue) Pretty(w io.Writer, opts ...PrettyOption) error {
+	o := &prettyOptions{indent: "\t"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	b, err := json.Marshal(dumpValue(s.value, map[uintptr]bool{}))
+	if err != nil {
+		return errors.Wrap(err, "could not render struct")
+	}
+	p := &prettyPrinter{dec: json.NewDecoder(bytes.NewReader(b)), opts: o}
+	p.dec.UseNumber()
+	if err := p.value(0); err != nil {
+		return errors.Wrap(err, "could not render struct")
+	}
+	p.buf.WriteByte('\n')
+	if _, err := w.Write(p.buf.Bytes()); err != nil {
+		return errors.Wrap(err, "could not write struct")
+	}
+	return nil
+}
+
+/*   U n e x p o r t e d   */
+
+// value renders the next json value, at nesting level depth.
+func (p *prettyPrinter) value(depth int) error {
+	tok, err := p.dec.Token()
+	if err != nil {
+		return err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		return p.composite(t, depth)
+	case string:
+		p.write(colorString, quoteJSON(t))
+	case json.Number:
+		p.write(colorNumber, t.String())
+	case bool:
+		p.write(colorBool, fmt.Sprint(t))
+	case nil:
+		p.write(colorNil, "null")
+	default:
+		return errors.Errorf("unexpected json token %v", tok)
+	}
+	return nil
+}
+
+// composite renders the members of the json object or array opened by delim,
+// one per line.
+func (p *prettyPrinter) composite(delim json.Delim, depth int) error {
+	end := byte(']')
+	if delim == '{' {
+		end = '}'
+	}
+	p.buf.WriteByte(byte(delim))
+	var n int
+	for ; p.dec.More(); n++ {
+		if n > 0 {
+			p.buf.WriteByte(',')
+		}
+		p.newline(depth + 1)
+		if delim == '{' {
+			tok, err := p.dec.Token()
+			if err != nil {
+				return err
+			}
+			p.write(colorKey, quoteJSON(fmt.Sprint(tok)))
+			p.buf.WriteString(": ")
+		}
+		if err := p.value(depth + 1); err != nil {
+			return err
+		}
+	}
+	if _, err := p.dec.Token(); err != nil {
+		return err
+	}
+	if n > 0 {
+		p.newline(depth)
+	}
+	p.buf.WriteByte(end)
+	return nil
+}
+
+// write renders token x, highlighted with color if enabled.
+func (p *prettyPrinter) write(color, x string) {
+	if p.opts.color {
+		p.buf.WriteString(color + x + colorReset)
+		return
+	}
+	p.buf.WriteString(x)
+}
+
+// newline starts a new line indented at nesting level depth.
+func (p *prettyPrinter) newline(depth int) {
+	p.buf.WriteByte('\n')
+	p.buf.WriteString(strings.Repeat(p.opts.indent, depth))
+}
+
+// quoteJSON returns x as a json string, without escaping html characters.
+func quoteJSON(x string) string {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(x); err != nil {
+		return fmt.Sprintf("%q", x)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPretty struct {
+	Name    string            `json:"name"`
+	Port    int               `json:"port"`
+	Enabled bool              `json:"enabled"`
+	Phase   complex128        `json:"phase"`
+	Owner   *string           `json:"owner"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+}
+
+type testFailingWriter struct{}
+
+func (testFailingWriter) Write([]byte) (int, error) { return 0, errors.New("closed") }
+
+func TestPretty(t *testing.T) {
+	ts := testPretty{
+		Name:    "<web>",
+		Port:    8080,
+		Enabled: true,
+		Phase:   1i,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{},
+	}
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+
+	var b bytes.Buffer
+	err = s.Pretty(&b, WithIndent("  "))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{
+  "name": "<web>",
+  "port": 8080,
+  "enabled": true,
+  "phase": "(0+1i)",
+  "owner": null,
+  "tags": [
+    "a",
+    "b"
+  ],
+  "labels": {}
+}
+`, b.String())
+
+	b.Reset()
+	err = s.Pretty(&b, WithColor())
+	assert.Equal(t, nil, err)
+	out := b.String()
+	assert.Contains(t, out, "\t"+colorKey+`"name"`+colorReset+": "+colorString+`"<web>"`+colorReset+",\n")
+	assert.Contains(t, out, colorNumber+"8080"+colorReset)
+	assert.Contains(t, out, colorBool+"true"+colorReset)
+	assert.Contains(t, out, colorNil+"null"+colorReset)
+
+	err = s.Pretty(testFailingWriter{})
+	assert.EqualError(t, err, "could not write struct: closed")
+}
@@ -0,0 +1,205 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// Pair represents a field of a struct as a key/value pair, see Pairs.
+type Pair struct {
+	Key   string      // Dot separated name of the field, e.g. "Program.Name".
+	Value interface{} // Value of the field.
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Pick returns a pointer to a new struct, built at runtime, made of the fields of
+// the struct called names only, in declared order, along with their struct tags,
+// so that it encodes the same way, e.g. to shape JSON responses without
+// hand-written DTOs. Field values are shallow copies.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Pick(names ...string) (interface{}, error) {
+	fields, err := s.selectFields(names, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not pick fields of struct %s", s.FullName())
+	}
+	return newProjection(fields), nil
+}
+
+// Omit returns a pointer to a new struct, built at runtime, made of all the fields
+// of the struct but the ones called names, e.g. "Password". See Pick.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Omit(names ...string) (interface{}, error) {
+	fields, err := s.selectFields(names, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not omit fields of struct %s", s.FullName())
+	}
+	return newProjection(fields), nil
+}
+
+// PickMap returns the values of the fields of the struct called names only,
+// indexed by field names. See Pick.
+// Unexported struct fields will be neglected.
+func (s *StructValue) PickMap(names ...string) (map[string]interface{}, error) {
+	fields, err := s.selectFields(names, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not pick fields of struct %s", s.FullName())
+	}
+	return newProjectionMap(fields), nil
+}
+
+// OmitMap returns the values of all the fields of the struct but the ones called
+// names, indexed by field names. See Omit.
+// Unexported struct fields will be neglected.
+func (s *StructValue) OmitMap(names ...string) (map[string]interface{}, error) {
+	fields, err := s.selectFields(names, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not omit fields of struct %s", s.FullName())
+	}
+	return newProjectionMap(fields), nil
+}
+
+// Project fills the fields of struct dest, possibly anonymous, with the values of
+// the fields of the struct called the same, or else sharing the same json tag
+// name, like a lighter-weight Transpose for inline projections in handlers:
+//
+//	var out struct {
+//		ID    int    `json:"id"`
+//		Label string `json:"name"`
+//	}
+//	err := s.Project(&out)
+//
+// Values are set following the same rules as Set, so that types may differ, and
+// nested structs of different types are projected recursively. Fields of dest
+// without counterpart in the struct are left untouched.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Project(dest interface{}) error {
+	// ctx will be the context error returned
+	// by this func if anything goes wrong
+	ctx := fmt.Sprintf("could not project struct %s", s.Name())
+	c, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	if !c.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", c.Name()), ctx)
+	}
+	if c.Multiple() {
+		return errors.Wrap(errors.Errorf("target is a slice of struct %s", c.Name()), ctx)
+	}
+	return errors.Wrap(c.project(s), ctx)
+}
+
+// Pairs returns all the leaf fields of the struct as key/value pairs, in declared
+// order, contrary to maps, e.g. to build ordered JSON, log fields or form bodies
+// deterministically. Nested struct fields are included recursively under dot
+// separated keys, such as "Program.Name", like ToStringMap.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Pairs() []Pair {
+	pairs := make([]Pair, 0)
+	s.walk("", func(name string, f *StructField) error {
+		pairs = append(pairs, Pair{Key: name, Value: f.Interface()})
+		return nil
+	})
+	return pairs
+}
+
+/*   U n e x p o r t e d   */
+
+// selectFields returns the exported fields of the struct called names if keep is
+// true, else all of its other exported fields, in declared order. Names not found
+// in the struct return an error.
+func (s *StructValue) selectFields(names []string, keep bool) (StructFields, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := s.fieldByName(name); !ok {
+			return nil, errors.Wrapf(ErrNoField, "field %s", name)
+		}
+		selected[name] = true
+	}
+	fields := make(StructFields, 0)
+	for _, f := range s.Fields() {
+		if f.IsExported() && selected[f.Name()] == keep {
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// newProjection returns a pointer to a new struct made of fields, holding their
+// values. Dots of embedded fields names, e.g. "Base.ID", are dropped.
+func newProjection(fields StructFields) interface{} {
+	sfs := make([]reflect.StructField, len(fields))
+	for i, f := range fields {
+		sfs[i] = reflect.StructField{
+			Name: strings.ReplaceAll(f.Name(), ".", ""),
+			Type: f.value.Type(),
+			Tag:  f.field.Tag,
+		}
+	}
+	v := reflect.New(reflect.StructOf(sfs))
+	for i, f := range fields {
+		v.Elem().Field(i).Set(f.value)
+	}
+	return v.Interface()
+}
+
+// newProjectionMap returns the values of fields, indexed by field names.
+func newProjectionMap(fields StructFields) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Name()] = f.value.Interface()
+	}
+	return m
+}
+
+// project sets the fields of the struct to the values of their counterparts in
+// struct c, see Project.
+func (s *StructValue) project(c *StructValue) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		x := c.counterpart(f)
+		if x == nil {
+			continue
+		}
+		if f.value.Type() != x.value.Type() && f.CanStruct() && x.CanStruct() {
+			if err := f.Struct().project(x.Struct()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := f.Set(x.value.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// counterpart returns the exported field of the struct called the same as field
+// f, or else sharing the same json tag name, if any.
+func (s *StructValue) counterpart(f *StructField) *StructField {
+	if x, ok := s.fieldByName(f.Name()); ok && x.IsExported() {
+		return x
+	}
+	name := f.TagName("json")
+	if name == "" {
+		return nil
+	}
+	for _, x := range s.Fields() {
+		if x.IsExported() && x.TagName("json") == name {
+			return x
+		}
+	}
+	return nil
+}
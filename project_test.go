@@ -0,0 +1,83 @@
+package structs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickOmit(t *testing.T) {
+	type User struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		secret   string
+	}
+
+	user := User{ID: 1, Name: "Roninzo", Password: "s3cr3t", secret: "hidden"}
+	s, err := New(&user)
+	assert.Equal(t, nil, err)
+
+	p, err := s.Pick("Name", "ID")
+	assert.Equal(t, nil, err)
+	b, err := json.Marshal(p)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"id":1,"name":"Roninzo"}`, string(b))
+
+	o, err := s.Omit("Password")
+	assert.Equal(t, nil, err)
+	b, err = json.Marshal(o)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"id":1,"name":"Roninzo"}`, string(b))
+
+	m, err := s.PickMap("Password")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{"Password": "s3cr3t"}, m)
+
+	m, err = s.OmitMap("Password", "Name")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{"ID": 1}, m)
+
+	_, err = s.Pick("Missing")
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+	_, err = s.OmitMap("Missing")
+	assert.Equal(t, ErrNoField, errors.Cause(err))
+}
+
+func TestProject(t *testing.T) {
+	type Program struct {
+		Name    string
+		Version string
+	}
+	type Server struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		Secret  string
+		Program *Program
+	}
+
+	server := Server{ID: 7, Name: "Roninzo", Secret: "s3cr3t", Program: &Program{Name: "Apache", Version: "2.4"}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	var out struct {
+		ID      int64  `json:"id"`
+		Label   string `json:"name"`
+		Missing string
+		Program struct {
+			Name string
+		}
+	}
+	out.Missing = "untouched"
+	err = s.Project(&out)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(7), out.ID)
+	assert.Equal(t, "Roninzo", out.Label)
+	assert.Equal(t, "untouched", out.Missing)
+	assert.Equal(t, "Apache", out.Program.Name)
+
+	err = s.Project(out)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   F u n c t i o n s   */
+
+// CopyToProto copies the values of the domain struct src into the generated
+// protobuf struct dest, e.g. *pb.Server, matching fields by protobuf field
+// number, when src carries protobuf struct tags too, else by protobuf field
+// name, e.g. "user_name" for field UserName. Values are set following the same
+// rules as Set. Nested messages and repeated messages are copied recursively,
+// allocating them as needed. Fields without counterpart are left untouched.
+func CopyToProto(dest, src interface{}) error {
+	return copyProto(dest, src, "could not copy struct %q to protobuf message %q")
+}
+
+// CopyFromProto copies the values of the generated protobuf struct src, e.g.
+// *pb.Server, into the domain struct dest. See CopyToProto.
+func CopyFromProto(dest, src interface{}) error {
+	return copyProto(dest, src, "could not copy protobuf message %q to struct %q")
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// NameProtobuf returns the name of the field defined in the name option of its
+// protobuf struct tag, e.g. "user_name" for `protobuf:"bytes,1,opt,name=user_name,proto3"`,
+// else it generates it.
+func (f *StructField) NameProtobuf() string {
+	for _, opt := range f.protobufTag() {
+		if strings.HasPrefix(opt, "name=") {
+			return strings.TrimPrefix(opt, "name=")
+		}
+	}
+	return utils.CamelCaseToUnderscore(f.field.Name)
+}
+
+// ProtobufNumber returns the field number defined in the protobuf struct tag of
+// the field, e.g. 1 for `protobuf:"bytes,1,opt,name=user_name,proto3"`, else 0.
+func (f *StructField) ProtobufNumber() int {
+	opts := f.protobufTag()
+	if len(opts) < 2 {
+		return 0
+	}
+	n, err := strconv.Atoi(opts[1])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+/*   U n e x p o r t e d   */
+
+// copyProto copies the fields of struct src into struct dest, see CopyToProto.
+// ctx is formatted with the names of src and dest structs.
+func copyProto(dest, src interface{}, ctx string) error {
+	s1, err := New(src)
+	if err != nil {
+		return errors.Wrap(err, "could not copy protobuf message")
+	}
+	s2, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, "could not copy protobuf message")
+	}
+	ctx = fmt.Sprintf(ctx, s1.Name(), s2.Name())
+	if !s2.CanSet() {
+		return errors.Wrap(errors.Errorf("cannot edit struct %s", s2.Name()), ctx)
+	}
+	if s1.Multiple() {
+		return errors.Wrap(errors.Errorf("source is a slice of struct %s", s1.Name()), ctx)
+	}
+	if s2.Multiple() {
+		return errors.Wrap(errors.Errorf("target is a slice of struct %s", s2.Name()), ctx)
+	}
+	return errors.Wrap(s2.copyProto(s1), ctx)
+}
+
+// copyProto sets the fields of the struct to the values of their counterparts in
+// struct c. Protobuf internal fields, i.e. unexported or prefixed with "XXX_",
+// are neglected.
+func (s *StructValue) copyProto(c *StructValue) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() || strings.HasPrefix(f.Name(), "XXX_") {
+			continue
+		}
+		x := c.protoCounterpart(f)
+		if x == nil {
+			continue
+		}
+		if err := f.copyProto(x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoCounterpart returns the exported field of the struct with the same
+// protobuf field number as field f, if both have one, else with the same
+// protobuf field name, if any.
+func (s *StructValue) protoCounterpart(f *StructField) *StructField {
+	if n := f.ProtobufNumber(); n > 0 {
+		for _, x := range s.Fields() {
+			if x.IsExported() && x.ProtobufNumber() == n {
+				return x
+			}
+		}
+	}
+	name := f.NameProtobuf()
+	for _, x := range s.Fields() {
+		if x.IsExported() && x.NameProtobuf() == name {
+			return x
+		}
+	}
+	return nil
+}
+
+// copyProto sets the field to the value of field x, copying nested structs and
+// slices of structs of different types recursively. A nil pointer x sets the
+// field to its zero-value.
+func (f *StructField) copyProto(x *StructField) error {
+	v := reflect.Indirect(x.value)
+	switch {
+	case !v.IsValid():
+		return f.SetZero()
+	case f.value.Type() == x.value.Type():
+		return f.Set(x.value.Interface())
+	case f.canStructType() && x.canStructType():
+		if f.value.Kind() == reflect.Ptr && f.value.IsNil() {
+			if err := f.Set(reflect.New(f.value.Type().Elem()).Interface()); err != nil {
+				return err
+			}
+		}
+		return f.Struct().copyProto(x.Struct())
+	case isStructSlice(f.value.Type()) && isStructSlice(v.Type()):
+		elems := reflect.MakeSlice(f.value.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := copyProtoElem(elems.Index(i), v.Index(i)); err != nil {
+				return errors.Wrapf(err, "could not copy element %d of field %s", i, f.FullName())
+			}
+		}
+		return f.Set(elems.Interface())
+	}
+	return f.Set(v.Interface())
+}
+
+// copyProtoElem copies struct, or pointer to struct, x into the settable struct,
+// or pointer to struct, e. Nil pointers x are left as nil pointers.
+func copyProtoElem(e, x reflect.Value) error {
+	if x.Kind() == reflect.Ptr {
+		if x.IsNil() {
+			return nil
+		}
+		x = x.Elem()
+	}
+	if e.Kind() == reflect.Ptr {
+		e.Set(reflect.New(e.Type().Elem()))
+		e = e.Elem()
+	}
+	s1, err := New(x.Interface())
+	if err != nil {
+		return err
+	}
+	s2, err := New(e.Addr().Interface())
+	if err != nil {
+		return err
+	}
+	return s2.copyProto(s1)
+}
+
+// isStructSlice returns true if t is a slice of structs, or of pointers to
+// structs, time.Time excepted.
+func isStructSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	t = t.Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// protobufTag returns the comma separated options of the protobuf struct tag of
+// the field, if any.
+func (f *StructField) protobufTag() []string {
+	tag, ok := f.Tag("protobuf")
+	if !ok {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
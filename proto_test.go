@@ -0,0 +1,84 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPbProgram struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+type testPbServer struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	UserName string           `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Port     int32            `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Program  *testPbProgram   `protobuf:"bytes,3,opt,name=program,proto3" json:"program,omitempty"`
+	Backups  []*testPbProgram `protobuf:"bytes,4,rep,name=backups,proto3" json:"backups,omitempty"`
+}
+
+type testProgram struct {
+	Name    string
+	Version string
+}
+
+type testServer struct {
+	UserName string
+	Port     int
+	Program  testProgram
+	Backups  []testProgram
+	Ignored  bool
+}
+
+func TestCopyProto(t *testing.T) {
+	server := testServer{
+		UserName: "Roninzo",
+		Port:     8080,
+		Program:  testProgram{Name: "Apache", Version: "2.4"},
+		Backups:  []testProgram{{Name: "Nginx"}, {Name: "Caddy"}},
+	}
+
+	pb := testPbServer{}
+	err := CopyToProto(&pb, &server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Roninzo", pb.UserName)
+	assert.Equal(t, int32(8080), pb.Port)
+	assert.Equal(t, &testPbProgram{Name: "Apache", Version: "2.4"}, pb.Program)
+	assert.Equal(t, 2, len(pb.Backups))
+	assert.Equal(t, "Caddy", pb.Backups[1].Name)
+
+	back := testServer{Ignored: true}
+	err = CopyFromProto(&back, &pb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testServer{
+		UserName: "Roninzo",
+		Port:     8080,
+		Program:  testProgram{Name: "Apache", Version: "2.4"},
+		Backups:  []testProgram{{Name: "Nginx"}, {Name: "Caddy"}},
+		Ignored:  true,
+	}, back)
+
+	err = CopyToProto(pb, &server)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestProtobufTags(t *testing.T) {
+	s, err := New(&testPbServer{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "user_name", s.Field("UserName").NameProtobuf())
+	assert.Equal(t, 4, s.Field("Backups").ProtobufNumber())
+
+	s, err = New(&testServer{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "user_name", s.Field("UserName").NameProtobuf())
+	assert.Equal(t, 0, s.Field("UserName").ProtobufNumber())
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"reflect"
+
+	"github.com/jinzhu/copier"
+	"github.com/pkg/errors"
+)
+
+// TagSensitive is the struct tag key marking fields to be redacted by Redact,
+// e.g. `sensitive:"true"`. Any value but "false" marks the field.
+const TagSensitive = "sensitive"
+
+// Redacted is the value string fields are masked with by Redact.
+const Redacted = "[REDACTED]"
+
+/*   F u n c t i o n s   */
+
+// Redact returns a pointer to a deep clone of struct dest whose sensitive fields
+// are masked, so that it can be passed to loggers and telemetry safely. Fields
+// are sensitive if tagged `json:"-"` or `sensitive:"true"`, or if listed in
+// names, either by field name, json name or path, e.g. "Password", "password"
+// or "Account.Password". Non-empty string fields, or pointers to strings, are
+// set to Redacted, so that their presence remains visible, while other fields
+// are set to their zero value. Nested structs are redacted recursively, including
+// the ones held by slices, arrays, maps and interfaces.
+// Unexported struct fields will be neglected.
+func Redact(dest interface{}, names ...string) (interface{}, error) {
+	c, err := Clone(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not redact struct")
+	}
+	s, err := New(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not redact struct")
+	}
+	if err := s.redact(names); err != nil {
+		return nil, errors.Wrapf(err, "could not redact struct %s", s.Name())
+	}
+	return c, nil
+}
+
+/*   U n e x p o r t e d   */
+
+// redact masks the sensitive fields of the struct in place, see Redact.
+func (s *StructValue) redact(names []string) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		if f.isSensitive(names) {
+			if err := f.redact(); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.CanStruct() {
+			if err := f.Struct().redact(names); err != nil { // Recursivity
+				return err
+			}
+			continue
+		}
+		err := walkStructs(f.value, map[uintptr]bool{}, true, func(s *StructValue) error {
+			return s.redact(names) // Recursivity
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkStructs calls fn with every struct held by value v, i.e. the structs v
+// points to, or holds if it is an interface, a slice, an array or a map,
+// recursively. Structs held by map entries and interfaces are not settable, so
+// fn is called with a settable copy of them, which is stored back afterwards.
+// Deep copies of structs share the pointers held by interfaces with the original,
+// so that, if clone is true, these are walked through a deep copy of what they
+// point to. Values not settable and pointers already walked are neglected.
+func walkStructs(v reflect.Value, seen map[uintptr]bool, clone bool, fn func(*StructValue) error) error {
+	if !v.CanSet() || !canHoldStruct(v.Type()) {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		return walkStructs(v.Elem(), seen, clone, fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		e := v.Elem()
+		x := reflect.New(e.Type()).Elem()
+		x.Set(e)
+		if clone && e.Kind() == reflect.Ptr && !e.IsNil() {
+			p := reflect.New(e.Type().Elem())
+			if err := copier.CopyWithOption(p.Interface(), e.Interface(), copier.Option{DeepCopy: true}); err != nil {
+				return err
+			}
+			x.Set(p)
+		}
+		if err := walkStructs(x, seen, clone, fn); err != nil {
+			return err
+		}
+		v.Set(x)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkStructs(v.Index(i), seen, clone, fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			x := reflect.New(v.Type().Elem()).Elem()
+			x.Set(iter.Value())
+			if err := walkStructs(x, seen, clone, fn); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), x)
+		}
+	case reflect.Struct:
+		s, err := New(v.Addr().Interface())
+		if err != nil {
+			return err
+		}
+		return fn(s)
+	}
+	return nil
+}
+
+// canHoldStruct returns true if values of type t can hold structs other than
+// scalar ones, see isScalarStruct.
+func canHoldStruct(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		return !isScalarStruct(t)
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return canHoldStruct(t.Elem())
+	}
+	return false
+}
+
+// isSensitive returns true if the field is to be redacted, i.e. it is tagged
+// `json:"-"` or `sensitive:"true"`, or it is listed in names.
+func (f *StructField) isSensitive(names []string) bool {
+	if tag, ok := f.Tag("json"); ok && tag == "-" {
+		return true
+	}
+	if tag, ok := f.Tag(TagSensitive); ok && tag != "false" {
+		return true
+	}
+	for _, name := range names {
+		if name == f.Name() || name == f.NameJson() || name == f.Namespace() {
+			return true
+		}
+	}
+	return false
+}
+
+// redact masks the value of the field, see Redact.
+func (f *StructField) redact() error {
+	if f.IndirectType().Kind() == reflect.String && !f.IsZero() {
+		return f.Set(Redacted)
+	}
+	return f.SetZero()
+}
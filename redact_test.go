@@ -0,0 +1,108 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/roninzo/structs/pointers"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccount struct {
+	User     string  `json:"user"`
+	Password string  `json:"-"`
+	Token    *string `sensitive:"true"`
+	PIN      int     `sensitive:"true"`
+	Public   string  `sensitive:"false"`
+}
+
+type testCustomer struct {
+	Name    string      `json:"name"`
+	Email   string      `json:"email"`
+	Phone   string      `json:"phone"`
+	Account testAccount `json:"account"`
+	Tags    []string    `json:"tags"`
+}
+
+func TestRedact(t *testing.T) {
+	ts := testCustomer{
+		Name:  "Roninzo",
+		Email: "roninzo@example.com",
+		Account: testAccount{
+			User:     "roninzo",
+			Password: "abcdefg",
+			Token:    pointers.String("secret"),
+			PIN:      1234,
+			Public:   "visible",
+		},
+		Tags: []string{"vip"},
+	}
+
+	x, err := Redact(&ts, "email", "Phone", "Account.User")
+	assert.Equal(t, nil, err)
+	r, ok := x.(*testCustomer)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "Roninzo", r.Name)
+	assert.Equal(t, Redacted, r.Email)
+	assert.Equal(t, "", r.Phone)
+	assert.Equal(t, Redacted, r.Account.User)
+	assert.Equal(t, Redacted, r.Account.Password)
+	assert.Equal(t, Redacted, *r.Account.Token)
+	assert.Equal(t, 0, r.Account.PIN)
+	assert.Equal(t, "visible", r.Account.Public)
+	assert.Equal(t, []string{"vip"}, r.Tags)
+
+	// original left untouched
+	assert.Equal(t, "roninzo@example.com", ts.Email)
+	assert.Equal(t, "abcdefg", ts.Account.Password)
+	assert.Equal(t, "secret", *ts.Account.Token)
+	assert.Equal(t, 1234, ts.Account.PIN)
+
+	_, err = Redact(nil)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestRedactCollections(t *testing.T) {
+	type Holder struct {
+		Accts []testAccount
+		Arr   [1]testAccount
+		ByID  map[string]*testAccount
+		ByKey map[string]testAccount
+		Any   interface{}
+		Value interface{}
+		Names []string
+	}
+
+	ts := Holder{
+		Accts: []testAccount{{User: "a", Password: "pa"}},
+		Arr:   [1]testAccount{{User: "b", Password: "pb"}},
+		ByID:  map[string]*testAccount{"x": {User: "x", Password: "px"}},
+		ByKey: map[string]testAccount{"y": {User: "y", Password: "py"}},
+		Any:   &testAccount{User: "z", Password: "pz"},
+		Value: testAccount{User: "v", Password: "pv"},
+		Names: []string{"kept"},
+	}
+
+	x, err := Redact(&ts)
+	assert.Equal(t, nil, err)
+	r := x.(*Holder)
+	assert.Equal(t, Redacted, r.Accts[0].Password)
+	assert.Equal(t, "a", r.Accts[0].User)
+	assert.Equal(t, Redacted, r.Arr[0].Password)
+	assert.Equal(t, Redacted, r.ByID["x"].Password)
+	assert.Equal(t, Redacted, r.ByKey["y"].Password)
+	assert.Equal(t, Redacted, r.Any.(*testAccount).Password)
+	assert.Equal(t, Redacted, r.Value.(testAccount).Password)
+	assert.Equal(t, []string{"kept"}, r.Names)
+
+	// original left untouched
+	assert.Equal(t, "pa", ts.Accts[0].Password)
+	assert.Equal(t, "pb", ts.Arr[0].Password)
+	assert.Equal(t, "px", ts.ByID["x"].Password)
+	assert.Equal(t, "py", ts.ByKey["y"].Password)
+	assert.Equal(t, "pz", ts.Any.(*testAccount).Password)
+	assert.Equal(t, "pv", ts.Value.(testAccount).Password)
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	registry   = map[string]reflect.Type{}
+	registryMu sync.RWMutex
+)
+
+/*   F u n c t i o n s   */
+
+// Register registers the struct type of dest, e.g. Register(Server{}) or
+// Register(&Server{}), so that NewByName can allocate it out of its name, e.g.
+// in plugin-style systems receiving type names from configuration files or wire
+// protocols. Types are registered under both their name, e.g. "Server", and
+// their package qualified name, e.g. "main.Server". Registering another type
+// under a name already taken returns an error, while registering the same type
+// again is a no-op.
+func Register(dest interface{}) error {
+	ctx := "could not register struct"
+	s, err := New(dest)
+	if err != nil {
+		return errors.Wrap(err, ctx)
+	}
+	t := s.Type()
+	if t.Name() == "" {
+		return errors.Wrap(errors.New("anonymous struct has no name"), ctx)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, name := range []string{t.Name(), t.String()} {
+		if r, ok := registry[name]; ok && r != t {
+			return errors.Wrapf(errors.Errorf("name %q is already taken by %s", name, r), "%s %s", ctx, t)
+		}
+	}
+	registry[t.Name()] = t
+	registry[t.String()] = t
+	return nil
+}
+
+// NewByName returns the StructValue of a freshly allocated struct of the type
+// registered under name, e.g. "Server" or "main.Server". See Register.
+func NewByName(name string) (*StructValue, error) {
+	registryMu.RLock()
+	t, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, errors.Wrapf(ErrNoStruct, "could not allocate struct %q, which is not registered", name)
+	}
+	return New(reflect.New(t).Interface())
+}
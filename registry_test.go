@@ -0,0 +1,44 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRegistered struct {
+	Name string
+}
+
+func TestRegister(t *testing.T) {
+	err := Register(testRegistered{})
+	assert.Equal(t, nil, err)
+	err = Register(&testRegistered{})
+	assert.Equal(t, nil, err)
+
+	s, err := NewByName("testRegistered")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "testRegistered", s.Name())
+	assert.Equal(t, true, s.CanSet())
+	err = s.Field("Name").Set("Roninzo")
+	assert.Equal(t, nil, err)
+
+	s2, err := NewByName("structs.testRegistered")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", s2.Field("Name").String())
+
+	{
+		type testRegistered struct {
+			ID int
+		}
+		err = Register(testRegistered{})
+		assert.NotEqual(t, nil, err)
+	}
+
+	err = Register(struct{ ID int }{})
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewByName("testUnknown")
+	assert.Equal(t, ErrNoStruct, errors.Cause(err))
+}
@@ -4,6 +4,14 @@
 
 package structs
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 /*   S t r u c t   d e f i n i t i o n   */
 
 // StructRows represents a single row of a struct from a StructValue containing a slice
@@ -11,8 +19,70 @@ package structs
 // initialized by contructor Rows. StructRows encapsulates high level functions around
 // the element of slice of structs.
 type StructRows struct {
-	rownum      int // index of the slice of structs.
-	StructValue     // embedded copy and inherits all fields and methods.
+	rownum      int         // index of the slice of structs.
+	errs        []*RowError // errors encountered during iteration, in order.
+	StructValue             // embedded copy and inherits all fields and methods.
+}
+
+// RowError represents an error encountered while iterating over StructRows,
+// along with the index of the row it happened on.
+type RowError struct {
+	Row int   // Index of the row in the slice of structs, OutOfRange if none.
+	Err error // Error encountered on the row.
+}
+
+// RowErrors is the list of all RowError found while processing a slice of
+// structs, such as by ScanFromMaps.
+type RowErrors []*RowError
+
+// ColumnOption configures how the columns of StructRows, or the names of the
+// fields of a struct, are selected and named, see Columns and Names.
+type ColumnOption func(*columnOptions)
+
+// columnOptions holds the configuration of columns.
+type columnOptions struct {
+	tag          string // Struct tag key naming columns, if any.
+	exportedOnly bool   // Whether unexported fields are excluded.
+	recursive    bool   // Whether nested struct fields are included instead.
+	shallow      bool   // Whether nested structs are kept as single fields.
+}
+
+/*   F u n c t i o n s   */
+
+// Tagged names columns after the name part of struct tag key, e.g. "json" or
+// "db", so that they match serialized output. Fields tagged "-" are excluded,
+// while fields without such a tag fall back to their Go field name.
+func Tagged(key string) ColumnOption {
+	return func(o *columnOptions) {
+		o.tag = key
+	}
+}
+
+// ExportedOnly excludes unexported fields from columns.
+func ExportedOnly() ColumnOption {
+	return func(o *columnOptions) {
+		o.exportedOnly = true
+	}
+}
+
+// Recursive replaces nested structs with their own fields, named after their
+// dot separated path, e.g. "Program.Name", or "program.name" along with option
+// Tagged("json"). Nil pointers to nested structs are neglected.
+func Recursive() ColumnOption {
+	return func(o *columnOptions) {
+		o.recursive = true
+		o.shallow = false
+	}
+}
+
+// Shallow keeps nested structs as single fields, which is the default of Names
+// and Columns, but not of Values and IndirectValues, so that their values line
+// up with the fields of the struct.
+func Shallow() ColumnOption {
+	return func(o *columnOptions) {
+		o.recursive = false
+		o.shallow = true
+	}
 }
 
 /*   C o n s t r u c t o r   */
@@ -22,7 +92,7 @@ type StructRows struct {
 func (s *StructValue) Rows() (*StructRows, error) {
 	if s.Multiple() {
 		if s.rows.Len() > 0 {
-			return &StructRows{OutOfRange, *s}, nil
+			return &StructRows{rownum: OutOfRange, StructValue: *s}, nil
 		}
 		return nil, ErrNoRows
 	}
@@ -58,11 +128,14 @@ func (r *StructRows) MaxRow() int {
 	return OutOfRange
 }
 
-// Columns returns the current struct field names.
+// Columns returns the current struct field names. Options Tagged and
+// ExportedOnly select and name columns so that they match serialized output,
+// e.g. Columns(Tagged("json"), ExportedOnly()).
 // Columns returns an error if the rows are closed.
-func (r *StructRows) Columns() ([]string, error) {
+func (r *StructRows) Columns(opts ...ColumnOption) ([]string, error) {
 	if !r.isClosed() {
-		return r.Fields().Names(), nil
+		_, names := r.columns(newColumnOptions(opts))
+		return names, nil
 	}
 	return nil, ErrRowsClosed
 }
@@ -73,24 +146,38 @@ func (r *StructRows) Columns() ([]string, error) {
 // the two cases.
 func (r *StructRows) Next() bool {
 	if !r.isClosed() {
+		r.collect()
 		if i := r.rownum + 1; i < r.Len() {
 			err := r.getRow(i)
 			if err == nil {
 				r.rownum = i // confirm new row number
 				return true
 			}
+			r.errs = append(r.errs, &RowError{Row: i, Err: err})
+			r.StructValue.Err() // reset, now that it is collected
 		}
 	}
 	return false
 }
 
-// Err returns the error, if any, that was encountered during iteration.
-// Err may be called after an explicit or implicit Close.
-func (r *StructRows) Err() (err error) {
-	if r.Error != nil {
-		err, r.Error = r.Error, err // swap variable values
+// Err returns the first error, if any, that was encountered during iteration,
+// e.g. by a Field lookup on one of the rows, as a *RowError. Errors are sticky:
+// reading them does not clear them, so that they cannot be lost between calls to
+// Next. Err may be called after an explicit or implicit Close.
+func (r *StructRows) Err() error {
+	r.collect()
+	if len(r.errs) > 0 {
+		return r.errs[0]
 	}
-	return err
+	return nil
+}
+
+// Errs returns all the errors that were encountered during iteration, in order,
+// along with the indexes of the rows they happened on.
+// Errs may be called after an explicit or implicit Close.
+func (r *StructRows) Errs() []*RowError {
+	r.collect()
+	return append([]*RowError(nil), r.errs...)
 }
 
 // Close closes the Rows, preventing further enumeration. If Next is called
@@ -98,11 +185,221 @@ func (r *StructRows) Err() (err error) {
 // the Rows are closed automatically and it will suffice to check the
 // result of Err. Close is idempotent and does not affect the result of Err.
 func (r *StructRows) Close() error {
+	r.collect()
 	return r.destroy()
 }
 
+// Error returns the error message, prefixed with the row index, if any, e.g.
+// "row 2: struct field not found".
+func (e *RowError) Error() string {
+	if e.Row == OutOfRange {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Cause returns the underlying error, for errors.Cause.
+func (e *RowError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error, for errors.Is and errors.As.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// GroupBy returns the indexes of the rows grouped by the value of their field
+// name, e.g. GroupBy("Enabled") returns map[false:[1] true:[0 2]]. Pointer
+// fields are grouped by the values they point to, and nil ones under key nil.
+// GroupBy does not move the current row of the iteration.
+// GroupBy returns an error if the rows are closed, if the field does not exist
+// if it is unexported, or if its values cannot be used as map keys, e.g. slices.
+func (r *StructRows) GroupBy(name string) (map[interface{}][]int, error) {
+	f, err := r.column(name, newColumnOptions(nil))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not group rows")
+	}
+	groups := make(map[interface{}][]int)
+	for i := 0; i < r.Len(); i++ {
+		key, ok, err := r.key(f, i)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not group rows")
+		}
+		if !ok {
+			return nil, errors.Errorf("could not group rows by field %s of type %s", name, f.Type())
+		}
+		groups[key] = append(groups[key], i)
+	}
+	return groups, nil
+}
+
+// Distinct returns the unique values of field name across all rows, in the
+// order they are first seen. Pointer fields contribute the values they point
+// to, and nil ones a nil value. Option Tagged identifies the column by its
+// struct tag name instead, e.g. Distinct("name", Tagged("json")).
+// Distinct does not move the current row of the iteration.
+// Distinct returns an error if the rows are closed, the field does not exist or
+// is unexported.
+func (r *StructRows) Distinct(name string, opts ...ColumnOption) ([]interface{}, error) {
+	f, err := r.column(name, newColumnOptions(opts))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get distinct values")
+	}
+	values := make([]interface{}, 0)
+	seen := make(map[interface{}]bool)
+	for i := 0; i < r.Len(); i++ {
+		key, ok, err := r.key(f, i)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get distinct values")
+		}
+		if ok {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		} else if containsDeep(values, key) {
+			continue
+		}
+		values = append(values, key)
+	}
+	return values, nil
+}
+
+// ToMaps returns the rows as a slice of maps, e.g. for JSON APIs, templates or
+// generic CSV writers. Map keys are the name part of struct tag key tagName,
+// such as "json" or "db", falling back to field names. Fields tagged "-", or
+// tagged omitempty and empty, are omitted, like unexported fields. Nested
+// structs are included recursively as maps, unless tagged with the inline
+// option, in which case their fields are merged into the map. Nil rows are nil
+// maps.
+// ToMaps does not move the current row of the iteration.
+// ToMaps returns an error if the rows are closed.
+func (r *StructRows) ToMaps(tagName string) ([]map[string]interface{}, error) {
+	if r.isClosed() {
+		return nil, ErrRowsClosed
+	}
+	maps := make([]map[string]interface{}, r.Len())
+	for i := range maps {
+		row := r.rows.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		s, err := New(row.Addr().Interface())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not convert row %d to map", i)
+		}
+		maps[i] = s.toMap(tagName)
+	}
+	return maps, nil
+}
+
+// Error returns all row errors descriptions separated by semicolons.
+func (errs RowErrors) Error() string {
+	mesgs := make([]string, len(errs))
+	for i, e := range errs {
+		mesgs[i] = e.Error()
+	}
+	return strings.Join(mesgs, "; ")
+}
+
+// Rows returns the indexes of the rows in error, in order and without
+// duplicates.
+func (errs RowErrors) Rows() []int {
+	rows := make([]int, 0, len(errs))
+	for _, e := range errs {
+		if n := len(rows); n == 0 || rows[n-1] != e.Row {
+			rows = append(rows, e.Row)
+		}
+	}
+	return rows
+}
+
 /*   U n e x p o r t e d   */
 
+// newColumnOptions returns the column configuration set by opts.
+func newColumnOptions(opts []ColumnOption) *columnOptions {
+	o := &columnOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// columns returns the fields selected as columns by o, along with their names.
+func (r *StructRows) columns(o *columnOptions) (StructFields, []string) {
+	return r.StructValue.columns(o, "")
+}
+
+// column returns the field named name among the columns selected by o.
+// column returns an error if the rows are closed or no such column exists.
+func (r *StructRows) column(name string, o *columnOptions) (*StructField, error) {
+	if r.isClosed() {
+		return nil, ErrRowsClosed
+	}
+	fields, names := r.columns(o)
+	for i, n := range names {
+		if n == name {
+			return fields[i], nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNoField, "column %s", name)
+}
+
+// cell returns the value of field f in row i, dereferenced if it is a pointer,
+// without moving the current row. Field f can be a field of a nested struct, see
+// Recursive. cell returns the zero Value if the row or a pointer is nil.
+func (r *StructRows) cell(f *StructField, i int) reflect.Value {
+	path := StructFields{f}
+	for p := f.Parent; p != nil && p.parentField != nil; p = p.parentField.Parent {
+		path = append(StructFields{p.parentField}, path...)
+	}
+	v := reflect.Indirect(r.rows.Index(i))
+	for _, f := range path {
+		if !v.IsValid() {
+			break
+		}
+		v = reflect.Indirect(unlock(fieldByIndex(v, f.indexes), f.field))
+	}
+	return v
+}
+
+// key returns the value of field f in row i, as returned by cell, along with
+// whether it is comparable and can therefore be used as a map key. key returns
+// ErrNotExported if the value of field f cannot be read, see AllowUnexported.
+func (r *StructRows) key(f *StructField, i int) (interface{}, bool, error) {
+	v := r.cell(f, i)
+	if v.IsValid() && !v.CanInterface() {
+		return nil, false, errors.Wrapf(ErrNotExported, "column %s", f.Name())
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, true, nil
+	}
+	return v.Interface(), v.Type().Comparable(), nil
+}
+
+// containsDeep returns true if values holds a value deeply equal to v.
+func containsDeep(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if reflect.DeepEqual(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// collect moves the error of the current row, if any, to the iteration errors.
+func (r *StructRows) collect() {
+	if err := r.StructValue.Err(); err != nil {
+		r.errs = append(r.errs, &RowError{Row: r.rownum, Err: err})
+	}
+}
+
 // isClosed returns true if r is not closed and false if it is.
 // Closure prevents further enumeration of StructRows.
 func (r *StructRows) isClosed() bool {
@@ -73,6 +73,33 @@
 //             rows.go              StructRows object
 //
 //   Helpers   helpers.go           Wrapper object functions
+//             generics.go          Typed wrapper functions
+//             env.go               Environment variables binding
+//             flag.go              Command-line flags binding
+//             validate.go          Struct tags validation
+//             defaults.go          Computed default values
+//             layouts.go           Time layouts parsing
+//             durations.go         Duration units parsing
+//             bools.go             Truthiness parsing
+//             overflow.go          Numeric overflow checks
+//             track.go             Fields changes tracking
+//             hooks.go             Fields setter callbacks
+//             compare.go           Structs detailed comparison
+//             options.go           Package-wide options
+//             namespace.go         Fields paths rendering
+//             path.go              Fields paths lookup
+//             dump.go              Resilient json rendering
+//             pretty.go            Colorized pretty-printing
+//             project.go           Fields subsets projection
+//             redact.go            Sensitive fields redaction
+//             mask.go              PII fields masking
+//             proto.go             Protobuf messages mapping
+//             msgpack.go           MessagePack encoding
+//             fill.go              Random data fixtures
+//             registry.go          Struct types registry
+//             aggregate.go         Column aggregations
+//             iter.go              Range over func iterators, Go 1.23+
+//             numeric.go           Big and custom numeric types
 //
 //
 // All objects in this package are linked to the main StructValue object.
@@ -272,9 +299,10 @@ package structs
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -286,14 +314,34 @@ import (
 
 // StructValue is the representation of a Go struct powered by the Go reflection package.
 // Its interface provides field getters, field setters and much more.
+//
+// A StructValue can be read by concurrent goroutines, e.g. with Field, Get or the
+// field getters. Since they would share the error saved in StructValue, use the
+// methods returning errors instead, such as FieldE or Get, to tell which lookup
+// failed. Setters are not safe for concurrent use.
 type StructValue struct {
 	value         reflect.Value           // Go value of struct via Go reflection.
 	rows          reflect.Value           // Go slice of struct values via Go reflection.
+	rownum        int                     // Index of current struct in slice of structs, if iterated.
 	kinds         []reflect.Kind          // Lits of types that preceeds/including the struct.
 	fieldsByIndex StructFields            // List of struct fields by index (not recursive).
 	fieldsByName  map[string]*StructField // Map of struct fields by names (not recursive).
+	layouts       []string                // Accepted time layouts, if not global ones.
+	tracker       *tracker                // Changes made to fields, if tracked.
+	hooks         []SetHook               // Callbacks fired after fields are set.
+	frozen        bool                    // Whether setters are disabled.
+	strict        bool                    // Whether setters disable implicit conversions.
+	parentField   *StructField            // Parent struct field owning nested struct.
 	Parent        *StructValue            // Parent struct, if nested struct.
-	Error         error                   // Error added when struct could not be found.
+	locks         *structLocks            // Guards Error and fields loading, shared by copies.
+	Error         error                   // Error added when struct could not be found. Read it with Err.
+}
+
+// structLocks guards the state of StructValue that readers may update, so that
+// a struct can be read concurrently.
+type structLocks struct {
+	err    sync.Mutex // Guards Error.
+	fields sync.Mutex // Guards the lazy loading of fieldsByIndex and fieldsByName.
 }
 
 /*   C o n s t r u c t o r   */
@@ -376,7 +424,7 @@ func New(dest interface{}, parents ...*StructValue) (*StructValue, error) {
 //
 // Similar to utils.CanStruct(v)
 func IndirectStruct(v reflect.Value) *StructValue {
-	s := &StructValue{kinds: make([]reflect.Kind, 0)}
+	s := &StructValue{kinds: make([]reflect.Kind, 0), rownum: OutOfRange, locks: new(structLocks)}
 	t := v.Type()
 	i := 0
 	for {
@@ -435,15 +483,17 @@ func (s *StructValue) Name() string {
 // FullName returns the same as the Name method, unless StructValue is a nested struct.
 // When dealing with a nested struct, parent struct names are looked up and concatenated
 // to the response recursively all the way to the top level struct.
+// Structs being iterated as rows of a slice, or found in elements of slice fields,
+// are suffixed with their index, e.g. "Server[2].Program".
 func (s *StructValue) FullName() string {
 	n := ""
 	p := s.Parent
 	for {
 		if p != nil {
 			if n == "" {
-				n = p.Name()
+				n = p.fullNameSegment()
 			} else {
-				n = fmt.Sprintf("%s.%s", p.Name(), n)
+				n = fmt.Sprintf("%s.%s", p.fullNameSegment(), n)
 			}
 			p = p.Parent
 		} else {
@@ -451,9 +501,9 @@ func (s *StructValue) FullName() string {
 		}
 	}
 	if n == "" {
-		return s.Name()
+		return s.fullNameSegment()
 	}
-	return fmt.Sprintf("%s.%s", n, s.Name())
+	return fmt.Sprintf("%s.%s", n, s.fullNameSegment())
 }
 
 // Kind returns the struct reflect kind, or the last kind identified when the struct could
@@ -469,66 +519,102 @@ func (s *StructValue) Type() reflect.Type {
 }
 
 // Value returns the reflect value of the struct when the struct was found, else it returns
-// zero-value reflect value.
+// zero-value reflect value. Frozen structs return a copy, see Freeze.
 func (s *StructValue) Value() (v reflect.Value) {
-	return s.value
+	return s.frozenValue(s.value)
 }
 
-// Values returns the values of the struct as a slice of interfaces recursively.
-// Unexported struct fields will be neglected.
-func (s *StructValue) Values() (values []reflect.Value) {
-	for _, f := range s.Fields() {
-		if f.IsExported() {
-			if f.CanStruct() {
-				values = append(values, f.Struct().Values()...)
-			} else {
-				v := reflect.Indirect(f.value) // f.Value()
-				values = append(values, v)
-			}
-		}
+// Addr returns a pointer to the struct, e.g. a *T for a struct of type T, so
+// that it can be handed over to APIs filling values in, such as json.Unmarshal,
+// even after the struct was located inside a slice.
+// Addr returns nil if the struct is not addressable, e.g. New was given a
+// struct rather than a pointer to it, or if it is frozen.
+func (s *StructValue) Addr() interface{} {
+	if !s.value.CanAddr() || !s.value.Addr().CanInterface() || s.IsFrozen() {
+		return nil
 	}
-	return values
+	return s.value.Addr().Interface()
 }
 
-// IndirectValues returns the values of the struct as a slice of reflect Values recursively.
-func (s *StructValue) IndirectValues() (values []reflect.Value) {
-	for _, f := range s.Fields() {
-		if f.CanStruct() {
-			values = append(values, f.Struct().IndirectValues()...)
-			continue
+// Values returns the values of the struct as a slice of interfaces recursively,
+// i.e. the values of the fields of nested structs replace the ones of the fields
+// holding them. With option Shallow, nested structs are kept as single values
+// instead, e.g. Values(Shallow()), and Recursive makes the default explicit.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Values(opts ...ColumnOption) []reflect.Value {
+	o := newColumnOptions(opts)
+	o.exportedOnly = true
+	return s.values(o)
+}
+
+// InterfaceValues returns the same values as Values, as a slice of interfaces,
+// so that they can be handed over to fmt, database drivers or any variadic API
+// as is, e.g. db.Exec(query, s.InterfaceValues()...). Values of nil pointers
+// are nil.
+// Unexported struct fields will be neglected.
+func (s *StructValue) InterfaceValues(opts ...ColumnOption) []interface{} {
+	values := s.Values(opts...)
+	x := make([]interface{}, len(values))
+	for i, v := range values {
+		if v.IsValid() {
+			x[i] = v.Interface()
 		}
-		v := reflect.Indirect(f.value)
-		values = append(values, v)
 	}
-	return values
+	return x
+}
+
+// IndirectValues returns the values of the struct as a slice of reflect Values
+// recursively. With option Shallow, nested structs are kept as single values
+// instead, so that the values line up with Fields and NumField, and option
+// ExportedOnly neglects unexported struct fields.
+func (s *StructValue) IndirectValues(opts ...ColumnOption) []reflect.Value {
+	return s.values(newColumnOptions(opts))
 }
 
-// Debug dumps the StructValue object itself as json string.
-func (s *StructValue) Debug() string {
+// Debug dumps the StructValue object itself as json string. Optional argument
+// level details the fields of the struct, instead of only listing their names:
+// level 1 dumps their names and values, level 2 adds their types, kinds, tags
+// and indexes and level 3 adds their addresses and settability.
+func (s *StructValue) Debug(level ...int) string {
 	var p string
 	if s.Parent != nil {
-		p = s.Parent.Debug()
+		p = s.Parent.Debug(level...)
 	}
-	fields := make(map[int]string)
-	for i, f := range s.Fields() {
-		fields[i] = f.Name()
+	var fields interface{}
+	if len(level) > 0 && level[0] > 0 {
+		debugs := make([]interface{}, 0, s.NumField())
+		for _, f := range s.Fields() {
+			debugs = append(debugs, debugField(f, level[0]))
+		}
+		fields = debugs
+	} else {
+		names := make(map[int]string)
+		for i, f := range s.Fields() {
+			names[i] = f.Name()
+		}
+		fields = names
+	}
+	var rows interface{}
+	var maxRow int
+	if s.rows.IsValid() {
+		rows, maxRow = s.rows.Interface(), s.rows.Len()
 	}
 	d := struct {
-		Value  interface{}    `json:"value"`
-		Rows   interface{}    `json:"rows"`
-		MaxRow int            `json:"max_row"`
-		Kinds  string         `json:"kinds"`
-		Fields map[int]string `json:"fields"`
-		Parent string         `json:"parent"`
-		Error  error          `json:"error"`
+		Value  interface{} `json:"value"`
+		Rows   interface{} `json:"rows"`
+		MaxRow int         `json:"max_row"`
+		Kinds  string      `json:"kinds"`
+		Fields interface{} `json:"fields"`
+		Parent string      `json:"parent"`
+		Error  error       `json:"error"`
 	}{
 		Value:  s.value.Interface(),
-		Rows:   s.rows.Interface(),
-		MaxRow: s.rows.Len(),
+		Rows:   rows,
+		MaxRow: maxRow,
 		Kinds:  utils.Kinds(s.kinds...),
 		Fields: fields,
 		Parent: p,
-		Error:  s.Error,
+		Error:  s.peekErr(),
 	}
 	return Sprint(d)
 }
@@ -538,7 +624,41 @@ func (s *StructValue) Debug() string {
 // unexported struct fields. If CanSet returns false, calling Set or any type-specific setter
 // (e.g., SetBool, SetInt) will panic.
 func (s *StructValue) CanSet() bool {
-	return s.value.CanSet()
+	return s.value.CanSet() && !s.IsFrozen()
+}
+
+// Freeze makes the struct read-only: from then on, all setters of the struct,
+// its fields and its nested structs return ErrReadOnly, so that it can be handed
+// to untrusted code for inspection. A frozen struct cannot be unfrozen. Reflect
+// values handed out by Value and Indirect are then non-addressable copies, which
+// cannot be set. However, copies share what pointers, slices and maps point to,
+// which remain modifiable through them.
+// Freeze is carried out on the top level struct, even if s is a nested struct.
+func (s *StructValue) Freeze() *StructValue {
+	s.root().frozen = true
+	return s
+}
+
+// IsFrozen returns true if the struct is read-only, see Freeze.
+func (s *StructValue) IsFrozen() bool {
+	return s.root().frozen
+}
+
+// Strict sets whether the setters of the struct, its fields and its nested
+// structs, such as Set or Append, disable all implicit conversions, e.g. of text
+// into numbers, for callers that want type safety over convenience. Values must
+// then be assignable, or convertible following Go rules, to the field types, see
+// StructField.SetStrict.
+// Strict is carried out on the top level struct, even if s is a nested struct.
+func (s *StructValue) Strict(strict bool) *StructValue {
+	s.root().strict = strict
+	return s
+}
+
+// IsStrict returns true if the struct setters disable implicit conversions, see
+// Strict.
+func (s *StructValue) IsStrict() bool {
+	return s.root().strict
 }
 
 // Multiple reports whether the value of StructValue is a slice of structs. If Multiple
@@ -547,12 +667,80 @@ func (s *StructValue) Multiple() bool {
 	return s.rows.IsValid()
 }
 
+// Len returns the number of structs in the slice of structs, without having to
+// iterate over them with Rows.
+// Len returns OutOfRange, i.e. -1, if it is not a slice of structs.
+func (s *StructValue) Len() int {
+	if s.Multiple() {
+		return s.rows.Len()
+	}
+	return OutOfRange
+}
+
+// Cap returns the capacity of the slice of structs.
+// Cap returns OutOfRange, i.e. -1, if it is not a slice of structs.
+func (s *StructValue) Cap() int {
+	if s.Multiple() {
+		return s.rows.Cap()
+	}
+	return OutOfRange
+}
+
+// NewElem returns a new zero-value struct of the element type of the slice of
+// structs, allocating the pointee for slices of pointers, so that new rows can be
+// built with the same field API before being appended, see Append.
+// NewElem returns nil and saves an error in StructValue if it is not a slice of
+// structs, see Err.
+func (s *StructValue) NewElem() *StructValue {
+	if !s.Multiple() {
+		s.setErr(ErrNoStructs)
+		return nil
+	}
+	e := IndirectStruct(reflect.New(s.Type()))
+	e.layouts = s.layouts
+	e.strict = s.IsStrict()
+	return e
+}
+
+// Append appends values to the slice of structs in place, i.e. to the slice New
+// was given a pointer to, e.g. New(&servers). Values can be structs, pointers to
+// structs or StructValues, such as returned by NewElem, of the element type of
+// the slice. Structs are copied when appended to a slice of pointers.
+// Append returns an error if it is not a slice of structs, if the slice cannot
+// be grown in place or if a value is not of the element type.
+func (s *StructValue) Append(values ...interface{}) error {
+	if !s.Multiple() || s.rows.Kind() != reflect.Slice {
+		return errors.Wrap(ErrNoStructs, "could not append to slice")
+	}
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not append to slice of %s", s.Name())
+	}
+	if !s.rows.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not append to slice of %s", s.Name())
+	}
+	t := s.rows.Type().Elem()
+	elems := make([]reflect.Value, len(values))
+	for i, value := range values {
+		v, err := appendable(value, t)
+		if err != nil {
+			return errors.Wrapf(err, "could not append value %d to slice of %s", i, s.Name())
+		}
+		elems[i] = v
+	}
+	n := s.rows.Len()
+	s.rows.Set(reflect.Append(s.rows, elems...))
+	if n == 0 && s.rows.Len() > 0 {
+		// The struct of an empty slice is a detached zero-value, see New.
+		s.getRow(0)
+		s.rownum = OutOfRange
+	}
+	return nil
+}
+
 // NumField returns the number of fields in the struct.
 // This method is not recursive, which means that nested structs must be dealt with explicitly.
 func (s *StructValue) NumField() int {
-	if s.fieldsByIndex == nil {
-		s.getFields()
-	}
+	s.getFields()
 	return len(s.fieldsByIndex)
 }
 
@@ -590,6 +778,33 @@ func (s *StructValue) IsZero() bool {
 	return true
 }
 
+// IsEmpty returns true if all struct fields are empty, in the sense of the json
+// omitempty option. See the StructField IsEmpty method for details. Unlike for
+// json, nested structs are empty when all of their fields are empty, and other
+// structs, such as time.Time, when they are zero.
+// Unexported struct fields will be neglected.
+func (s *StructValue) IsEmpty() bool {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		if f.value.Kind() == reflect.Struct {
+			empty := f.IsZero()
+			if f.CanStruct() {
+				empty = f.Struct().IsEmpty() // Recursivity
+			}
+			if !empty {
+				return false
+			}
+			continue
+		}
+		if !f.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
 // HasZero returns true if one or more struct fields are of zero value.
 // Unexported struct fields will be neglected.
 func (s *StructValue) HasZero() bool {
@@ -605,6 +820,30 @@ func (s *StructValue) HasZero() bool {
 	return false
 }
 
+// ZeroFieldNames returns the names of the zero-value fields of the struct. Fields
+// of nested structs are included recursively under dot separated names, such as
+// "Program.Name", while nil pointers to nested structs are reported by their own
+// name.
+// Unexported struct fields will be neglected.
+func (s *StructValue) ZeroFieldNames() []string {
+	return s.fieldNames("", true)
+}
+
+// NonZeroFieldNames returns the names of the non-zero fields of the struct, i.e.
+// the opposite of ZeroFieldNames.
+// Unexported struct fields will be neglected.
+func (s *StructValue) NonZeroFieldNames() []string {
+	return s.fieldNames("", false)
+}
+
+// ParentField returns the field of the parent struct owning the nested struct,
+// e.g. to reconstruct paths or read struct tags of the container. ParentField
+// returns nil if StructValue is the top level struct, or if it was not obtained
+// from the Struct method of StructField.
+func (s *StructValue) ParentField() *StructField {
+	return s.parentField
+}
+
 // IsNested returns true if struct is a nested struct within the root struct.
 // IsNested returns false if StructValue is the top level struct.
 func (s *StructValue) IsNested() bool {
@@ -623,7 +862,13 @@ func (s *StructValue) HasNested() bool {
 }
 
 // Err gets error from StructValue, then resets internal error.
+// Err is safe for concurrent use, but the error is shared by all the goroutines
+// using the struct, so that it may have been recorded by another one. Accessors
+// without error result, i.e. Field, Value, StringOf, SetInt, SetUint, SetFloat
+// and SetComplex, record their errors there. Concurrent callers should use
+// FieldE, ValueE and Set instead, which return their errors per call.
 func (s *StructValue) Err() (err error) {
+	defer s.lockErr()()
 	err, s.Error = s.Error, err // swap variable values
 	return err
 }
@@ -648,24 +893,308 @@ func (s *StructValue) Contains(dest interface{}) int {
 	return s.contains(v)
 }
 
-// HasField returns true if struct dest has a field called the same as
-// argument name.
-func (s *StructValue) HasField(dest interface{}, arg interface{}) (bool, error) {
-	f := s.Field(arg)
-	err := s.Err()
+// IndexOf returns the path of the first field equal to dest, like Contains, except
+// that nested structs, as well as the elements of slice, array and map fields,
+// are searched recursively, e.g. "Program.Name", "Programs[2].Name" or
+// "Labels[env]". Paths are rendered like Namespace. Its second returned value
+// reports whether dest was found.
+// Unexported struct fields will be neglected.
+func (s *StructValue) IndexOf(dest interface{}) (string, bool) {
+	v := reflect.ValueOf(dest)
+	if f := s.indexOf(v); f != nil {
+		return f.Namespace(), true
+	}
+	return "", false
+}
+
+// ContainsDeep returns true if dest is equal to any field of the struct, nested
+// structs and elements of collections included. See IndexOf.
+// Unexported struct fields will be neglected.
+func (s *StructValue) ContainsDeep(dest interface{}) bool {
+	_, ok := s.IndexOf(dest)
+	return ok
+}
+
+// Names returns the names of the fields of the struct. Options Tagged,
+// ExportedOnly and Recursive select and name fields so that they match
+// serialized keys, e.g. Names(Tagged("json"), Recursive()) returns names such
+// as "program.name".
+func (s *StructValue) Names(opts ...ColumnOption) []string {
+	_, names := s.columns(newColumnOptions(opts), "")
+	return names
+}
+
+// Has returns true if the struct has a field called name. Contrary to Field,
+// Has does not save any error in StructValue when there is no such field.
+func (s *StructValue) Has(name string) bool {
+	_, ok := s.fieldByName(name)
+	return ok
+}
+
+// WhereClause returns a SQL where clause built from all non-zero fields of the
+// struct, e.g. "name = ? AND id = ?", as well as the arguments to be bound to
+// its placeholders, in the same order. Column names are looked up in the struct
+// tag key tag, e.g. "db", and default to the field names. Slice and array
+// fields, apart from bytes, match any of their elements, e.g. "tags IN (?, ?)",
+// with one argument per element.
+// Unexported, ignored (tagged "-"), empty slice, map and nested struct fields
+// will be neglected.
+func (s *StructValue) WhereClause(tag string) (string, []interface{}) {
+	conds := make([]string, 0)
+	args := make([]interface{}, 0)
+	for _, f := range s.Fields() {
+		if !f.IsExported() || f.CanStruct() || f.IsZero() {
+			continue
+		}
+		col := f.TagName(tag)
+		if col == "" {
+			continue
+		}
+		v := f.Indirect()
+		switch {
+		case v.Kind() == reflect.Map:
+			continue
+		case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8:
+			if v.Len() == 0 {
+				continue
+			}
+			marks := make([]string, v.Len())
+			for i := range marks {
+				marks[i] = "?"
+				args = append(args, v.Index(i).Interface())
+			}
+			conds = append(conds, fmt.Sprintf("%s IN (%s)", col, strings.Join(marks, ", ")))
+		default:
+			conds = append(conds, fmt.Sprintf("%s = ?", col))
+			args = append(args, v.Interface())
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// ToValues returns the struct as url values, e.g. to be encoded as a query string.
+// Keys are looked up in the struct tag key tag, e.g. "url", and default to the field
+// names. Slice fields result in repeated keys, while nested struct fields result in
+// dot separated keys, such as "program.name".
+// Unexported and hidden fields, i.e. tagged "-" or tagged omitempty and zero-value,
+// will be neglected.
+func (s *StructValue) ToValues(tag string) url.Values {
+	values := url.Values{}
+	s.toValues(values, tag, "")
+	return values
+}
+
+// ToStringMap returns all the leaf fields of the struct formatted as strings,
+// indexed by field names. Nested struct fields are included recursively under
+// dot separated names, such as "Program.Name". Times are formatted in RFC3339,
+// durations in their String representation and slices as comma separated values.
+// Unexported struct fields will be neglected.
+func (s *StructValue) ToStringMap() map[string]string {
+	m := make(map[string]string)
+	s.walk("", func(name string, f *StructField) error {
+		m[name] = formatStrings(f.Indirect(), ",")
+		return nil
+	})
+	return m
+}
+
+// ToMap returns the fields of the struct indexed by their names in documents,
+// i.e. the name part of their json tag, or bson tag if missing, see TagName, so
+// that structs shared with the Mongo driver use the same names as the database.
+// Nested structs are included recursively as maps, unless tagged with the
+// inline option, e.g. `bson:",inline"`, in which case their fields are merged
+// into the map. Hidden fields are omitted, see IsHidden.
+// Values of types implementing json.Marshaler, other than time.Time, are
+// rendered as encoding/json does, i.e. decoded from their MarshalJSON output,
+// with numbers as json.Number.
+// Unexported struct fields will be neglected.
+func (s *StructValue) ToMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, f := range s.Fields() {
+		if !f.IsExported() || f.IsHidden() {
+			continue
+		}
+		if !f.CanStruct() || f.isJSONMarshaler() {
+			m[f.TagName(f.nameTag())] = f.mapValue()
+			continue
+		}
+		nested := f.Struct().ToMap()
+		if f.TagHasOption(f.nameTag(), "inline") {
+			for k, v := range nested {
+				m[k] = v
+			}
+			continue
+		}
+		m[f.TagName(f.nameTag())] = nested
+	}
+	return m
+}
+
+// Set replaces the whole struct with other, so that a nested struct or a row of
+// a slice of structs can be overwritten in one go. Argument other can be a
+// struct, a pointer to a struct or a StructValue, of the same type as the struct.
+// Fields are set one by one, like StructField.Set, so that changes are tracked
+// and hooks are fired. To assign a struct of a different type field by field,
+// see Project.
+// Unsettable structs will return an error. Unexported struct fields will be
+// neglected, unless writing them is allowed, see AllowUnexported.
+func (s *StructValue) Set(other interface{}) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not set struct %s", s.FullName())
+	}
+	if !s.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not set struct %s", s.FullName())
+	}
+	x, err := appendable(other, s.Type())
 	if err != nil {
-		return false, err
+		return errors.Wrapf(err, "could not set struct %s", s.FullName())
+	}
+	y := reflect.New(s.Type()).Elem() // i.e. addressable, see unlock
+	y.Set(x)
+	for _, f := range s.Fields() {
+		if !f.isWritable() {
+			continue
+		}
+		v := unlock(fieldByIndex(y, f.indexes), f.field)
+		if f.canAllocate() && v.IsZero() { // i.e. nil embedded struct pointers
+			continue
+		}
+		err := f.mutate(func() error {
+			f.value.Set(v)
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not set struct %s", s.FullName())
+		}
 	}
-	if f != nil {
-		return true, nil
+	return nil
+}
+
+// SetZero resets the whole struct to its zero-value.
+// Unsettable structs will return an error.
+func (s *StructValue) SetZero() error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not set struct %s to zero-value", s.FullName())
+	}
+	if !s.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not set struct %s to zero-value", s.FullName())
+	}
+	s.value.Set(reflect.Zero(s.Type()))
+	return nil
+}
+
+// ZeroFields sets the fields of the struct called names to their zero-value, e.g.
+// to sanitize a struct before caching or logging it. This method is not recursive,
+// see ZeroFieldsDeep for clearing fields of nested structs as well.
+// Unknown and unsettable struct fields will return an error.
+func (s *StructValue) ZeroFields(names ...string) error {
+	for _, name := range names {
+		f, ok := s.fieldByName(name)
+		if !ok {
+			return errors.Wrapf(ErrNoField, "could not set field %s.%s to zero-value", s.FullName(), name)
+		}
+		if err := f.SetZero(); err != nil {
+			return err
+		}
 	}
-	return false, nil
+	return nil
+}
+
+// ZeroFieldsDeep sets the fields called names to their zero-value, in the struct
+// as well as in all of its nested structs, recursively. Contrary to ZeroFields,
+// names not found in a struct are neglected.
+// Unsettable struct fields will return an error.
+func (s *StructValue) ZeroFieldsDeep(names ...string) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		for _, name := range names {
+			if f.Name() == name {
+				if err := f.SetZero(); err != nil {
+					return err
+				}
+			}
+		}
+		if f.CanStruct() {
+			if err := f.Struct().ZeroFieldsDeep(names...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// InitNils allocates a zero-value to every nil pointer field of the struct, so
+// that they can be dereferenced safely. Pointers to maps point to empty maps,
+// ready for use. When recursive is true, nil pointer fields of nested structs
+// are allocated as well.
+// Unsettable structs will return an error, while unsettable fields will be neglected.
+func (s *StructValue) InitNils(recursive bool) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not initialize nil pointers of struct %s", s.FullName())
+	}
+	if !s.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not initialize nil pointers of struct %s", s.FullName())
+	}
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		if utils.CanPtr(f.value) && f.IsNil() {
+			v := utils.PresetIndirect(f.value)
+			if utils.CanMap(v) {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+		}
+		if recursive && f.CanStruct() {
+			if err := f.Struct().InitNils(recursive); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PruneZero is the inverse of InitNils. It sets every pointer field of the struct
+// pointing to a zero-value back to nil, e.g. so that the json omitempty option
+// applies to them. Pointers to empty maps and slices are also set to nil. When
+// recursive is true, nested structs are pruned first, so that pointers to nested
+// structs that end up with zero-values only are set to nil as well.
+// Unsettable structs will return an error, while unsettable fields will be neglected.
+func (s *StructValue) PruneZero(recursive bool) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not prune zero-value pointers of struct %s", s.FullName())
+	}
+	if !s.CanSet() {
+		return errors.Wrapf(ErrNotSettable, "could not prune zero-value pointers of struct %s", s.FullName())
+	}
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		if recursive && f.CanStruct() {
+			if err := f.Struct().PruneZero(recursive); err != nil {
+				return err
+			}
+		}
+		if utils.CanPtr(f.value) && !f.IsNil() {
+			v := f.value.Elem()
+			if v.IsZero() || ((utils.CanMap(v) || utils.CanSlice(v)) && v.Len() == 0) {
+				f.value.Set(utils.Zero(f.value))
+			}
+		}
+	}
+	return nil
 }
 
 // Import loops through destination fields of struct s and set their values to the
 // corresponding fields from c. Usually, s is a trim-down version of c.
 // Unsettable struct fields will be neglected.
 func (s *StructValue) Import(c *StructValue) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not import into struct %s", s.FullName())
+	}
 	for _, field := range s.Fields() {
 		if field.CanSet() {
 			v := field.value
@@ -684,6 +1213,9 @@ func (s *StructValue) Import(c *StructValue) error {
 // corresponding fields from c. Zero-value fields from c will be neglected.
 // Unsettable struct fields will be neglected.
 func (s *StructValue) Forward(c *StructValue) error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not forward into struct %s", s.FullName())
+	}
 	for _, field := range s.Fields() {
 		if field.CanSet() {
 			v := field.value
@@ -703,6 +1235,9 @@ func (s *StructValue) Forward(c *StructValue) error {
 
 // MapFunc maps struct with func handler.
 func (s *StructValue) MapFunc(handler func(reflect.Value) error) (*StructValue, error) {
+	if s.IsFrozen() {
+		return s, errors.Wrapf(ErrReadOnly, "could not map struct %s", s.FullName())
+	}
 	for _, f := range s.Fields() {
 		if f.IsExported() {
 			if f.CanStruct() {
@@ -719,6 +1254,47 @@ func (s *StructValue) MapFunc(handler func(reflect.Value) error) (*StructValue,
 	return s, nil
 }
 
+// MapDeep maps struct with func handler, like MapFunc, except that it also
+// descends into slices, arrays and maps of nested structs, e.g. []Nested or
+// map[string]*Nested, as well as pointer chains, so that a whole object graph
+// can be mapped in one call. Nil pointers are neglected. Slices and maps of
+// other types are handed over to handler as a whole, as MapFunc does.
+// Unexported struct fields will be neglected.
+func (s *StructValue) MapDeep(handler func(reflect.Value) error) (*StructValue, error) {
+	if s.IsFrozen() {
+		return s, errors.Wrapf(ErrReadOnly, "could not map struct %s", s.FullName())
+	}
+	for _, f := range s.Fields() {
+		if f.IsExported() {
+			if err := mapDeep(f.value, handler); err != nil {
+				return s, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// MapFields maps struct with func handler, like MapFunc, except that handler
+// receives the StructField itself, rather than its bare reflect.Value, so that
+// transformations can be conditioned on names, struct tags or paths, e.g. only
+// lower-case fields tagged `normalize:"lower"`. Nested structs are mapped
+// recursively, field by field.
+// Unexported struct fields will be neglected.
+func (s *StructValue) MapFields(handler func(f *StructField) error) (*StructValue, error) {
+	for _, f := range s.Fields() {
+		if f.IsExported() {
+			if f.CanStruct() {
+				if _, err := f.Struct().MapFields(handler); err != nil {
+					return s, err
+				}
+			} else if err := handler(f); err != nil {
+				return s, err
+			}
+		}
+	}
+	return s, nil
+}
+
 // Diff returns the differences in field values between two StructValue.
 func (s *StructValue) Diff(c *StructValue) (map[string]interface{}, error) {
 	diffs := make(map[string]interface{})
@@ -739,68 +1315,80 @@ func (s *StructValue) Diff(c *StructValue) (map[string]interface{}, error) {
 }
 
 // Defaults initializes struct from inline default struct tags.
-// Unsettable and zero-value fields will be neglected.
+//
+// Deprecated: Defaults is an alias to the ApplyDefaults method.
 func (s *StructValue) Defaults() error {
+	return s.ApplyDefaults()
+}
+
+// ApplyDefaults sets every zero-value field of the struct to the value declared
+// in its default struct tag, recursively. Default values are parsed according to
+// the field kind: strings (optionally quoted), numbers, integers possibly with a
+// base prefix such as "0x1F", bools, durations, times (RFC3339) and comma
+// separated slices. Default values can also be computed by
+// functions, such as `default:"now()"`; see RegisterDefaultFunc.
+// Unsettable, non-zero and nil nested struct fields will be neglected.
+// Frozen structs will return an error, see Freeze.
+func (s *StructValue) ApplyDefaults() error {
+	if s.IsFrozen() {
+		return errors.Wrapf(ErrReadOnly, "could not apply defaults to struct %s", s.FullName())
+	}
 	for _, f := range s.Fields() {
-		if f.CanSet() && f.IsZero() {
-			if d := f.Default(); d != "" {
-				v := reflect.Indirect(f.value)
-				switch {
-				case utils.CanStruct(v):
-					err := f.Struct().Defaults() // Recursivity
-					if err != nil {
-						return err
-					}
-				case utils.CanBool(v):
-					x, err := strconv.ParseBool(d)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for bool, got error: %v", d, err)
-					}
-					v.SetBool(x)
-				case utils.CanInt(v):
-					x, err := strconv.ParseInt(d, 0, 64)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for int, got error: %v", d, err)
-					}
-					v.SetInt(x)
-				case utils.CanUint(v):
-					x, err := strconv.ParseUint(d, 0, 64)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for uint, got error: %v", d, err)
-					}
-					v.SetUint(x)
-				case utils.CanFloat(v):
-					x, err := strconv.ParseFloat(d, 64)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for float, got error: %v", d, err)
-					}
-					v.SetFloat(x)
-				case utils.CanDuration(v):
-					x, err := time.ParseDuration(d)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for duration, got error: %v", d, err)
-					}
-					f.SetDuration(x)
-				case utils.CanTime(v):
-					t, err := utils.StringToTime(d)
-					if err != nil {
-						return errors.Wrapf(err, "failed to parse %v as default value for time, got error: %v", d, err)
-					}
-					x := reflect.ValueOf(t)
-					v.Set(x)
-				case utils.CanString(v):
-					d = strings.Trim(d, "'")
-					d = strings.Trim(d, "\"")
-					v.SetString(d)
-				case utils.CanBytes(v):
-					x := []byte(d)
-					v.SetBytes(x)
-				case utils.CanSlice(v):
-					return errors.New("slice default struct tag value not supported")
-				case utils.CanMap(v):
-					return errors.New("map default struct tag value not supported")
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		if f.CanStruct() {
+			if err := f.Struct().ApplyDefaults(); err != nil { // Recursivity
+				return err
+			}
+			continue
+		}
+		if f.canStructType() || !f.IsZero() {
+			continue
+		}
+		if x, ok, err := f.defaultFunc(); ok {
+			if err != nil {
+				return err
+			}
+			switch d := x.(type) {
+			case nil:
+				continue
+			case string:
+				err = f.setStrings([]string{d})
+			default:
+				err = f.Set(d)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to set computed default value of field %s", f.FullName())
+			}
+			continue
+		}
+		d := f.Default()
+		if d == "" {
+			continue
+		}
+		t := f.value.Type()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		values := []string{decimalDefault(t, d)}
+		switch t.Kind() {
+		case reflect.String:
+			d = strings.Trim(d, "'")
+			d = strings.Trim(d, "\"")
+			values = []string{d}
+		case reflect.Slice:
+			if t.Elem().Kind() != reflect.Uint8 {
+				values = strings.Split(d, ",")
+				for i := range values {
+					values[i] = decimalDefault(t.Elem(), values[i])
 				}
 			}
+		case reflect.Map:
+			return errors.Errorf("map default struct tag value not supported for field %s", f.FullName())
+		}
+		if err := f.setStrings(values); err != nil {
+			return errors.Wrapf(err, "failed to parse %v as default value", d)
 		}
 	}
 	return nil
@@ -808,6 +1396,86 @@ func (s *StructValue) Defaults() error {
 
 /*   U n e x p o r t e d   */
 
+// frozenValue returns a non-addressable copy of reflect value v if the struct
+// is frozen, so that it cannot be set, see Freeze. Otherwise, v is returned as
+// is.
+func (s *StructValue) frozenValue(v reflect.Value) reflect.Value {
+	if !v.CanSet() || !s.IsFrozen() {
+		return v
+	}
+	return v.Convert(v.Type()) // i.e. a copy
+}
+
+// fieldByIndex returns the nested field of struct v at indexes, like
+// reflect.Value.FieldByIndex, except that fields promoted from nil embedded
+// struct pointers are returned as non-settable zero-values instead of panicking.
+func fieldByIndex(v reflect.Value, indexes []int) reflect.Value {
+	x, err := v.FieldByIndexErr(indexes)
+	if err != nil {
+		return reflect.Zero(v.Type().FieldByIndex(indexes).Type)
+	}
+	return x
+}
+
+// debugField returns the details of field f dumped by Debug at given level.
+func debugField(f *StructField, level int) interface{} {
+	d := struct {
+		Name     string            `json:"name"`
+		Value    interface{}       `json:"value"`
+		Type     string            `json:"type,omitempty"`
+		Kind     string            `json:"kind,omitempty"`
+		Tags     map[string]string `json:"tags,omitempty"`
+		Index    *int              `json:"index,omitempty"`
+		Indexes  []int             `json:"indexes,omitempty"`
+		Address  string            `json:"address,omitempty"`
+		CanAddr  *bool             `json:"can_addr,omitempty"`
+		CanSet   *bool             `json:"can_set,omitempty"`
+		Exported *bool             `json:"exported,omitempty"`
+	}{
+		Name:  f.Name(),
+		Value: f.Get(),
+	}
+	if level >= 2 {
+		index := f.Index()
+		d.Type = f.Type().String()
+		d.Kind = f.Kind().String()
+		d.Tags = f.Tags()
+		d.Index = &index
+		d.Indexes = f.indexes
+	}
+	if level >= 3 {
+		canAddr, canSet, exported := f.value.CanAddr(), f.CanSet(), f.IsExported()
+		if canAddr {
+			d.Address = fmt.Sprintf("%#x", f.value.UnsafeAddr())
+		}
+		d.CanAddr = &canAddr
+		d.CanSet = &canSet
+		d.Exported = &exported
+	}
+	return d
+}
+
+// fullNameSegment returns the name of the struct, suffixed with its index when
+// it is a row of a slice of structs or an element of a slice field.
+func (s *StructValue) fullNameSegment() string {
+	n := s.Name()
+	if s.rownum != OutOfRange {
+		n = fmt.Sprintf("%s[%d]", n, s.rownum)
+	}
+	if f := s.parentField; f != nil && f.owner != nil {
+		n = fmt.Sprintf("%s[%s]", n, f.key)
+	}
+	return n
+}
+
+// root returns the top level struct, i.e. itself unless it is a nested struct.
+func (s *StructValue) root() *StructValue {
+	for s.Parent != nil {
+		s = s.Parent
+	}
+	return s
+}
+
 // getFields loads and saves all the struct fields.
 // This method is not recursive, which means that nested structs must be dealt with explicitly.
 //
@@ -816,11 +1484,39 @@ func (s *StructValue) Defaults() error {
 // fields, those will be expanded and their related fields will explicitely be parsed, potentially
 // pushing remaining struct fields further down the order.
 func (s *StructValue) getFields() {
+	defer s.lockFields()()
 	if s.fieldsByIndex == nil {
 		v := s.value
 		m := make(map[int]embedded.Unembeddeds)
-		embedded.Explode(v, v.Type(), m, nil, nil, nil)
+		switch getEmbeddedMode() {
+		case EmbeddedKeep:
+			for i := 0; i < v.NumField(); i++ {
+				sf := v.Type().Field(i)
+				m[i] = embedded.Unembeddeds{Index: i, Indexes: []int{i}, Name: sf.Name, Value: v.Field(i), StructField: sf, Type: sf.Type}
+			}
+		case EmbeddedPrefix:
+			embedded.Explode(v, v.Type(), m, nil, nil, nil)
+			for i, u := range m {
+				u.StructField.Name = embeddedName(v.Type(), u.Indexes)
+				u.Name = u.StructField.Name
+				m[i] = u
+			}
+		default:
+			embedded.Explode(v, v.Type(), m, nil, nil, nil)
+		}
 		n := len(m)
+		if isSkipUnexported() {
+			c := 0
+			for i := 0; i < n; i++ {
+				if m[i].StructField.PkgPath == "" {
+					u := m[i]
+					u.Index = c
+					m[c] = u
+					c++
+				}
+			}
+			n = c
+		}
 		s.initFields(n)
 		for i := 0; i < n; i++ {
 			s.loadField(m[i].Index, m[i].Indexes, m[i].Value, m[i].StructField)
@@ -828,12 +1524,21 @@ func (s *StructValue) getFields() {
 	}
 }
 
+// embeddedName returns the dot separated names of the fields of struct type t
+// found at indexes x, i.e. the name of a promoted field prefixed with the names
+// of the embedded structs it comes from.
+func embeddedName(t reflect.Type, x []int) string {
+	names := make([]string, len(x))
+	for i := range x {
+		names[i] = t.FieldByIndex(x[:i+1]).Name
+	}
+	return strings.Join(names, ".")
+}
+
 // getFieldByIndex loads and saves the struct field indentified by index i. If an error occurred finding
 // field, getFieldByIndex returns nil and error is saved in StructValue.
 func (s *StructValue) getFieldByIndex(i int) *StructField {
-	if s.fieldsByIndex == nil {
-		s.getFields()
-	}
+	s.getFields()
 	if OutOfRange < i && i < s.NumField() { // Try cache first
 		return s.fieldsByIndex[i]
 	}
@@ -848,9 +1553,7 @@ func (s *StructValue) getFieldByIndex(i int) *StructField {
 // getFieldByName loads and saves the struct field indentified by name n. If an error occurred finding
 // field, getFieldByName returns nil and error is saved in StructValue.
 func (s *StructValue) getFieldByName(n string) *StructField {
-	if s.fieldsByIndex == nil {
-		s.getFields()
-	}
+	s.getFields()
 	if f, ok := s.fieldsByName[n]; ok { // Try cache first
 		return f
 	}
@@ -862,6 +1565,14 @@ func (s *StructValue) getFieldByName(n string) *StructField {
 	return nil
 }
 
+// fieldByName returns the struct field called n, like getFieldByName, without
+// saving any error in StructValue.
+func (s *StructValue) fieldByName(n string) (*StructField, bool) {
+	s.getFields()
+	f, ok := s.fieldsByName[n]
+	return f, ok
+}
+
 // initFields initializes the struct fields attributes of StructValue.
 func (s *StructValue) initFields(c ...int) {
 	total := 0
@@ -877,7 +1588,7 @@ func (s *StructValue) loadField(i int, x []int, v reflect.Value, sf reflect.Stru
 	f := &StructField{
 		index:   i,
 		indexes: x,
-		value:   v,
+		value:   unlock(v, sf),
 		field:   sf,
 		Parent:  s,
 	}
@@ -885,6 +1596,56 @@ func (s *StructValue) loadField(i int, x []int, v reflect.Value, sf reflect.Stru
 	s.fieldsByName[f.Name()] = f
 }
 
+// replace sets the first n fields of the struct equal to v to new, like the
+// Replace helper, and returns how many were replaced.
+// This method is not recursive, which means that nested structs must be dealt with explicitly.
+func (s *StructValue) replace(v reflect.Value, new interface{}, n int) (int, error) {
+	c := 0
+	for {
+		if n != ReplaceAll {
+			if c >= n {
+				break
+			}
+		}
+		if i := s.contains(v); i != OutOfRange {
+			f := s.Field(i)
+			if f == nil {
+				return c, s.Err()
+			}
+			if err := f.Set(new); err != nil {
+				return c, err
+			}
+		} else {
+			break
+		}
+		c++
+	}
+	return c, nil
+}
+
+// replaceFunc sets the first n fields of the struct, searched recursively, for
+// which fn returns true to the value fn returns, like the ReplaceFunc helper,
+// and returns how many were replaced.
+// Unexported struct fields will be neglected.
+func (s *StructValue) replaceFunc(fn func(f *StructField) (interface{}, bool), n int) (int, error) {
+	c := 0
+	_, err := s.MapFields(func(f *StructField) error {
+		if n != ReplaceAll && c >= n {
+			return nil
+		}
+		x, ok := fn(f)
+		if !ok {
+			return nil
+		}
+		if err := f.Set(x); err != nil {
+			return err
+		}
+		c++
+		return nil
+	})
+	return c, err
+}
+
 // contains returns index field of struct inside interface dest.
 // This method is not recursive, which means that nested structs must be dealt with explicitly.
 // Unexported struct fields will be neglected.
@@ -892,7 +1653,7 @@ func (s *StructValue) contains(v reflect.Value) int {
 	for _, f := range s.Fields() {
 		if f.IsExported() {
 			if !f.CanStruct() {
-				if i := f.equal(v); i != OutOfRange {
+				if i := f.equal(v, 0); i != OutOfRange { // i.e. exact match
 					return f.Index()
 				}
 			}
@@ -901,6 +1662,173 @@ func (s *StructValue) contains(v reflect.Value) int {
 	return OutOfRange
 }
 
+// indexOf returns the first field, or element of a collection field, equal to
+// value v, searching nested structs recursively.
+func (s *StructValue) indexOf(v reflect.Value) *StructField {
+	for _, f := range s.Fields() {
+		if f.IsExported() {
+			if x := f.indexOf(v); x != nil {
+				return x
+			}
+		}
+	}
+	return nil
+}
+
+// scanFromValues sets the struct fields to the url values found under their
+// keys, prepended with prefix. Nil nested struct pointers are only allocated when
+// values exist for their fields.
+func (s *StructValue) scanFromValues(values url.Values, tag, prefix string) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || !f.CanSet() {
+			continue
+		}
+		key := f.TagName(tag)
+		if key == "" {
+			continue
+		}
+		key = prefix + key
+		if f.canStructType() {
+			if f.IsNil() {
+				if !hasKeyPrefix(values, key+".") {
+					continue
+				}
+				utils.PresetIndirect(f.value)
+			}
+			if err := f.Struct().scanFromValues(values, tag, key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		if x, ok := values[key]; ok {
+			if err := f.setStrings(x); err != nil {
+				return errors.Wrapf(err, "could not set column %q in %s", key, s.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// toValues adds the struct fields to url values under their keys, prepended with
+// prefix.
+func (s *StructValue) toValues(values url.Values, tag, prefix string) {
+	for _, f := range s.Fields() {
+		if !f.IsExported() || f.isHiddenBy(tag) {
+			continue
+		}
+		key := f.TagName(tag)
+		if key == "" {
+			continue
+		}
+		key = prefix + key
+		switch {
+		case f.CanStruct():
+			f.Struct().toValues(values, tag, key+".")
+		case f.canStructType(), f.IsNil():
+			continue
+		case f.CanSlice() && !f.CanBytes():
+			v := f.Indirect()
+			for i := 0; i < v.Len(); i++ {
+				values.Add(key, formatString(v.Index(i)))
+			}
+		default:
+			values.Set(key, formatString(f.value))
+		}
+	}
+}
+
+// columns returns the fields of the struct selected by o, along with their names
+// prepended with prefix, see ColumnOption.
+func (s *StructValue) columns(o *columnOptions, prefix string) (StructFields, []string) {
+	fields := make(StructFields, 0)
+	names := make([]string, 0)
+	for _, f := range s.Fields() {
+		if o.exportedOnly && !f.IsExported() {
+			continue
+		}
+		name := f.Name()
+		if o.tag != "" {
+			if name = f.TagName(o.tag); name == "" {
+				continue
+			}
+		}
+		name = prefix + name
+		if o.recursive {
+			if f.CanStruct() {
+				nf, nn := f.Struct().columns(o, name+".")
+				fields = append(fields, nf...)
+				names = append(names, nn...)
+				continue
+			}
+			if f.canStructType() {
+				continue
+			}
+		}
+		fields = append(fields, f)
+		names = append(names, name)
+	}
+	return fields, names
+}
+
+// values returns the dereferenced values of the fields of the struct selected by
+// o, recursively unless o is shallow.
+func (s *StructValue) values(o *columnOptions) (values []reflect.Value) {
+	for _, f := range s.Fields() {
+		if o.exportedOnly && !f.IsExported() {
+			continue
+		}
+		if !o.shallow && f.CanStruct() {
+			values = append(values, f.Struct().values(o)...)
+			continue
+		}
+		values = append(values, reflect.Indirect(f.value))
+	}
+	return values
+}
+
+// walk calls fn for each exported leaf field of the struct, recursively, passing
+// their dot separated names, prepended with prefix. Nil pointers to nested structs
+// are neglected. walk stops at the first error returned by fn.
+func (s *StructValue) walk(prefix string, fn func(name string, f *StructField) error) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		name := prefix + f.Name()
+		switch {
+		case f.CanStruct():
+			if err := f.Struct().walk(name+".", fn); err != nil {
+				return err
+			}
+		case f.canStructType():
+			continue
+		default:
+			if err := fn(name, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldNames returns the names of the zero-value fields of the struct if zero is
+// true, else the names of its non-zero fields, prepended with prefix.
+func (s *StructValue) fieldNames(prefix string, zero bool) []string {
+	names := make([]string, 0)
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		name := prefix + f.Name()
+		if f.CanStruct() {
+			names = append(names, f.Struct().fieldNames(name+".", zero)...)
+		} else if f.IsZero() == zero {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // getRow returns the StructRows object, which is mainly used to loop through elements of the
 // slice of structs. If s is not a slice of structs, nothing happens except saving an internal
 // error.
@@ -910,6 +1838,7 @@ func (s *StructValue) getRow(rownum int) error {
 			if OutOfRange < rownum && rownum < n {
 				//
 				// Update StructValue value
+				s.rownum = rownum
 				s.value = s.rows.Index(rownum)
 				if s.value.Kind() == reflect.Ptr {
 					s.value = s.value.Elem()
@@ -917,7 +1846,8 @@ func (s *StructValue) getRow(rownum int) error {
 				//
 				// Update StructField values
 				for _, f := range s.fieldsByIndex {
-					f.value = s.value.FieldByIndex(f.indexes)
+					f.value = unlock(fieldByIndex(s.value, f.indexes), f.field)
+					f.Parent = s
 				}
 				return s.setErr(nil)
 			}
@@ -928,14 +1858,72 @@ func (s *StructValue) getRow(rownum int) error {
 	return s.setErr(ErrNoStructs)
 }
 
+// toMap returns the exported fields of the struct indexed by the name part of
+// their struct tag key, see StructRows type ToMaps() method.
+func (s *StructValue) toMap(key string) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, f := range s.Fields() {
+		name := f.TagName(key)
+		if !f.IsExported() || name == "" || f.isHiddenBy(key) {
+			continue
+		}
+		if !f.CanStruct() || f.isJSONMarshaler() {
+			m[name] = f.mapValue()
+			continue
+		}
+		nested := f.Struct().toMap(key)
+		if f.TagHasOption(key, "inline") {
+			for k, v := range nested {
+				m[k] = v
+			}
+			continue
+		}
+		m[name] = nested
+	}
+	return m
+}
+
+// appendable returns value as a reflect value of type t, e.g. the element type
+// of a slice of structs, where t is either a struct type or a pointer to one.
+func appendable(value interface{}, t reflect.Type) (reflect.Value, error) {
+	var v reflect.Value
+	if e, ok := value.(*StructValue); ok && e != nil {
+		v = e.value
+		if v.CanAddr() {
+			v = v.Addr()
+		}
+	} else {
+		v = reflect.ValueOf(value)
+	}
+	if !v.IsValid() {
+		return v, errors.Errorf("invalid value <nil>; want: %s", t)
+	}
+	switch {
+	case v.Type() == t:
+		return v, nil
+	case t.Kind() == reflect.Ptr && v.Type() == t.Elem():
+		p := reflect.New(t.Elem())
+		p.Elem().Set(v)
+		return p, nil
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == t:
+		if v.IsNil() {
+			return v, errors.Errorf("invalid value <nil>; want: %s", t)
+		}
+		return v.Elem(), nil
+	}
+	return v, errors.Errorf("invalid value of type %s; want: %s", v.Type(), t)
+}
+
 // setErr sets error to StructValue.
 func (s *StructValue) setErr(err error) error {
+	defer s.lockErr()()
 	s.Error = err
 	return s.Error
 }
 
 // wrapErr appends error to StructValue.
 func (s *StructValue) wrapErr(err error) error {
+	defer s.lockErr()()
 	if err != nil {
 		if s.Error != nil {
 			s.Error = errors.Wrap(s.Error, err.Error())
@@ -950,6 +1938,7 @@ func (s *StructValue) wrapErr(err error) error {
 
 // setError sets mesg as error to StructValue.
 func (s *StructValue) setError(mesg string) error {
+	defer s.lockErr()()
 	if mesg != "" {
 		s.Error = errors.New(mesg)
 	} else {
@@ -960,6 +1949,7 @@ func (s *StructValue) setError(mesg string) error {
 
 // setErrors adds mesg as error to StructValue.
 func (s *StructValue) setErrors(err error, mesg string) error {
+	defer s.lockErr()()
 	if err != nil {
 		if mesg != "" {
 			s.Error = errors.Wrap(err, mesg)
@@ -984,6 +1974,31 @@ func (s *StructValue) setErrorsf(err error, format string, args ...interface{})
 	return s.setErrors(err, mesg)
 }
 
+// peekErr returns the error saved in StructValue, like Err, without resetting it.
+func (s *StructValue) peekErr() error {
+	defer s.lockErr()()
+	return s.Error
+}
+
+// lockErr locks the error of StructValue and returns the function unlocking it.
+func (s *StructValue) lockErr() func() {
+	if s.locks == nil { // i.e. not built by IndirectStruct
+		return func() {}
+	}
+	s.locks.err.Lock()
+	return s.locks.err.Unlock
+}
+
+// lockFields locks the fields of StructValue and returns the function unlocking
+// them.
+func (s *StructValue) lockFields() func() {
+	if s.locks == nil { // i.e. not built by IndirectStruct
+		return func() {}
+	}
+	s.locks.fields.Lock()
+	return s.locks.fields.Unlock
+}
+
 // destroy is the object destructor, applying zero-value to all its fields.
 func (s *StructValue) destroy() error {
 	s.value = reflect.Value{}
@@ -991,7 +2006,71 @@ func (s *StructValue) destroy() error {
 	s.kinds = nil
 	s.fieldsByIndex = nil
 	s.fieldsByName = nil
+	s.layouts = nil
+	s.tracker = nil
+	s.hooks = nil
+	s.frozen = false
+	s.strict = false
+	s.parentField = nil
+	s.rownum = OutOfRange
 	s.Parent = nil
 	s.Error = nil
 	return nil
 }
+
+// mapDeep applies handler to value v, or to the values nested inside it, if v is
+// a struct, a pointer to one or a collection of them. See MapDeep.
+func mapDeep(v reflect.Value, handler func(reflect.Value) error) error {
+	switch {
+	case v.Kind() == reflect.Ptr && isDeepType(v.Type()):
+		if v.IsNil() {
+			return nil
+		}
+		return mapDeep(v.Elem(), handler)
+	case v.Kind() == reflect.Struct && isDeepType(v.Type()):
+		_, err := IndirectStruct(v).MapDeep(handler)
+		return err
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && isDeepType(v.Type()):
+		for i := 0; i < v.Len(); i++ {
+			if err := mapDeep(v.Index(i), handler); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Kind() == reflect.Map && isDeepType(v.Type()):
+		for _, k := range v.MapKeys() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			if err := mapDeep(e, handler); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+		return nil
+	case v.CanSet():
+		return handler(v)
+	}
+	return nil
+}
+
+// isDeepType returns true if type t is a struct, other than time.Time, or holds
+// such structs, through pointers, slices, arrays or maps.
+func isDeepType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return isDeepType(t.Elem())
+	}
+	return false
+}
+
+// hasKeyPrefix returns true if any of the url values keys starts with prefix.
+func hasKeyPrefix(values url.Values, prefix string) bool {
+	for key := range values {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
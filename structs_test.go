@@ -1,9 +1,12 @@
 package structs
 
 import (
+	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/roninzo/structs/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -278,3 +281,216 @@ func TestNestedStruct(t *testing.T) {
 	assert.Equal(t, int64(123456), s1.Field("B").Int())
 	assert.Equal(t, true, s1.Field("C").Bool())
 }
+
+func TestStructAppend(t *testing.T) {
+	type testStruct struct {
+		Name string
+	}
+
+	var ts []testStruct
+	s, err := New(&ts)
+	assert.Equal(t, nil, err)
+	err = s.Append(&testStruct{Name: "Apache"}, testStruct{Name: "Nginx"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []testStruct{{Name: "Apache"}, {Name: "Nginx"}}, ts)
+	assert.Equal(t, "Apache", s.Field("Name").String())
+	err = s.Append((*testStruct)(nil))
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, cap(ts), s.Cap())
+
+	s, err = New(ts)
+	assert.Equal(t, nil, err)
+	err = s.Append(testStruct{})
+	assert.Equal(t, ErrNotSettable, errors.Cause(err))
+
+	s, err = New(&ts)
+	assert.Equal(t, nil, err)
+	err = s.Freeze().Append(testStruct{})
+	assert.Equal(t, ErrReadOnly, errors.Cause(err))
+
+	s, err = New(&ts[0])
+	assert.Equal(t, nil, err)
+	err = s.Append(testStruct{})
+	assert.Equal(t, ErrNoStructs, errors.Cause(err))
+	assert.Equal(t, OutOfRange, s.Len())
+	assert.Equal(t, OutOfRange, s.Cap())
+}
+
+func TestStructSet(t *testing.T) {
+	type Program struct {
+		Name    string
+		Version string
+		note    string
+	}
+	type Server struct {
+		Name    string
+		Program Program
+	}
+	server := Server{Name: "Apache", Program: Program{Name: "httpd", Version: "2.2", note: "kept"}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	var hooked []string
+	s.Track().OnSet(func(f *StructField, old, new interface{}) {
+		hooked = append(hooked, f.Namespace())
+	})
+
+	p := s.Field("Program").Struct()
+	err = p.Set(Program{Name: "nginx", Version: "1.21", note: "lost"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Program{Name: "nginx", Version: "1.21", note: "kept"}, server.Program)
+	assert.Equal(t, []string{"Program.Name", "Program.Version"}, s.Changed())
+	assert.Equal(t, []string{"Program.Name", "Program.Version"}, hooked)
+
+	err = s.Freeze().Field("Program").Struct().Set(Program{Name: "lighttpd"})
+	assert.Equal(t, ErrReadOnly, errors.Cause(err))
+	assert.Equal(t, "nginx", server.Program.Name)
+}
+
+func TestStructWhereClause(t *testing.T) {
+	type Server struct {
+		Name   string            `db:"name"`
+		ID     uint              `db:"id"`
+		Tags   []string          `db:"tags"`
+		Ports  [2]int            `db:"ports"`
+		Hash   []byte            `db:"hash"`
+		Labels map[string]string `db:"labels"`
+		Hosts  []string          `db:"hosts"`
+	}
+	server := Server{
+		Name:   "Roninzo",
+		ID:     3,
+		Tags:   []string{"x", "y"},
+		Ports:  [2]int{80, 443},
+		Hash:   []byte("ab"),
+		Labels: map[string]string{"app": "web"},
+		Hosts:  []string{},
+	}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	where, args := s.WhereClause("db")
+	assert.Equal(t, "name = ? AND id = ? AND tags IN (?, ?) AND ports IN (?, ?) AND hash = ?", where)
+	assert.Equal(t, []interface{}{"Roninzo", uint(3), "x", "y", 80, 443, []byte("ab")}, args)
+}
+
+func TestFreeze(t *testing.T) {
+	type Server struct {
+		Name  string `default:"Apache"`
+		Port  int    `default:"80"`
+		Ports []int
+	}
+	server := Server{Ports: []int{80}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	s.Freeze()
+
+	err = s.ApplyDefaults()
+	assert.Equal(t, ErrReadOnly, errors.Cause(err))
+	assert.Equal(t, Server{Ports: []int{80}}, server)
+
+	assert.Equal(t, false, s.Value().CanSet())
+	assert.Equal(t, false, s.Field("Name").Value().CanSet())
+	assert.Equal(t, false, s.Field("Port").Indirect().CanSet())
+	assert.Equal(t, Server{Ports: []int{80}}, s.Value().Interface())
+	assert.Equal(t, true, s.Field("Ports").Value().Index(0).CanSet()) // shared
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	type Program struct {
+		Name string
+	}
+	type Server struct {
+		Name    string
+		Ports   []int
+		Program *Program
+	}
+	server := Server{Name: "web", Ports: []int{80, 443}, Program: &Program{Name: "Apache"}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.Equal(t, "web", s.Field("Name").String())
+				assert.Equal(t, 3, s.NumField())
+				assert.Equal(t, "Apache", s.Field("Program").Struct().Field("Name").String())
+				v, err := s.Get("Ports[1]")
+				assert.Equal(t, nil, err)
+				assert.Equal(t, int64(443), v)
+				_, err = s.FieldE("Nope")
+				assert.Equal(t, ErrNoField, errors.Cause(err))
+				f, err := s.FieldE(0)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, "Name", f.Name())
+				s.Field("Nope")
+				s.Err()
+			}
+		}()
+	}
+	wg.Wait()
+
+	type Secret struct {
+		Name  string
+		token string
+	}
+	s, err = New(&Secret{Name: "web", token: "t0k3n"})
+	assert.Equal(t, nil, err)
+	v, err := s.Field("token").ValueE()
+	assert.Equal(t, ErrNotExported, errors.Cause(err))
+	assert.Equal(t, "", v.String())
+	v, err = s.Field("Name").ValueE()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "web", v.String())
+	assert.Equal(t, nil, s.Err())
+}
+
+func TestStructDebugLevels(t *testing.T) {
+	type T1 struct {
+		Name  string `json:"name" db:"nm"`
+		Count int
+		note  string
+	}
+
+	s, err := New(&T1{Name: "test", Count: 2, note: "hidden"})
+	assert.Equal(t, nil, err)
+
+	debug := func(level ...int) (d struct {
+		Fields json.RawMessage `json:"fields"`
+	}) {
+		assert.Equal(t, nil, json.Unmarshal([]byte(s.Debug(level...)), &d))
+		return
+	}
+	var names map[int]string
+	assert.Equal(t, nil, json.Unmarshal(debug().Fields, &names))
+	assert.Equal(t, map[int]string{0: "Name", 1: "Count", 2: "note"}, names)
+	names = nil
+	assert.Equal(t, nil, json.Unmarshal(debug(0).Fields, &names))
+	assert.Equal(t, map[int]string{0: "Name", 1: "Count", 2: "note"}, names)
+
+	var fields []map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(debug(1).Fields, &fields))
+	assert.Equal(t, 3, len(fields))
+	assert.Equal(t, map[string]interface{}{"name": "Name", "value": "test"}, fields[0])
+	assert.Equal(t, map[string]interface{}{"name": "note", "value": nil}, fields[2])
+
+	fields = nil
+	assert.Equal(t, nil, json.Unmarshal(debug(2).Fields, &fields))
+	assert.Equal(t, "string", fields[0]["type"])
+	assert.Equal(t, "int", fields[1]["kind"])
+	assert.Equal(t, map[string]interface{}{"json": "name", "db": "nm"}, fields[0]["tags"])
+	assert.Equal(t, float64(1), fields[1]["index"])
+	assert.Equal(t, []interface{}{float64(1)}, fields[1]["indexes"])
+	assert.Equal(t, nil, fields[1]["can_set"])
+
+	fields = nil
+	assert.Equal(t, nil, json.Unmarshal(debug(3).Fields, &fields))
+	assert.Regexp(t, "^0x[0-9a-f]+$", fields[0]["address"])
+	assert.Equal(t, true, fields[0]["can_set"])
+	assert.Equal(t, false, fields[2]["can_set"])
+	assert.Equal(t, false, fields[2]["exported"])
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+/*   T y p e   d e f i n i t i o n   */
+
+// Change represents the change of value of a struct field, as recorded by a
+// tracked StructValue. Pointer fields are recorded by the values they point to,
+// and nil pointers as nil.
+type Change struct {
+	Name string      // Path of the field, e.g. "Program.Name", see Namespace.
+	Old  interface{} // Value of the field before the first change.
+	New  interface{} // Value of the field after the last change.
+}
+
+// AuditEntry represents a change of value of a struct field attributed to an
+// actor, as returned by AuditLog, so that it can be persisted.
+type AuditEntry struct {
+	Actor string      `json:"actor"` // Who made the change, e.g. a user name.
+	Name  string      `json:"name"`  // Path of the field, e.g. "Program.Count".
+	Old   interface{} `json:"old"`   // Value of the field before the first change.
+	New   interface{} `json:"new"`   // Value of the field after the last change.
+	At    time.Time   `json:"at"`    // Time of the last change.
+}
+
+// tracker records the changes made to the fields of a struct.
+type tracker struct {
+	names   []string             // Paths of changed fields, in order of first change.
+	changes map[string]*Change   // Changes by field paths.
+	times   map[string]time.Time // Times of last change by field paths.
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Track starts tracking changes made through the setter methods of the struct
+// fields, including the ones of its nested structs, e.g. to build UPDATE
+// statements or audit logs. Calling Track again resets changes recorded so far.
+// Track is carried out on the top level struct, even if s is a nested struct.
+func (s *StructValue) Track() *StructValue {
+	s.root().tracker = &tracker{changes: make(map[string]*Change), times: make(map[string]time.Time)}
+	return s
+}
+
+// IsTracked returns true if changes made to the struct fields are tracked.
+func (s *StructValue) IsTracked() bool {
+	return s.root().tracker != nil
+}
+
+// Changed returns the paths of the fields changed since Track was called, e.g.
+// "Program.Name", in order of first change. A field set back to its original
+// value is not considered changed.
+func (s *StructValue) Changed() []string {
+	t := s.root().tracker
+	if t == nil {
+		return nil
+	}
+	names := make([]string, 0, len(t.names))
+	for _, n := range t.names {
+		if t.isChanged(n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// ChangedValues returns the old and new values of the fields changed since
+// Track was called, in the same order as Changed.
+func (s *StructValue) ChangedValues() []Change {
+	t := s.root().tracker
+	if t == nil {
+		return nil
+	}
+	changes := make([]Change, 0, len(t.names))
+	for _, n := range t.names {
+		if t.isChanged(n) {
+			changes = append(changes, *t.changes[n])
+		}
+	}
+	return changes
+}
+
+// AuditLog returns the changes of values of the fields changed since Track was
+// called, in the same order as Changed, attributed to actor and stamped with the
+// time of their last change, e.g. to persist them in an audit trail. It returns
+// nil if the struct is not tracked.
+func (s *StructValue) AuditLog(actor string) []AuditEntry {
+	t := s.root().tracker
+	if t == nil {
+		return nil
+	}
+	entries := make([]AuditEntry, 0, len(t.names))
+	for _, c := range s.ChangedValues() {
+		entries = append(entries, AuditEntry{
+			Actor: actor,
+			Name:  c.Name,
+			Old:   c.Old,
+			New:   c.New,
+			At:    t.times[c.Name],
+		})
+	}
+	return entries
+}
+
+// String returns the entry as a human-readable sentence, e.g.
+// `roninzo changed Program.Count from 5 to 6 at 2021-08-31T14:11:11Z`. Values
+// are rendered as json, see Sprint.
+func (e AuditEntry) String() string {
+	return fmt.Sprintf("%s changed %s from %s to %s at %s", e.Actor, e.Name, SprintCompact(e.Old), SprintCompact(e.New), e.At.Format(time.RFC3339))
+}
+
+/*   U n e x p o r t e d   */
+
+// record keeps track of the change of value of field name, from old to new.
+func (t *tracker) record(name string, old, new interface{}) {
+	t.times[name] = time.Now()
+	c, ok := t.changes[name]
+	if !ok {
+		t.names = append(t.names, name)
+		t.changes[name] = &Change{Name: name, Old: old, New: new}
+		return
+	}
+	c.New = new
+}
+
+// isChanged returns true if the value of field name differs from its original.
+func (t *tracker) isChanged(name string) bool {
+	c := t.changes[name]
+	return !reflect.DeepEqual(c.Old, c.New)
+}
+
+// snapshot returns a copy of the value v, which outlives later changes made to
+// v. Pointers are dereferenced, while slices and maps are copied.
+func snapshot(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		x := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(x, v)
+		return x.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		x := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, k := range v.MapKeys() {
+			x.SetMapIndex(k, v.MapIndex(k))
+		}
+		return x.Interface()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,131 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	type Program struct {
+		Name string
+	}
+	type Server struct {
+		Name    string
+		Port    *int
+		Ports   []int
+		Program Program
+	}
+
+	server := Server{Name: "Roninzo", Ports: []int{80}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, s.IsTracked())
+	assert.Equal(t, []string(nil), s.Changed())
+
+	assert.Equal(t, nil, s.Field("Name").Set("Apache"))
+	s.Track()
+	assert.Equal(t, true, s.IsTracked())
+	assert.Equal(t, []string{}, s.Changed())
+
+	assert.Equal(t, nil, s.Field("Name").Set("Nginx"))
+	assert.Equal(t, nil, s.Field("Port").Set(8080))
+	assert.Equal(t, nil, s.Field("Ports").Append(443))
+	assert.Equal(t, nil, s.Field("Program").Struct().Field("Name").Set("IIS"))
+	s.Field("Name").SetString("Caddy")
+	assert.Equal(t, []string{"Name", "Port", "Ports", "Program.Name"}, s.Changed())
+	assert.Equal(t, []Change{
+		{Name: "Name", Old: "Apache", New: "Caddy"},
+		{Name: "Port", Old: nil, New: 8080},
+		{Name: "Ports", Old: []int{80}, New: []int{80, 443}},
+		{Name: "Program.Name", Old: "", New: "IIS"},
+	}, s.ChangedValues())
+
+	assert.Equal(t, nil, s.Field("Name").Set("Apache"))
+	assert.Equal(t, nil, s.Field("Port").SetNil())
+	assert.Equal(t, []string{"Ports", "Program.Name"}, s.Changed())
+
+	_, err = s.Field("Ports").IntE()
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, []string{"Ports", "Program.Name"}, s.Changed())
+}
+
+func TestTrackSameNestedType(t *testing.T) {
+	type Program struct {
+		Name string
+	}
+	type Server struct {
+		Primary Program
+		Backup  Program
+	}
+
+	server := Server{Primary: Program{Name: "Apache"}, Backup: Program{Name: "Nginx"}}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	s.Track()
+
+	assert.Equal(t, nil, s.Field("Primary").Struct().Field("Name").Set("IIS"))
+	assert.Equal(t, nil, s.Field("Backup").Struct().Field("Name").Set("Caddy"))
+	assert.Equal(t, []Change{
+		{Name: "Primary.Name", Old: "Apache", New: "IIS"},
+		{Name: "Backup.Name", Old: "Nginx", New: "Caddy"},
+	}, s.ChangedValues())
+}
+
+func TestAuditLog(t *testing.T) {
+	type Server struct {
+		Name  string
+		Count int
+	}
+
+	server := Server{Name: "Roninzo", Count: 5}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []AuditEntry(nil), s.AuditLog("roninzo"))
+
+	s.Track()
+	before := time.Now()
+	assert.Equal(t, nil, s.Field("Count").Set(6))
+	assert.Equal(t, nil, s.Field("Name").Set("Apache"))
+	assert.Equal(t, nil, s.Field("Name").Set("Roninzo"))
+
+	entries := s.AuditLog("roninzo")
+	assert.Equal(t, 1, len(entries))
+	e := entries[0]
+	assert.Equal(t, "roninzo", e.Actor)
+	assert.Equal(t, "Count", e.Name)
+	assert.Equal(t, 5, e.Old)
+	assert.Equal(t, 6, e.New)
+	assert.False(t, e.At.Before(before))
+
+	e.At = time.Date(2021, 8, 31, 14, 11, 11, 0, time.UTC)
+	assert.Equal(t, "roninzo changed Count from 5 to 6 at 2021-08-31T14:11:11Z", e.String())
+	e.Old, e.New = "Apache", nil
+	assert.Equal(t, `roninzo changed Count from "Apache" to null at 2021-08-31T14:11:11Z`, e.String())
+}
+
+func TestAuditLogSameNestedType(t *testing.T) {
+	type Program struct {
+		Count int
+	}
+	type Server struct {
+		Primary Program
+		Backup  Program
+	}
+
+	s, err := New(&Server{})
+	assert.Equal(t, nil, err)
+	s.Track()
+	assert.Equal(t, nil, s.Field("Primary").Struct().Field("Count").Set(1))
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, nil, s.Field("Backup").Struct().Field("Count").Set(2))
+
+	entries := s.AuditLog("roninzo")
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "Primary.Count", entries[0].Name)
+	assert.Equal(t, 1, entries[0].New)
+	assert.Equal(t, "Backup.Count", entries[1].Name)
+	assert.Equal(t, 2, entries[1].New)
+	assert.True(t, entries[0].At.Before(entries[1].At))
+}
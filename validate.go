@@ -0,0 +1,306 @@
+// Copyright 2021 Roninzo. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/roninzo/structs/utils"
+)
+
+/*   S t r u c t   d e f i n i t i o n   */
+
+// ValidationError reports a struct field failing one of the rules declared in
+// its validate struct tag.
+type ValidationError struct {
+	Field string // Path of the field, such as "Program.Name", see Namespace.
+	Rule  string // Rule that failed, such as "min=3".
+	Mesg  string // Description of the failure.
+}
+
+// ValidationErrors is the list of all ValidationError found while validating
+// a struct.
+type ValidationErrors []*ValidationError
+
+// rule is a single validate rule parsed out of a validate struct tag.
+type rule struct {
+	name  string
+	param string
+}
+
+/*   I m p l e m e n t a t i o n   */
+
+// Validate checks the struct fields against the rules declared in their validate
+// struct tag, recursively. It returns nil if all fields are valid, ValidationErrors
+// listing every field that failed and why, or any other error if a rule could not
+// be understood.
+//
+// Rules are comma separated and can be any of:
+//
+//	required    the field must not be a zero-value.
+//	min=n       numbers must be at least n, strings, slices and maps
+//	            must have a length of at least n.
+//	max=n       numbers must be at most n, strings, slices and maps
+//	            must have a length of at most n.
+//	len=n       strings, slices and maps must have a length of exactly n.
+//	oneof=a b   the field value must be one of the space separated values.
+//	regexp=re   strings must match the regular expression re.
+//
+// The regexp rule consumes the rest of the tag and must therefore come last,
+// e.g. `validate:"required,max=8,regexp=^[a-z]+$"`. Durations accept duration
+// parameters, e.g. `validate:"min=1s"`. Nil pointers are only checked against
+// the required rule.
+// Unexported struct fields will be neglected.
+func (s *StructValue) Validate() error {
+	errs := make(ValidationErrors, 0)
+	if err := s.validate(&errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MissingRequired returns the paths of the zero-value fields that are required,
+// recursively, e.g. "Program.Name", see Namespace. A field is required when
+// tagged `required:"true"` or when its validate struct tag declares the required
+// rule.
+// Unexported struct fields will be neglected.
+func (s *StructValue) MissingRequired() []string {
+	names := make([]string, 0)
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		if f.isRequired() && f.IsZero() {
+			names = append(names, f.Namespace())
+		}
+		if f.CanStruct() {
+			names = append(names, f.Struct().MissingRequired()...)
+		}
+	}
+	return names
+}
+
+// Error returns the description of the validation failure.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("field %s %s", e.Field, e.Mesg)
+}
+
+// Error returns all validation failures descriptions separated by semicolons.
+func (errs ValidationErrors) Error() string {
+	mesgs := make([]string, len(errs))
+	for i, e := range errs {
+		mesgs[i] = e.Error()
+	}
+	return strings.Join(mesgs, "; ")
+}
+
+// Fields returns the paths of the fields that failed validation, in order.
+func (errs ValidationErrors) Fields() []string {
+	names := make([]string, len(errs))
+	for i, e := range errs {
+		names[i] = e.Field
+	}
+	return names
+}
+
+/*   U n e x p o r t e d   */
+
+// validate appends the validation failures of the struct fields to errs.
+func (s *StructValue) validate(errs *ValidationErrors) error {
+	for _, f := range s.Fields() {
+		if !f.IsExported() {
+			continue
+		}
+		rules, err := f.rules()
+		if err != nil {
+			return err
+		}
+		for _, r := range rules {
+			mesg, err := f.check(r)
+			if err != nil {
+				return errors.Wrapf(err, "invalid validate rule %q on field %s", r, f.Namespace())
+			}
+			if mesg != "" {
+				*errs = append(*errs, &ValidationError{Field: f.Namespace(), Rule: r.String(), Mesg: mesg})
+			}
+		}
+		if f.CanStruct() {
+			if err := f.Struct().validate(errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rules parses the validate struct tag of the field.
+func (f *StructField) rules() ([]rule, error) {
+	tag, ok := f.Tag("validate")
+	if !ok || tag == "" || tag == "-" {
+		return nil, nil
+	}
+	rules := make([]rule, 0)
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "regexp=") {
+			part, tag = tag, ""
+		} else if i := strings.Index(tag, ","); i != OutOfRange {
+			part, tag = tag[:i], tag[i+1:]
+		} else {
+			part, tag = tag, ""
+		}
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r := rule{name: part}
+		if i := strings.Index(part, "="); i != OutOfRange {
+			r.name, r.param = part[:i], part[i+1:]
+		}
+		switch r.name {
+		case "required", "min", "max", "len", "oneof", "regexp":
+		default:
+			return nil, errors.Errorf("unknown validate rule %q on field %s", r.name, f.Namespace())
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// hasRule returns true if the validate struct tag of the field declares the
+// rule called name.
+func (f *StructField) hasRule(name string) bool {
+	rules, _ := f.rules()
+	for _, r := range rules {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired returns true if the field is tagged `required:"true"` or if its
+// validate struct tag declares the required rule.
+func (f *StructField) isRequired() bool {
+	if tag, ok := f.Tag("required"); ok {
+		if required, err := strconv.ParseBool(tag); err == nil && required {
+			return true
+		}
+	}
+	return f.hasRule("required")
+}
+
+// check returns the description of why the field fails rule r, or zero-value
+// string if the field passes it. It returns an error if rule r does not apply
+// to the field.
+func (f *StructField) check(r rule) (string, error) {
+	if r.name == "required" {
+		if f.IsZero() {
+			return "is required", nil
+		}
+		return "", nil
+	}
+	if utils.CanPtr(f.value) && f.IsNil() {
+		return "", nil
+	}
+	v := f.Indirect()
+	switch r.name {
+	case "min", "max":
+		n, isLen, err := measure(v)
+		if err != nil {
+			return "", err
+		}
+		x, err := strconv.ParseFloat(r.param, 64)
+		if err != nil && utils.CanDuration(v) {
+			var d time.Duration
+			d, err = time.ParseDuration(r.param)
+			x = float64(d)
+		}
+		if err != nil {
+			return "", err
+		}
+		what := "be"
+		if isLen {
+			what = "have a length of"
+		}
+		if r.name == "min" && n < x {
+			return fmt.Sprintf("must %s at least %s", what, r.param), nil
+		}
+		if r.name == "max" && n > x {
+			return fmt.Sprintf("must %s at most %s", what, r.param), nil
+		}
+	case "len":
+		n, isLen, err := measure(v)
+		if err != nil {
+			return "", err
+		}
+		if !isLen {
+			return "", errors.Errorf("rule does not apply to %q", v.Type())
+		}
+		x, err := strconv.Atoi(r.param)
+		if err != nil {
+			return "", err
+		}
+		if int(n) != x {
+			return fmt.Sprintf("must have a length of %d", x), nil
+		}
+	case "oneof":
+		txt := formatString(v)
+		for _, x := range strings.Fields(r.param) {
+			if x == txt {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of [%s]", r.param), nil
+	case "regexp":
+		if !utils.CanString(v) {
+			return "", errors.Errorf("rule does not apply to %q", v.Type())
+		}
+		re, err := regexp.Compile(r.param)
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(v.String()) {
+			return fmt.Sprintf("must match %s", r.param), nil
+		}
+	}
+	return "", nil
+}
+
+// measure returns the numeric value of v, or its length for strings, slices and
+// maps, in which case isLen is true.
+func measure(v reflect.Value) (n float64, isLen bool, err error) {
+	switch {
+	case utils.CanString(v):
+		return float64(utf8.RuneCountInString(v.String())), true, nil
+	case utils.CanSlice(v), utils.CanMap(v):
+		return float64(v.Len()), true, nil
+	case utils.CanInt(v):
+		return float64(v.Int()), false, nil
+	case utils.CanUint(v):
+		return float64(v.Uint()), false, nil
+	case utils.CanFloat(v):
+		return v.Float(), false, nil
+	}
+	return 0, false, errors.Errorf("rule does not apply to %q", v.Type())
+}
+
+// String returns the rule as declared in the validate struct tag.
+func (r rule) String() string {
+	if r.param != "" {
+		return r.name + "=" + r.param
+	}
+	return r.name
+}
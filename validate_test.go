@@ -0,0 +1,98 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	type Program struct {
+		Name string `validate:"required"`
+	}
+
+	type Server struct {
+		Name    string            `validate:"required,min=3,max=8,regexp=^[a-z]+$"`
+		Count   int               `validate:"min=1,max=10"`
+		Mode    string            `validate:"oneof=dev prod"`
+		Tags    []string          `validate:"len=2"`
+		Labels  map[string]string `validate:"max=1"`
+		Timeout time.Duration     `validate:"min=1s"`
+		Owner   *string           `validate:"min=2"`
+		Program *Program          `validate:"required"`
+		Backup  Program
+		Primary Program
+	}
+
+	server := Server{
+		Name:    "roninzo",
+		Count:   5,
+		Mode:    "prod",
+		Tags:    []string{"a", "b"},
+		Timeout: time.Minute,
+		Program: &Program{Name: "Apache"},
+		Backup:  Program{Name: "Nginx"},
+		Primary: Program{Name: "IIS"},
+	}
+	s, err := New(&server)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Validate())
+
+	server = Server{
+		Name:    "Roninzo-Server",
+		Count:   11,
+		Mode:    "test",
+		Tags:    []string{"a"},
+		Labels:  map[string]string{"a": "1", "b": "2"},
+		Timeout: time.Millisecond,
+		Backup:  Program{Name: "Nginx"},
+		Primary: Program{},
+	}
+	s, err = New(&server)
+	assert.Equal(t, nil, err)
+	err = s.Validate()
+	errs, ok := err.(ValidationErrors)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, []string{
+		"Name",
+		"Name",
+		"Count",
+		"Mode",
+		"Tags",
+		"Labels",
+		"Timeout",
+		"Program",
+		"Primary.Name",
+	}, errs.Fields())
+	assert.Equal(t, "field Name must have a length of at most 8", errs[0].Error())
+	assert.Equal(t, "regexp=^[a-z]+$", errs[1].Rule)
+	assert.Equal(t, "field Program is required", errs[7].Error())
+	assert.Equal(t, "field Primary.Name is required", errs[8].Error())
+
+	type Invalid struct {
+		Name string `validate:"unknown"`
+	}
+	s, err = New(&Invalid{})
+	assert.Equal(t, nil, err)
+	err = s.Validate()
+	_, ok = err.(ValidationErrors)
+	assert.Equal(t, false, ok)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMissingRequired(t *testing.T) {
+	type Program struct {
+		Name string `required:"true"`
+	}
+
+	type Server struct {
+		Name    string `validate:"required"`
+		Primary Program
+		Backup  *Program
+	}
+
+	s, err := New(&Server{Primary: Program{Name: "Apache"}, Backup: &Program{}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"Name", "Backup.Name"}, s.MissingRequired())
+}